@@ -0,0 +1,18 @@
+package main
+
+import (
+	"fmt"
+	"github-connector/internal/auth"
+	"github-connector/internal/httpcache"
+)
+
+// newCachedClient wraps authClient with the ETag cache persisted in plugin vars.
+func newCachedClient(authClient auth.Client) *httpcache.Client {
+	return httpcache.NewClient(authClient, httpcache.NewVarStore())
+}
+
+// logCacheStats logs the cache hit/miss counts; called at the end of each export.
+func logCacheStats(export string, client *httpcache.Client) {
+	hits, misses := client.Stats()
+	logger.Info(fmt.Sprintf("%s: HTTP cache %d hits, %d misses", export, hits, misses))
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github-connector/internal/core"
+	"github-connector/internal/paginate"
+	"strconv"
+	"strings"
+)
+
+// discussionCommentsQuery lists the discussion comments authored by a user. The connection has no
+// orderBy and lists comments oldest first, so it is read from the end with last/before.
+const discussionCommentsQuery = `query($login: String!, $before: String) {
+  user(login: $login) {
+    repositoryDiscussionComments(last: 50, before: $before) {
+      pageInfo { hasPreviousPage startCursor }
+      nodes {
+        id databaseId url body createdAt isAnswer
+        replyTo { id }
+        discussion {
+          number title url
+          category { name }
+          repository { nameWithOwner }
+        }
+      }
+    }
+  }
+}`
+
+func (c *fetchHTTPClient) FetchDiscussionComments(username string, opts paginate.Options) ([]map[string]any, error) {
+	result, err := paginate.ListCursorBackward(opts, func(cursor string) ([]map[string]any, string, error) {
+		variables := map[string]any{"login": username}
+		if cursor != "" {
+			variables["before"] = cursor
+		}
+
+		var data struct {
+			User *struct {
+				RepositoryDiscussionComments struct {
+					PageInfo struct {
+						HasPreviousPage bool   `json:"hasPreviousPage"`
+						StartCursor     string `json:"startCursor"`
+					} `json:"pageInfo"`
+					Nodes []map[string]any `json:"nodes"`
+				} `json:"repositoryDiscussionComments"`
+			} `json:"user"`
+		}
+		if err := graphQL(c.authClient, discussionCommentsQuery, variables, &data); err != nil {
+			return nil, "", err
+		}
+		if data.User == nil {
+			return nil, "", fmt.Errorf("user %s not found", username)
+		}
+
+		comments := data.User.RepositoryDiscussionComments
+		previous := ""
+		if comments.PageInfo.HasPreviousPage {
+			previous = comments.PageInfo.StartCursor
+		}
+		return comments.Nodes, previous, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Truncated {
+		logger.Warn(fmt.Sprintf("Stopped listing discussion comments of %s at the pagination limit (%d pages, %d items)", username, result.Pages, len(result.Items)))
+	}
+
+	return result.Items, nil
+}
+
+func (c *enrichHTTPClient) FetchGist(gistID string) (map[string]any, error) {
+	url := fmt.Sprintf("%s/gists/%s", core.GithubAPIBaseURL, gistID)
+	body, status, err := c.authClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	if status != 200 {
+		return nil, fmt.Errorf("GitHub API error (status %d): %s", status, string(body))
+	}
+
+	var apiResp map[string]any
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse API response: %w", err)
+	}
+
+	return apiResp, nil
+}
+
+// discussionQuery fetches a discussion with its category, chosen answer and labels
+const discussionQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    discussion(number: $number) {
+      number title body url createdAt updatedAt closed locked upvoteCount
+      isAnswered answerChosenAt
+      author { login }
+      category { name isAnswerable }
+      answer { url author { login } }
+      comments { totalCount }
+      labels(first: 20) { nodes { name } }
+    }
+  }
+}`
+
+func (c *enrichHTTPClient) FetchDiscussion(repo, number string) (map[string]any, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository name: %s", repo)
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return nil, fmt.Errorf("invalid discussion number: %s", number)
+	}
+
+	var data struct {
+		Repository struct {
+			Discussion map[string]any `json:"discussion"`
+		} `json:"repository"`
+	}
+	variables := map[string]any{"owner": owner, "name": name, "number": n}
+	if err := graphQL(c.authClient, discussionQuery, variables, &data); err != nil {
+		return nil, err
+	}
+	if data.Repository.Discussion == nil {
+		return nil, fmt.Errorf("discussion %s #%s not found", repo, number)
+	}
+
+	return data.Repository.Discussion, nil
+}
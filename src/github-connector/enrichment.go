@@ -6,6 +6,9 @@ import (
 	"github-connector/internal/auth"
 	"github-connector/internal/core"
 	"github-connector/internal/enrich"
+	"github-connector/internal/paginate"
+	"strconv"
+	"strings"
 )
 
 // EnrichContext enriches the given context with data from GitHub API
@@ -32,7 +35,10 @@ func EnrichContext(input EnrichRequest) (EnrichResponse, error) {
 		return EnrichResponse{}, fmt.Errorf("failed to initialize auth client: %w", err)
 	}
 
-	enricher, err := enrich.NewContextEnricher(&enrichHTTPClient{authClient: authClient}, contextType, config, enrichmentParams, logger)
+	cachedClient := newCachedClient(authClient)
+	defer logCacheStats("EnrichContext", cachedClient)
+
+	enricher, err := enrich.NewContextEnricher(&enrichHTTPClient{authClient: cachedClient}, contextType, config, enrichmentParams, logger)
 	if err != nil {
 		return EnrichResponse{}, fmt.Errorf("failed to create context enricher: %w", err)
 	}
@@ -160,3 +166,128 @@ func (c *enrichHTTPClient) FetchIssue(repo, number string) (map[string]any, erro
 
 	return apiResp, nil
 }
+
+func (c *enrichHTTPClient) FetchIssueTimeline(repo, number string) ([]map[string]any, error) {
+	url := fmt.Sprintf("%s/repos/%s/issues/%s/timeline?per_page=100", core.GithubAPIBaseURL, repo, number)
+	return listPages(c.authClient, url, paginate.Options{}, paginate.DecodeArray)
+}
+
+func (c *enrichHTTPClient) FetchWorkflow(repo, workflowFile string) (map[string]any, error) {
+	url := fmt.Sprintf("%s/repos/%s/actions/workflows/%s", core.GithubAPIBaseURL, repo, workflowFile)
+	body, status, err := c.authClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	if status != 200 {
+		return nil, fmt.Errorf("GitHub API error (status %d): %s", status, string(body))
+	}
+
+	var apiResp map[string]any
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse API response: %w", err)
+	}
+
+	return apiResp, nil
+}
+
+func (c *enrichHTTPClient) FetchWorkflowRun(repo, runID string) (map[string]any, error) {
+	url := fmt.Sprintf("%s/repos/%s/actions/runs/%s", core.GithubAPIBaseURL, repo, runID)
+	body, status, err := c.authClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	if status != 200 {
+		return nil, fmt.Errorf("GitHub API error (status %d): %s", status, string(body))
+	}
+
+	var apiResp map[string]any
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse API response: %w", err)
+	}
+
+	return apiResp, nil
+}
+
+// reviewThreadsQuery lists the review threads of a pull request. Only the first 100 comments of
+// each thread are loaded, which covers the conversations found in practice.
+const reviewThreadsQuery = `query($owner: String!, $name: String!, $number: Int!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100, after: $after) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          id isResolved isOutdated path line startLine diffSide
+          resolvedBy { login }
+          comments(first: 100) {
+            totalCount
+            nodes { databaseId body url createdAt diffHunk author { login } }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// maxReviewThreadPages bounds the pages of review threads scanned for a single comment
+const maxReviewThreadPages = 10
+
+func (c *enrichHTTPClient) FetchReviewThread(repo, prNumber, commentID string) (map[string]any, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository name: %s", repo)
+	}
+	number, err := strconv.Atoi(prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pull request number: %s", prNumber)
+	}
+	id, err := strconv.ParseInt(commentID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid comment id: %s", commentID)
+	}
+
+	variables := map[string]any{"owner": owner, "name": name, "number": number}
+	for page := 0; page < maxReviewThreadPages; page++ {
+		var data struct {
+			Repository struct {
+				PullRequest struct {
+					ReviewThreads struct {
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+						Nodes []map[string]any `json:"nodes"`
+					} `json:"reviewThreads"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		if err := graphQL(c.authClient, reviewThreadsQuery, variables, &data); err != nil {
+			return nil, err
+		}
+
+		threads := data.Repository.PullRequest.ReviewThreads
+		for _, thread := range threads.Nodes {
+			if threadContainsComment(thread, id) {
+				return thread, nil
+			}
+		}
+		if !threads.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = threads.PageInfo.EndCursor
+	}
+
+	return nil, fmt.Errorf("review thread with comment %s not found in %s #%s", commentID, repo, prNumber)
+}
+
+// threadContainsComment reports whether a GraphQL review thread includes the comment
+func threadContainsComment(thread map[string]any, commentID int64) bool {
+	comments, _ := thread["comments"].(map[string]any)
+	nodes, _ := comments["nodes"].([]any)
+	for _, node := range nodes {
+		comment, _ := node.(map[string]any)
+		if id, ok := comment["databaseId"].(float64); ok && int64(id) == commentID {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,8 @@ import (
 	"github-connector/internal/auth"
 	"github-connector/internal/core"
 	"github-connector/internal/fetch"
+	"github-connector/internal/paginate"
+	"net/url"
 
 	"github.com/extism/go-pdk"
 )
@@ -24,7 +26,10 @@ func FetchActivities(input FetchRequest) (FetchResponse, error) {
 		return FetchResponse{}, fmt.Errorf("failed to initialize auth client: %w", err)
 	}
 
-	fetcher, err := fetch.NewActivityFetcher(&fetchHTTPClient{authClient: authClient}, config, input.Params.TargetDate, logger)
+	cachedClient := newCachedClient(authClient)
+	defer logCacheStats("FetchActivities", cachedClient)
+
+	fetcher, err := fetch.NewActivityFetcher(&fetchHTTPClient{authClient: cachedClient}, config, input.Params.TargetDate, logger)
 	if err != nil {
 		return FetchResponse{}, fmt.Errorf("failed to create activity fetcher: %w", err)
 	}
@@ -73,10 +78,15 @@ type fetchHTTPClient struct {
 	authClient auth.Client
 }
 
-func (c *fetchHTTPClient) FetchActivities(username string, page int) ([]map[string]any, error) {
-	url := fmt.Sprintf("https://api.github.com/users/%s/events?per_page=100&page=%d", username, page)
+func (c *fetchHTTPClient) FetchActivities(username string, opts paginate.Options) ([]map[string]any, error) {
+	url := fmt.Sprintf("%s/users/%s/events?per_page=100", core.GithubAPIBaseURL, username)
+	return listPages(c.authClient, url, opts, paginate.DecodeArray)
+}
+
+func (c *fetchHTTPClient) FetchRepository(repo string) (map[string]any, error) {
+	url := fmt.Sprintf("%s/repos/%s", core.GithubAPIBaseURL, repo)
 
-	pdk.Log(pdk.LogDebug, fmt.Sprintf("Fetching page %d: %s", page, url))
+	pdk.Log(pdk.LogDebug, fmt.Sprintf("Fetching repository attributes: %s", url))
 
 	body, status, err := c.authClient.Get(url)
 	if err != nil {
@@ -86,10 +96,96 @@ func (c *fetchHTTPClient) FetchActivities(username string, page int) ([]map[stri
 		return nil, fmt.Errorf("GitHub API error: HTTP %d", status)
 	}
 
-	var events []map[string]any
-	if err := json.Unmarshal(body, &events); err != nil {
-		return nil, fmt.Errorf("failed to parse events: %w", err)
+	var repository map[string]any
+	if err := json.Unmarshal(body, &repository); err != nil {
+		return nil, fmt.Errorf("failed to parse repository: %w", err)
+	}
+
+	return repository, nil
+}
+
+func (c *fetchHTTPClient) FetchAuthenticatedUser() (string, error) {
+	url := fmt.Sprintf("%s/user", core.GithubAPIBaseURL)
+
+	body, status, err := c.authClient.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	if status != 200 {
+		return "", fmt.Errorf("GitHub API error: HTTP %d", status)
+	}
+
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := json.Unmarshal(body, &user); err != nil {
+		return "", fmt.Errorf("failed to parse user: %w", err)
+	}
+
+	return user.Login, nil
+}
+
+func (c *fetchHTTPClient) FetchUserOrganizations() ([]string, error) {
+	url := fmt.Sprintf("%s/user/orgs?per_page=100", core.GithubAPIBaseURL)
+	orgs, err := listPages(c.authClient, url, paginate.Options{}, paginate.DecodeArray)
+	if err != nil {
+		return nil, err
+	}
+
+	logins := make([]string, 0, len(orgs))
+	for _, org := range orgs {
+		if login, ok := org["login"].(string); ok {
+			logins = append(logins, login)
+		}
+	}
+
+	return logins, nil
+}
+
+func (c *fetchHTTPClient) FetchOrgActivities(username, org string, opts paginate.Options) ([]map[string]any, error) {
+	url := fmt.Sprintf("%s/users/%s/events/orgs/%s?per_page=100", core.GithubAPIBaseURL, username, org)
+	return listPages(c.authClient, url, opts, paginate.DecodeArray)
+}
+
+func (c *fetchHTTPClient) FetchWorkflowRuns(repo, actor, created string, opts paginate.Options) ([]map[string]any, error) {
+	query := "created=" + url.QueryEscape(created)
+	if actor != "" {
+		query += "&actor=" + url.QueryEscape(actor)
+	}
+	url := fmt.Sprintf("%s/repos/%s/actions/runs?per_page=100&%s", core.GithubAPIBaseURL, repo, query)
+	return listPages(c.authClient, url, opts, paginate.DecodeField("workflow_runs"))
+}
+
+func (c *fetchHTTPClient) FetchRunApprovals(repo string, runID int64) ([]map[string]any, error) {
+	url := fmt.Sprintf("%s/repos/%s/actions/runs/%d/approvals", core.GithubAPIBaseURL, repo, runID)
+
+	body, status, err := c.authClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	if status != 200 {
+		return nil, fmt.Errorf("GitHub API error: HTTP %d", status)
+	}
+
+	var approvals []map[string]any
+	if err := json.Unmarshal(body, &approvals); err != nil {
+		return nil, fmt.Errorf("failed to parse approvals: %w", err)
+	}
+
+	return approvals, nil
+}
+
+// listPages reads a list endpoint with the paginator, logging when a limit cut the list short
+func listPages(client auth.Client, url string, opts paginate.Options, decode paginate.Decoder) ([]map[string]any, error) {
+	pdk.Log(pdk.LogDebug, fmt.Sprintf("Fetching list: %s", url))
+
+	result, err := paginate.List(client, url, opts, decode)
+	if err != nil {
+		return nil, err
+	}
+	if result.Truncated {
+		logger.Warn(fmt.Sprintf("Stopped listing %s at the pagination limit (%d pages, %d items)", url, result.Pages, len(result.Items)))
 	}
 
-	return events, nil
+	return result.Items, nil
 }
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github-connector/internal/auth"
+	"github-connector/internal/core"
+	"strings"
+)
+
+// graphQL sends a query to the GitHub GraphQL API and decodes its data into out.
+// GraphQL reports most failures with HTTP 200 and an errors array, which is returned as an error.
+func graphQL(client auth.Client, query string, variables map[string]any, out any) error {
+	reqBody, err := json.Marshal(map[string]any{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode GraphQL request: %w", err)
+	}
+
+	body, status, err := client.Post(core.GithubGraphQLURL, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	if status != 200 {
+		return fmt.Errorf("GitHub API error (status %d): %s", status, string(body))
+	}
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to parse GraphQL response: %w", err)
+	}
+	if len(resp.Errors) > 0 {
+		messages := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			messages[i] = e.Message
+		}
+		return fmt.Errorf("GitHub GraphQL error: %s", strings.Join(messages, "; "))
+	}
+
+	if err := json.Unmarshal(resp.Data, out); err != nil {
+		return fmt.Errorf("failed to parse GraphQL data: %w", err)
+	}
+	return nil
+}
@@ -22,10 +22,30 @@ func newBearerClient(cfg map[string]any) (*bearerClient, error) {
 }
 
 func (c *bearerClient) Get(url string) ([]byte, int, error) {
-	req := pdk.NewHTTPRequest(pdk.MethodGet, url)
+	body, status, _, err := c.GetWithHeaders(url, nil)
+	return body, status, err
+}
+
+func (c *bearerClient) GetWithHeaders(url string, headers map[string]string) ([]byte, int, map[string]string, error) {
+	return c.doRequest(pdk.MethodGet, url, headers, nil)
+}
+
+func (c *bearerClient) Post(url string, body []byte) ([]byte, int, error) {
+	respBody, status, _, err := c.doRequest(pdk.MethodPost, url, map[string]string{"Content-Type": "application/json"}, body)
+	return respBody, status, err
+}
+
+func (c *bearerClient) doRequest(method pdk.HTTPMethod, url string, headers map[string]string, body []byte) ([]byte, int, map[string]string, error) {
+	req := pdk.NewHTTPRequest(method, url)
 	req.SetHeader("Authorization", authorizationHeader(c.token))
 	req.SetHeader("Accept", "application/vnd.github+json")
 	req.SetHeader("User-Agent", "acteedog/github-connector")
+	for k, v := range headers {
+		req.SetHeader(k, v)
+	}
+	if body != nil {
+		req.SetBody(body)
+	}
 	res := req.Send()
-	return res.Body(), int(res.Status()), nil
+	return res.Body(), int(res.Status()), res.Headers(), nil
 }
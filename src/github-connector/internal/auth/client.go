@@ -1,10 +1,16 @@
 package auth
 
-// Client is the interface for making authenticated HTTP GET requests.
+// Client is the interface for making authenticated HTTP requests.
 // Concrete implementations handle token resolution based on auth method type.
 type Client interface {
 	// Get sends an authenticated GET request and returns the response body and status code.
 	Get(url string) ([]byte, int, error)
+	// GetWithHeaders sends an authenticated GET request with additional request headers and
+	// returns the response body, status code and response headers.
+	GetWithHeaders(url string, headers map[string]string) ([]byte, int, map[string]string, error)
+	// Post sends an authenticated POST request with a JSON body (e.g., a GraphQL query) and
+	// returns the response body and status code.
+	Post(url string, body []byte) ([]byte, int, error)
 }
 
 // authorizationHeader builds the Authorization header value for GitHub API requests.
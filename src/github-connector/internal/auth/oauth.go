@@ -38,9 +38,24 @@ func newOAuthClient(cfg map[string]any) (*oauthClient, error) {
 }
 
 func (c *oauthClient) Get(url string) ([]byte, int, error) {
-	body, status, err := c.doRequest(url)
+	body, status, _, err := c.GetWithHeaders(url, nil)
+	return body, status, err
+}
+
+func (c *oauthClient) GetWithHeaders(url string, headers map[string]string) ([]byte, int, map[string]string, error) {
+	return c.send(pdk.MethodGet, url, headers, nil)
+}
+
+func (c *oauthClient) Post(url string, body []byte) ([]byte, int, error) {
+	respBody, status, _, err := c.send(pdk.MethodPost, url, map[string]string{"Content-Type": "application/json"}, body)
+	return respBody, status, err
+}
+
+// send performs a request, refreshing the access token and retrying once on HTTP 401.
+func (c *oauthClient) send(method pdk.HTTPMethod, url string, headers map[string]string, reqBody []byte) ([]byte, int, map[string]string, error) {
+	body, status, respHeaders, err := c.doRequest(method, url, headers, reqBody)
 	if err != nil {
-		return nil, status, err
+		return nil, status, nil, err
 	}
 
 	// On 401: attempt a transparent token refresh and retry once.
@@ -48,27 +63,33 @@ func (c *oauthClient) Get(url string) ([]byte, int, error) {
 		pdk.Log(pdk.LogInfo, "Refreshing token...")
 		if refreshErr := c.refresh(); refreshErr != nil {
 			// Refresh failed – surface a clear re-auth message.
-			return nil, status, fmt.Errorf(
+			return nil, status, nil, fmt.Errorf(
 				"OAuth token expired and refresh failed: %w – please reconnect via GitHub App (Device Flow)",
 				refreshErr,
 			)
 		}
 		pdk.Log(pdk.LogInfo, "Refresh token completed")
 		// Retry with the new access token.
-		body, status, err = c.doRequest(url)
+		body, status, respHeaders, err = c.doRequest(method, url, headers, reqBody)
 	}
 
-	return body, status, err
+	return body, status, respHeaders, err
 }
 
-// doRequest performs a single GET request with the current access token.
-func (c *oauthClient) doRequest(url string) ([]byte, int, error) {
-	req := pdk.NewHTTPRequest(pdk.MethodGet, url)
+// doRequest performs a single request with the current access token.
+func (c *oauthClient) doRequest(method pdk.HTTPMethod, url string, headers map[string]string, body []byte) ([]byte, int, map[string]string, error) {
+	req := pdk.NewHTTPRequest(method, url)
 	req.SetHeader("Authorization", authorizationHeader(c.accessToken))
 	req.SetHeader("Accept", "application/vnd.github+json")
 	req.SetHeader("User-Agent", "acteedog/github-connector")
+	for k, v := range headers {
+		req.SetHeader(k, v)
+	}
+	if body != nil {
+		req.SetBody(body)
+	}
 	res := req.Send()
-	return res.Body(), int(res.Status()), nil
+	return res.Body(), int(res.Status()), res.Headers(), nil
 }
 
 // refresh exchanges the stored refresh_token for a new access_token via the
@@ -0,0 +1,370 @@
+package connection
+
+import (
+	"encoding/json"
+	"fmt"
+	"github-connector/internal/core"
+	"github-connector/internal/paginate"
+	"slices"
+	"strings"
+)
+
+const (
+	// maxRepositoriesPerPattern limits how many resolved repositories are probed per pattern
+	maxRepositoriesPerPattern = 5
+	// appInstallURL is where users install the GitHub App used by the oauth_device method
+	appInstallURL = "https://github.com/apps/acteedog-github-connector"
+)
+
+// Token types reported by the connection check
+const (
+	TokenTypeClassic     = "classic personal access token"
+	TokenTypeFineGrained = "fine-grained personal access token"
+	TokenTypeGithubApp   = "GitHub App user token"
+)
+
+// Report is the result of a connection check.
+type Report struct {
+	Login     string
+	TokenType string
+	Scopes    []string
+	Patterns  []*PatternResult
+}
+
+// PatternResult is the outcome of checking a single repository pattern.
+type PatternResult struct {
+	Pattern      string
+	Repositories []*RepositoryResult
+	Problems     []string
+}
+
+// RepositoryResult is the outcome of probing a single repository resolved from a pattern.
+type RepositoryResult struct {
+	Name     string
+	Failures []string
+}
+
+// OK reports whether every pattern resolved to repositories that can be read.
+func (r *Report) OK() bool {
+	for _, p := range r.Patterns {
+		if !p.OK() {
+			return false
+		}
+	}
+	return true
+}
+
+// OK reports whether the pattern has no problems and all its repositories are readable.
+func (p *PatternResult) OK() bool {
+	if len(p.Problems) > 0 {
+		return false
+	}
+	for _, repo := range p.Repositories {
+		if len(repo.Failures) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders the report as one line per pattern.
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Authenticated as %s (%s)", r.Login, r.TokenType)
+	if r.TokenType == TokenTypeClassic {
+		fmt.Fprintf(&b, ", scopes: [%s]", strings.Join(r.Scopes, ", "))
+	}
+
+	for _, p := range r.Patterns {
+		status := "OK"
+		if !p.OK() {
+			status = "NG"
+		}
+		names := make([]string, 0, len(p.Repositories))
+		for _, repo := range p.Repositories {
+			names = append(names, repo.Name)
+		}
+		fmt.Fprintf(&b, "\n[%s] %s: %d repositories", status, p.Pattern, len(p.Repositories))
+		if len(names) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(names, ", "))
+		}
+		for _, problem := range p.Problems {
+			fmt.Fprintf(&b, "\n  - %s", problem)
+		}
+		for _, repo := range p.Repositories {
+			for _, failure := range repo.Failures {
+				fmt.Fprintf(&b, "\n  - %s: %s", repo.Name, failure)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// Checker verifies that the configured credentials can read the repositories selected by
+// repository_patterns.
+type Checker struct {
+	httpClient HTTPClient
+	config     *config
+	logger     core.Logger
+}
+
+// NewChecker creates a new Checker instance
+func NewChecker(httpClient HTTPClient, cfg map[string]any, logger core.Logger) (*Checker, error) {
+	config, err := newConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Checker{
+		httpClient: httpClient,
+		config:     config,
+		logger:     logger,
+	}, nil
+}
+
+// Check authenticates, resolves each repository pattern to concrete repositories and probes
+// read access to their events, pulls and issues. An error is returned only when the token
+// itself is rejected; per-pattern problems are recorded in the report.
+func (c *Checker) Check() (*Report, error) {
+	body, status, headers, err := c.httpClient.Get("/user")
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if status != 200 {
+		return nil, statusError(status, body)
+	}
+
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := json.Unmarshal(body, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse user response: %w", err)
+	}
+
+	report := &Report{Login: user.Login}
+	report.TokenType, report.Scopes = c.tokenType(headers)
+	c.logger.Info(fmt.Sprintf("Authenticated as %s (%s)", report.Login, report.TokenType))
+
+	var rules []*core.RepositoryRule
+	for _, rule := range c.config.repositoryFilter.Rules() {
+		if !rule.Exclude {
+			rules = append(rules, rule)
+		}
+	}
+
+	// Exclusions alone select every repository, which is checked like an empty filter
+	if len(rules) == 0 {
+		result := &PatternResult{Pattern: "(all repositories)"}
+		if failure := c.probe(fmt.Sprintf("/users/%s/events?per_page=1", c.config.username)); failure != "" {
+			result.Problems = append(result.Problems, fmt.Sprintf("events of %s: %s", c.config.username, failure))
+		}
+		report.Patterns = append(report.Patterns, result)
+		return report, nil
+	}
+
+	installations, err := c.listInstallations()
+	if err != nil {
+		return nil, err
+	}
+
+	accessible, err := c.listAccessibleRepositories()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		report.Patterns = append(report.Patterns, c.checkPattern(rule, accessible, installations))
+	}
+
+	return report, nil
+}
+
+// tokenType infers the kind of credential from the auth method and response headers.
+// Classic tokens report their scopes in X-OAuth-Scopes; fine-grained tokens and GitHub App
+// tokens do not.
+func (c *Checker) tokenType(headers map[string]string) (string, []string) {
+	if c.usesGithubApp() {
+		return TokenTypeGithubApp, nil
+	}
+
+	scopes, ok := headerValue(headers, "X-OAuth-Scopes")
+	if !ok {
+		return TokenTypeFineGrained, nil
+	}
+
+	result := []string{}
+	for _, s := range strings.Split(scopes, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			result = append(result, s)
+		}
+	}
+	return TokenTypeClassic, result
+}
+
+// listInstallations returns the lower-cased account logins the GitHub App is installed on,
+// or nil when the auth method does not use the GitHub App.
+func (c *Checker) listInstallations() (map[string]bool, error) {
+	if !c.usesGithubApp() {
+		return nil, nil
+	}
+
+	result, err := c.list("/user/installations?per_page=100", paginate.DecodeField("installations"))
+	if err != nil {
+		return nil, err
+	}
+
+	installations := make(map[string]bool, len(result))
+	for _, inst := range result {
+		account, _ := inst["account"].(map[string]any)
+		if login, _ := account["login"].(string); login != "" {
+			installations[strings.ToLower(login)] = true
+		}
+	}
+	return installations, nil
+}
+
+// usesGithubApp reports whether the credentials come from the GitHub App (Device Flow). The
+// oauth_web method is not supported by the GitHub App.
+func (c *Checker) usesGithubApp() bool {
+	return c.config.authMethod == "oauth_device"
+}
+
+// accessibleRepository is a repository listed by /user/repos.
+type accessibleRepository struct {
+	name  string
+	attrs *core.RepositoryAttributes
+}
+
+// listAccessibleRepositories lists the repositories visible to the token.
+func (c *Checker) listAccessibleRepositories() ([]accessibleRepository, error) {
+	repos, err := c.list("/user/repos?per_page=100", paginate.DecodeArray)
+	if err != nil {
+		return nil, err
+	}
+
+	repositories := make([]accessibleRepository, 0, len(repos))
+	for _, repo := range repos {
+		name, _ := repo["full_name"].(string)
+		repositories = append(repositories, accessibleRepository{
+			name:  name,
+			attrs: core.RepositoryAttributesFromAPI(repo),
+		})
+	}
+
+	c.logger.Debug(fmt.Sprintf("Token can access %d repositories", len(repositories)))
+	return repositories, nil
+}
+
+// list reads a list endpoint within the configured pagination limits
+func (c *Checker) list(path string, decode paginate.Decoder) ([]map[string]any, error) {
+	result, err := c.httpClient.List(path, paginate.Options{Limits: c.config.pagination}, decode)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if result.Truncated {
+		c.logger.Warn(fmt.Sprintf("Stopped listing %s at the pagination limit (%d pages, %d items)", path, result.Pages, len(result.Items)))
+	}
+	return result.Items, nil
+}
+
+// checkPattern resolves an inclusion rule to the accessible repositories it selects, after
+// exclusions, and probes them.
+func (c *Checker) checkPattern(rule *core.RepositoryRule, accessible []accessibleRepository, installations map[string]bool) *PatternResult {
+	result := &PatternResult{Pattern: rule.Pattern}
+	filter := c.config.repositoryFilter
+
+	owner, _, _ := strings.Cut(rule.Glob, "/")
+	if installations != nil && !strings.ContainsAny(owner, "*?[") && !installations[strings.ToLower(owner)] {
+		result.Problems = append(result.Problems, fmt.Sprintf(
+			"GitHub App is not installed on %q; install it from %s", owner, appInstallURL))
+	}
+
+	for _, repo := range accessible {
+		if rule.Match(repo.name, repo.attrs) && filter.Allows(repo.name, repo.attrs) {
+			result.Repositories = append(result.Repositories, &RepositoryResult{Name: repo.name})
+		}
+	}
+
+	// Exact patterns may name public repositories that /user/repos does not list
+	if len(result.Repositories) == 0 && rule.IsExact() && filter.Allows(rule.Glob, nil) {
+		if failure := c.probe("/repos/" + rule.Glob); failure != "" {
+			result.Problems = append(result.Problems, fmt.Sprintf("repository is not visible to this token: %s", failure))
+			return result
+		}
+		result.Repositories = append(result.Repositories, &RepositoryResult{Name: rule.Glob})
+	}
+
+	if len(result.Repositories) == 0 {
+		result.Problems = append(result.Problems, "no repositories visible to this token match the pattern")
+		return result
+	}
+
+	for i, repo := range result.Repositories {
+		if i >= maxRepositoriesPerPattern {
+			break
+		}
+		for _, endpoint := range []string{"events", "pulls", "issues"} {
+			path := fmt.Sprintf("/repos/%s/%s?per_page=1", repo.Name, endpoint)
+			allowed := []int{}
+			if endpoint == "issues" {
+				// Repositories with issues disabled answer 410 Gone, which is not a permission problem
+				allowed = append(allowed, 410)
+			}
+			if failure := c.probe(path, allowed...); failure != "" {
+				repo.Failures = append(repo.Failures, fmt.Sprintf("cannot read %s: %s", endpoint, failure))
+			}
+		}
+	}
+
+	return result
+}
+
+// probe sends a GET request and returns a description of the failure, or "" on success. Statuses
+// in allowed are treated as success.
+func (c *Checker) probe(path string, allowed ...int) string {
+	body, status, _, err := c.httpClient.Get(path)
+	if err != nil {
+		return err.Error()
+	}
+	if status == 200 || slices.Contains(allowed, status) {
+		return ""
+	}
+	return statusError(status, body).Error()
+}
+
+// statusError converts a non-200 GitHub API response into a descriptive error.
+func statusError(status int, body []byte) error {
+	var errorMsg string
+	switch {
+	case status == 401:
+		errorMsg = "Authentication failed: Invalid or expired token"
+	case status == 403:
+		errorMsg = "Access forbidden: Check token permissions"
+	case status == 404:
+		errorMsg = "Not found: the resource does not exist or the token cannot see it"
+	case status >= 500:
+		errorMsg = fmt.Sprintf("GitHub API server error (status %d)", status)
+	default:
+		errorMsg = fmt.Sprintf("Request failed with status %d", status)
+	}
+
+	var githubError struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &githubError); err == nil && githubError.Message != "" {
+		errorMsg = fmt.Sprintf("%s: %s", errorMsg, githubError.Message)
+	}
+
+	return fmt.Errorf("%s", errorMsg)
+}
+
+// headerValue returns the value of an HTTP header using a case-insensitive name lookup.
+func headerValue(headers map[string]string, name string) (string, bool) {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
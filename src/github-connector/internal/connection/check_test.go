@@ -0,0 +1,187 @@
+package connection
+
+import (
+	"github-connector/internal/core"
+	"github-connector/internal/paginate"
+	mock_connection "github-connector/mock/connection"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func ok(body string) func(string) ([]byte, int, map[string]string, error) {
+	return func(string) ([]byte, int, map[string]string, error) {
+		return []byte(body), 200, nil, nil
+	}
+}
+
+// listed returns a single page list response decoded with the decoder of the request
+func listed(body string) func(string, paginate.Options, paginate.Decoder) (*paginate.Result, error) {
+	return func(_ string, _ paginate.Options, decode paginate.Decoder) (*paginate.Result, error) {
+		items, err := decode([]byte(body))
+		if err != nil {
+			return nil, err
+		}
+		return &paginate.Result{Items: items, Pages: 1}, nil
+	}
+}
+
+var defaultListOptions = paginate.Options{Limits: paginate.DefaultLimits}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		getMockHTTP func(*gomock.Controller) HTTPClient
+		cfg         map[string]any
+		wantReport  string
+		wantOK      bool
+		wantErr     bool
+	}{
+		{
+			name: "invalid token",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_connection.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().Get("/user").Return([]byte(`{"message":"Bad credentials"}`), 401, nil, nil).Times(1)
+				return mockHTTP
+			},
+			cfg:     map[string]any{"username": "octocat"},
+			wantErr: true,
+		},
+		{
+			name: "no patterns - classic token",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_connection.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().Get("/user").Return([]byte(`{"login":"octocat"}`), 200, map[string]string{"x-oauth-scopes": "repo, read:user"}, nil).Times(1)
+				mockHTTP.EXPECT().Get("/users/octocat/events?per_page=1").Return([]byte(`[]`), 200, nil, nil).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{"username": "octocat"},
+			wantReport: "Authenticated as octocat (classic personal access token), scopes: [repo, read:user]\n" +
+				"[OK] (all repositories): 0 repositories",
+			wantOK: true,
+		},
+		{
+			name: "fine-grained token cannot read pulls of a matched repository",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_connection.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().Get("/user").DoAndReturn(ok(`{"login":"octocat"}`)).Times(1)
+				mockHTTP.EXPECT().List("/user/repos?per_page=100", defaultListOptions, gomock.Any()).DoAndReturn(listed(`[{"full_name":"myorg/api"},{"full_name":"other/tool"}]`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/myorg/api/events?per_page=1").DoAndReturn(ok(`[]`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/myorg/api/pulls?per_page=1").Return([]byte(`{"message":"Resource not accessible by personal access token"}`), 403, nil, nil).Times(1)
+				mockHTTP.EXPECT().Get("/repos/myorg/api/issues?per_page=1").DoAndReturn(ok(`[]`)).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{"username": "octocat", "repository_patterns": []any{"myorg/*"}},
+			wantReport: "Authenticated as octocat (fine-grained personal access token)\n" +
+				"[NG] myorg/*: 1 repositories (myorg/api)\n" +
+				"  - myorg/api: cannot read pulls: Access forbidden: Check token permissions: Resource not accessible by personal access token",
+			wantOK: false,
+		},
+		{
+			name: "oauth_web token is not a GitHub App token and disabled issues are not a failure",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_connection.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().Get("/user").DoAndReturn(ok(`{"login":"octocat"}`)).Times(1)
+				mockHTTP.EXPECT().List("/user/repos?per_page=100", defaultListOptions, gomock.Any()).DoAndReturn(listed(`[{"full_name":"myorg/api"}]`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/myorg/api/events?per_page=1").DoAndReturn(ok(`[]`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/myorg/api/pulls?per_page=1").DoAndReturn(ok(`[]`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/myorg/api/issues?per_page=1").Return([]byte(`{"message":"Issues are disabled for this repo"}`), 410, nil, nil).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{"username": "octocat", "active_auth_method": "oauth_web", "repository_patterns": []any{"myorg/*"}},
+			wantReport: "Authenticated as octocat (fine-grained personal access token)\n" +
+				"[OK] myorg/*: 1 repositories (myorg/api)",
+			wantOK: true,
+		},
+		{
+			name: "exclusions and qualifiers narrow matched repositories",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_connection.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().Get("/user").DoAndReturn(ok(`{"login":"octocat"}`)).Times(1)
+				mockHTTP.EXPECT().List("/user/repos?per_page=100", defaultListOptions, gomock.Any()).DoAndReturn(listed(`[
+					{"full_name":"myorg/api","visibility":"private"},
+					{"full_name":"myorg/legacy-web","visibility":"private"},
+					{"full_name":"myorg/old","archived":true,"visibility":"private"}
+				]`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/myorg/api/events?per_page=1").DoAndReturn(ok(`[]`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/myorg/api/pulls?per_page=1").DoAndReturn(ok(`[]`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/myorg/api/issues?per_page=1").DoAndReturn(ok(`[]`)).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"username":            "octocat",
+				"repository_patterns": []any{"myorg/*", "!myorg/legacy-*", "!myorg/* archived:true"},
+			},
+			wantReport: "Authenticated as octocat (fine-grained personal access token)\n" +
+				"[OK] myorg/*: 1 repositories (myorg/api)",
+			wantOK: true,
+		},
+		{
+			name: "exact pattern resolved via repository lookup",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_connection.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().Get("/user").DoAndReturn(ok(`{"login":"octocat"}`)).Times(1)
+				mockHTTP.EXPECT().List("/user/repos?per_page=100", defaultListOptions, gomock.Any()).DoAndReturn(listed(`[]`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/golang/go").DoAndReturn(ok(`{}`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/golang/go/events?per_page=1").DoAndReturn(ok(`[]`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/golang/go/pulls?per_page=1").DoAndReturn(ok(`[]`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/golang/go/issues?per_page=1").DoAndReturn(ok(`[]`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/golang/missing").Return([]byte(`{"message":"Not Found"}`), 404, nil, nil).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{"username": "octocat", "repository_patterns": []any{"golang/go", "golang/missing"}},
+			wantReport: "Authenticated as octocat (fine-grained personal access token)\n" +
+				"[OK] golang/go: 1 repositories (golang/go)\n" +
+				"[NG] golang/missing: 0 repositories\n" +
+				"  - repository is not visible to this token: Not found: the resource does not exist or the token cannot see it: Not Found",
+			wantOK: false,
+		},
+		{
+			name: "github app not installed on organization",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_connection.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().Get("/user").DoAndReturn(ok(`{"login":"octocat"}`)).Times(1)
+				mockHTTP.EXPECT().List("/user/installations?per_page=100", defaultListOptions, gomock.Any()).DoAndReturn(listed(`{"installations":[{"account":{"login":"octocat"}}]}`)).Times(1)
+				mockHTTP.EXPECT().List("/user/repos?per_page=100", defaultListOptions, gomock.Any()).DoAndReturn(listed(`[{"full_name":"octocat/hello"}]`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/octocat/hello/events?per_page=1").DoAndReturn(ok(`[]`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/octocat/hello/pulls?per_page=1").DoAndReturn(ok(`[]`)).Times(1)
+				mockHTTP.EXPECT().Get("/repos/octocat/hello/issues?per_page=1").DoAndReturn(ok(`[]`)).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"username":            "octocat",
+				"active_auth_method":  "oauth_device",
+				"repository_patterns": []any{"octocat/*", "myorg/*"},
+			},
+			wantReport: "Authenticated as octocat (GitHub App user token)\n" +
+				"[OK] octocat/*: 1 repositories (octocat/hello)\n" +
+				"[NG] myorg/*: 0 repositories\n" +
+				"  - GitHub App is not installed on \"myorg\"; install it from https://github.com/apps/acteedog-github-connector\n" +
+				"  - no repositories visible to this token match the pattern",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockHTTP := tt.getMockHTTP(ctrl)
+			checker, err := NewChecker(mockHTTP, tt.cfg, core.NewNoopLogger())
+			if err != nil {
+				t.Fatalf("Failed to create Checker: %v", err)
+			}
+
+			got, err := checker.Check()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantReport, got.String())
+			assert.Equal(t, tt.wantOK, got.OK())
+		})
+	}
+}
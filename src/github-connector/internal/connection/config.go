@@ -0,0 +1,43 @@
+package connection
+
+import (
+	"fmt"
+	"github-connector/internal/core"
+	"github-connector/internal/paginate"
+)
+
+type config struct {
+	username         string
+	authMethod       string
+	repositoryFilter *core.RepositoryFilter
+	pagination       paginate.Limits
+}
+
+func newConfig(cfg map[string]any) (*config, error) {
+	username, ok := cfg["username"].(string)
+	if !ok || username == "" {
+		return nil, fmt.Errorf("missing username")
+	}
+
+	authMethod, _ := cfg["active_auth_method"].(string)
+	if authMethod == "" {
+		authMethod = "token"
+	}
+
+	repositoryFilter, err := core.NewRepositoryFilterFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	pagination, err := paginate.LimitsFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &config{
+		username:         username,
+		authMethod:       authMethod,
+		repositoryFilter: repositoryFilter,
+		pagination:       pagination,
+	}, nil
+}
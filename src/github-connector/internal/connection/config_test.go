@@ -0,0 +1,95 @@
+package connection
+
+import (
+	"github-connector/internal/core"
+	"github-connector/internal/paginate"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        map[string]any
+		wantConfig *config
+		wantErr    bool
+	}{
+		{
+			name: "valid config",
+			cfg: map[string]any{
+				"username":             "octocat",
+				"active_auth_method":   "oauth_device",
+				"repository_patterns":  []any{"octocat/*", ""},
+				"pagination_max_pages": float64(2),
+			},
+			wantConfig: &config{
+				username:         "octocat",
+				authMethod:       "oauth_device",
+				repositoryFilter: mustParseFilter(t, "octocat/*", ""),
+				pagination:       paginate.Limits{MaxPages: 2, MaxItems: 1000},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config - default auth method",
+			cfg: map[string]any{
+				"username": "octocat",
+			},
+			wantConfig: &config{
+				username:         "octocat",
+				authMethod:       "token",
+				repositoryFilter: mustParseFilter(t),
+				pagination:       paginate.DefaultLimits,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid config - invalid repository pattern",
+			cfg: map[string]any{
+				"username":            "octocat",
+				"repository_patterns": []any{"octocat"},
+			},
+			wantConfig: nil,
+			wantErr:    true,
+		},
+		{
+			name: "invalid config - invalid pagination limit",
+			cfg: map[string]any{
+				"username":             "octocat",
+				"pagination_max_items": float64(0),
+			},
+			wantConfig: nil,
+			wantErr:    true,
+		},
+		{
+			name: "invalid config - missing username",
+			cfg: map[string]any{
+				"repository_patterns": []any{"octocat/*"},
+			},
+			wantConfig: nil,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotConfig, err := newConfig(tt.cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantConfig, gotConfig)
+		})
+	}
+}
+
+func mustParseFilter(t *testing.T, patterns ...string) *core.RepositoryFilter {
+	t.Helper()
+	filter, err := core.ParseRepositoryFilter(patterns, false)
+	if err != nil {
+		t.Fatalf("Failed to parse repository patterns: %v", err)
+	}
+	return filter
+}
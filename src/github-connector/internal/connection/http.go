@@ -0,0 +1,12 @@
+package connection
+
+import "github-connector/internal/paginate"
+
+// HTTPClient is the interface for the GitHub API requests made by the connection check.
+type HTTPClient interface {
+	// Get sends a GET request to an API path (e.g., "/user") and returns the response body,
+	// status code and response headers.
+	Get(path string) ([]byte, int, map[string]string, error)
+	// List reads a list endpoint starting at an API path, following its pagination links.
+	List(path string, opts paginate.Options, decode paginate.Decoder) (*paginate.Result, error)
+}
@@ -2,6 +2,8 @@ package core
 
 import (
 	"fmt"
+	neturl "net/url"
+	"strings"
 	"time"
 )
 
@@ -108,7 +110,252 @@ func (g *ContextGenerator) CreateIssueContext(repoName string, issueNumber int)
 	}
 }
 
+// CreateWorkflowContext creates a GitHub Actions workflow context. workflowFile is the file name
+// of the workflow definition (e.g., "ci.yml"), which identifies the workflow in URLs and API paths.
+func (g *ContextGenerator) CreateWorkflowContext(repoName, workflowFile string) *Context {
+	id := MakeWorkflowContextID(repoName, workflowFile)
+	parentID := MakeRepositoryContextID(repoName)
+	return &Context{
+		Id:           id,
+		Name:         fmt.Sprintf("workflow:%s", workflowFile),
+		ParentId:     parentID,
+		ConnectorId:  g.connectorID,
+		ResourceType: ResourceTypeWorkflow,
+		Title:        ptrString(workflowFile),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"repo":          repoName,
+				"workflow_file": workflowFile,
+			},
+		},
+	}
+}
+
+// CreateWorkflowRunContext creates a GitHub Actions workflow run context. Its parent is the
+// repository rather than the workflow, because run URLs do not identify the workflow.
+func (g *ContextGenerator) CreateWorkflowRunContext(repoName string, runID int64) *Context {
+	id := MakeWorkflowRunContextID(repoName, fmt.Sprintf("%d", runID))
+	parentID := MakeRepositoryContextID(repoName)
+	return &Context{
+		Id:           id,
+		Name:         fmt.Sprintf("Run #%d", runID),
+		ParentId:     parentID,
+		ConnectorId:  g.connectorID,
+		ResourceType: ResourceTypeWorkflowRun,
+		Title:        ptrString(fmt.Sprintf("Run #%d", runID)),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"repo":   repoName,
+				"run_id": fmt.Sprintf("%d", runID),
+			},
+		},
+	}
+}
+
+// CreateReviewThreadContext creates a pull request review thread context, a conversation on a
+// line of the diff. rootCommentID is the ID of the comment that started the thread.
+func (g *ContextGenerator) CreateReviewThreadContext(repoName string, prNumber int, rootCommentID int64) *Context {
+	id := MakeReviewThreadContextID(repoName, fmt.Sprintf("%d", prNumber), fmt.Sprintf("%d", rootCommentID))
+	parentID := MakePullRequestContextID(repoName, fmt.Sprintf("%d", prNumber))
+	return &Context{
+		Id:           id,
+		Name:         fmt.Sprintf("Thread r%d", rootCommentID),
+		ParentId:     parentID,
+		ConnectorId:  g.connectorID,
+		ResourceType: ResourceTypeReviewThread,
+		Title:        ptrString(fmt.Sprintf("Review thread on PR #%d", prNumber)),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"repo":       repoName,
+				"pr_number":  fmt.Sprintf("%d", prNumber),
+				"comment_id": fmt.Sprintf("%d", rootCommentID),
+			},
+		},
+	}
+}
+
+// CreateProjectContext creates a project (v2) context. Projects belong to an organization or a
+// user rather than a repository, so the source is their parent. ownerType is
+// ProjectOwnerOrganization or ProjectOwnerUser.
+func (g *ContextGenerator) CreateProjectContext(ownerType, owner string, projectNumber int) *Context {
+	id := MakeProjectContextID(owner, fmt.Sprintf("%d", projectNumber))
+	parentID := MakeSourceContextID()
+	return &Context{
+		Id:           id,
+		Name:         fmt.Sprintf("project:%s/%d", owner, projectNumber),
+		ParentId:     parentID,
+		ConnectorId:  g.connectorID,
+		ResourceType: ResourceTypeProject,
+		Title:        ptrString(fmt.Sprintf("Project #%d", projectNumber)),
+		Url:          ptrString(ProjectURL(ownerType, owner, projectNumber)),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"owner":          owner,
+				"owner_type":     ownerType,
+				"project_number": fmt.Sprintf("%d", projectNumber),
+			},
+		},
+	}
+}
+
+// CreateProjectItemContext creates a project item context, an issue, pull request or draft issue
+// on a project board. itemID is the database ID of the item.
+func (g *ContextGenerator) CreateProjectItemContext(ownerType, owner string, projectNumber int, itemID int64) *Context {
+	id := MakeProjectItemContextID(owner, fmt.Sprintf("%d", projectNumber), fmt.Sprintf("%d", itemID))
+	parentID := MakeProjectContextID(owner, fmt.Sprintf("%d", projectNumber))
+	return &Context{
+		Id:           id,
+		Name:         fmt.Sprintf("Item %d", itemID),
+		ParentId:     parentID,
+		ConnectorId:  g.connectorID,
+		ResourceType: ResourceTypeProjectItem,
+		Title:        ptrString(fmt.Sprintf("Item on project #%d", projectNumber)),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"owner":          owner,
+				"owner_type":     ownerType,
+				"project_number": fmt.Sprintf("%d", projectNumber),
+				"item_id":        fmt.Sprintf("%d", itemID),
+			},
+		},
+	}
+}
+
+// CreateGistContext creates a gist context. Gists belong to a user rather than a repository, so
+// the source is their parent.
+func (g *ContextGenerator) CreateGistContext(owner, gistID string) *Context {
+	id := MakeGistContextID(gistID)
+	parentID := MakeSourceContextID()
+	return &Context{
+		Id:           id,
+		Name:         fmt.Sprintf("gist:%s", gistID),
+		ParentId:     parentID,
+		ConnectorId:  g.connectorID,
+		ResourceType: ResourceTypeGist,
+		Title:        ptrString(fmt.Sprintf("Gist by %s", owner)),
+		Url:          ptrString(fmt.Sprintf("https://gist.github.com/%s/%s", owner, gistID)),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"owner":   owner,
+				"gist_id": gistID,
+			},
+		},
+	}
+}
+
+// CreateDiscussionContext creates a repository discussion context
+func (g *ContextGenerator) CreateDiscussionContext(repoName string, discussionNumber int) *Context {
+	id := MakeDiscussionContextID(repoName, fmt.Sprintf("%d", discussionNumber))
+	parentID := MakeRepositoryContextID(repoName)
+	return &Context{
+		Id:           id,
+		Name:         fmt.Sprintf("Discussion #%d", discussionNumber),
+		ParentId:     parentID,
+		ConnectorId:  g.connectorID,
+		ResourceType: ResourceTypeDiscussion,
+		Title:        ptrString(fmt.Sprintf("Discussion #%d", discussionNumber)),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"repo":              repoName,
+				"discussion_number": fmt.Sprintf("%d", discussionNumber),
+			},
+		},
+	}
+}
+
+// CreateBranchContext creates a branch context. branch is the branch name without the
+// "refs/heads/" prefix.
+func (g *ContextGenerator) CreateBranchContext(repoName, branch string) *Context {
+	id := MakeBranchContextID(repoName, branch)
+	parentID := MakeRepositoryContextID(repoName)
+	return &Context{
+		Id:           id,
+		Name:         fmt.Sprintf("branch:%s", branch),
+		ParentId:     parentID,
+		ConnectorId:  g.connectorID,
+		ResourceType: ResourceTypeBranch,
+		Title:        ptrString(branch),
+		Url:          ptrString(fmt.Sprintf("https://github.com/%s/tree/%s", repoName, escapePathSegments(branch))),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"repo":   repoName,
+				"branch": branch,
+			},
+		},
+	}
+}
+
+// CreateCommitContext creates a commit context. Its parent is the repository rather than a
+// branch, because a commit can be on several branches.
+func (g *ContextGenerator) CreateCommitContext(repoName, sha string) *Context {
+	id := MakeCommitContextID(repoName, sha)
+	parentID := MakeRepositoryContextID(repoName)
+	return &Context{
+		Id:           id,
+		Name:         fmt.Sprintf("commit:%s", ShortSHA(sha)),
+		ParentId:     parentID,
+		ConnectorId:  g.connectorID,
+		ResourceType: ResourceTypeCommit,
+		Title:        ptrString(fmt.Sprintf("Commit %s", ShortSHA(sha))),
+		Url:          ptrString(fmt.Sprintf("https://github.com/%s/commit/%s", repoName, sha)),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"repo": repoName,
+				"sha":  sha,
+			},
+		},
+	}
+}
+
+// CreateReleaseContext creates a release context identified by its tag name
+func (g *ContextGenerator) CreateReleaseContext(repoName, tag string) *Context {
+	id := MakeReleaseContextID(repoName, tag)
+	parentID := MakeRepositoryContextID(repoName)
+	return &Context{
+		Id:           id,
+		Name:         fmt.Sprintf("release:%s", tag),
+		ParentId:     parentID,
+		ConnectorId:  g.connectorID,
+		ResourceType: ResourceTypeRelease,
+		Title:        ptrString(tag),
+		Url:          ptrString(fmt.Sprintf("https://github.com/%s/releases/tag/%s", repoName, escapePathSegments(tag))),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"repo": repoName,
+				"tag":  tag,
+			},
+		},
+	}
+}
+
+// ShortSHA returns the 7-character abbreviation of a commit SHA shown by GitHub
+func ShortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// ProjectURL returns the URL of a project (v2)
+func ProjectURL(ownerType, owner string, projectNumber int) string {
+	segment := "orgs"
+	if ownerType == ProjectOwnerUser {
+		segment = "users"
+	}
+	return fmt.Sprintf("https://github.com/%s/%s/projects/%d", segment, owner, projectNumber)
+}
+
 // ptrString returns a pointer to a string
 func ptrString(s string) *string {
 	return &s
 }
+
+// escapePathSegments escapes each "/"-separated segment of a ref so that it can be used as the
+// tail of a URL path
+func escapePathSegments(ref string) string {
+	segments := strings.Split(ref, "/")
+	for i, segment := range segments {
+		segments[i] = neturl.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
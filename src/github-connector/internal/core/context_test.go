@@ -83,3 +83,226 @@ func TestCreateIssueContext(t *testing.T) {
 	}
 	assert.Equal(t, want, got)
 }
+
+func TestCreateWorkflowContext(t *testing.T) {
+	g := NewContextGenerator()
+	got := g.CreateWorkflowContext("octocat/Hello-World", "ci.yml")
+	want := &Context{
+		Id:           "github:workflow:octocat/Hello-World:ci.yml",
+		Name:         "workflow:ci.yml",
+		ParentId:     "github:repository:octocat/Hello-World",
+		ConnectorId:  "github",
+		ResourceType: "workflow",
+		Title:        ptrString("ci.yml"),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"repo":          "octocat/Hello-World",
+				"workflow_file": "ci.yml",
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestCreateWorkflowRunContext(t *testing.T) {
+	g := NewContextGenerator()
+	got := g.CreateWorkflowRunContext("octocat/Hello-World", 30433642)
+	want := &Context{
+		Id:           "github:workflow_run:octocat/Hello-World:30433642",
+		Name:         "Run #30433642",
+		ParentId:     "github:repository:octocat/Hello-World",
+		ConnectorId:  "github",
+		ResourceType: "workflow_run",
+		Title:        ptrString("Run #30433642"),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"repo":   "octocat/Hello-World",
+				"run_id": "30433642",
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestCreateReviewThreadContext(t *testing.T) {
+	g := NewContextGenerator()
+	got := g.CreateReviewThreadContext("octocat/Hello-World", 1347, 2524185090)
+	want := &Context{
+		Id:           "github:review_thread:octocat/Hello-World:1347:2524185090",
+		Name:         "Thread r2524185090",
+		ParentId:     "github:pull_request:octocat/Hello-World:1347",
+		ConnectorId:  "github",
+		ResourceType: "review_thread",
+		Title:        ptrString("Review thread on PR #1347"),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"repo":       "octocat/Hello-World",
+				"pr_number":  "1347",
+				"comment_id": "2524185090",
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestCreateProjectContext(t *testing.T) {
+	g := NewContextGenerator()
+	got := g.CreateProjectContext(ProjectOwnerUser, "octocat", 3)
+	want := &Context{
+		Id:           "github:project:octocat:3",
+		Name:         "project:octocat/3",
+		ParentId:     "github:source",
+		ConnectorId:  "github",
+		ResourceType: "project",
+		Title:        ptrString("Project #3"),
+		Url:          ptrString("https://github.com/users/octocat/projects/3"),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"owner":          "octocat",
+				"owner_type":     "user",
+				"project_number": "3",
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestCreateProjectItemContext(t *testing.T) {
+	g := NewContextGenerator()
+	got := g.CreateProjectItemContext(ProjectOwnerOrganization, "acme", 5, 98765)
+	want := &Context{
+		Id:           "github:project_item:acme:5:98765",
+		Name:         "Item 98765",
+		ParentId:     "github:project:acme:5",
+		ConnectorId:  "github",
+		ResourceType: "project_item",
+		Title:        ptrString("Item on project #5"),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"owner":          "acme",
+				"owner_type":     "organization",
+				"project_number": "5",
+				"item_id":        "98765",
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestCreateGistContext(t *testing.T) {
+	g := NewContextGenerator()
+	got := g.CreateGistContext("octocat", "6cad326836d38bd3a7ae")
+	want := &Context{
+		Id:           "github:gist:6cad326836d38bd3a7ae",
+		Name:         "gist:6cad326836d38bd3a7ae",
+		ParentId:     "github:source",
+		ConnectorId:  "github",
+		ResourceType: "gist",
+		Title:        ptrString("Gist by octocat"),
+		Url:          ptrString("https://gist.github.com/octocat/6cad326836d38bd3a7ae"),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"owner":   "octocat",
+				"gist_id": "6cad326836d38bd3a7ae",
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestCreateDiscussionContext(t *testing.T) {
+	g := NewContextGenerator()
+	got := g.CreateDiscussionContext("owner/repo", 7)
+	want := &Context{
+		Id:           "github:discussion:owner/repo:7",
+		Name:         "Discussion #7",
+		ParentId:     "github:repository:owner/repo",
+		ConnectorId:  "github",
+		ResourceType: "discussion",
+		Title:        ptrString("Discussion #7"),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"repo":              "owner/repo",
+				"discussion_number": "7",
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestCreateBranchContext(t *testing.T) {
+	g := NewContextGenerator()
+	got := g.CreateBranchContext("owner/repo", "feature/login")
+	want := &Context{
+		Id:           "github:branch:owner/repo:feature/login",
+		Name:         "branch:feature/login",
+		ParentId:     "github:repository:owner/repo",
+		ConnectorId:  "github",
+		ResourceType: "branch",
+		Title:        ptrString("feature/login"),
+		Url:          ptrString("https://github.com/owner/repo/tree/feature/login"),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"repo":   "owner/repo",
+				"branch": "feature/login",
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestCreateBranchContext_EscapesURL(t *testing.T) {
+	g := NewContextGenerator()
+	got := g.CreateBranchContext("owner/repo", "fix/#12 50%")
+	assert.Equal(t, "github:branch:owner/repo:fix/#12 50%", got.Id)
+	assert.Equal(t, "https://github.com/owner/repo/tree/fix/%2312%2050%25", *got.Url)
+}
+
+func TestCreateCommitContext(t *testing.T) {
+	g := NewContextGenerator()
+	got := g.CreateCommitContext("owner/repo", "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	want := &Context{
+		Id:           "github:commit:owner/repo:7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+		Name:         "commit:7fd1a60",
+		ParentId:     "github:repository:owner/repo",
+		ConnectorId:  "github",
+		ResourceType: "commit",
+		Title:        ptrString("Commit 7fd1a60"),
+		Url:          ptrString("https://github.com/owner/repo/commit/7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"repo": "owner/repo",
+				"sha":  "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestCreateReleaseContext(t *testing.T) {
+	g := NewContextGenerator()
+	got := g.CreateReleaseContext("owner/repo", "v1.2.3")
+	want := &Context{
+		Id:           "github:release:owner/repo:v1.2.3",
+		Name:         "release:v1.2.3",
+		ParentId:     "github:repository:owner/repo",
+		ConnectorId:  "github",
+		ResourceType: "release",
+		Title:        ptrString("v1.2.3"),
+		Url:          ptrString("https://github.com/owner/repo/releases/tag/v1.2.3"),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"repo": "owner/repo",
+				"tag":  "v1.2.3",
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestCreateReleaseContext_EscapesURL(t *testing.T) {
+	g := NewContextGenerator()
+	got := g.CreateReleaseContext("owner/repo", "cli/v2.0 #1?50%")
+	assert.Equal(t, "github:release:owner/repo:cli/v2.0 #1?50%", got.Id)
+	assert.Equal(t, "https://github.com/owner/repo/releases/tag/cli/v2.0%20%231%3F50%25", *got.Url)
+}
@@ -9,14 +9,32 @@ const (
 	ConnectorID = "github"
 	// GithubAPIBaseURL is the base URL for GitHub API
 	GithubAPIBaseURL = "https://api.github.com"
+	// GithubGraphQLURL is the endpoint of the GitHub GraphQL API
+	GithubGraphQLURL = GithubAPIBaseURL + "/graphql"
 )
 
 // Resource type constants for context identification
 const (
-	ResourceTypeSource      = "source"
-	ResourceTypeRepository  = "repository"
-	ResourceTypePullRequest = "pull_request"
-	ResourceTypeIssue       = "issue"
+	ResourceTypeSource       = "source"
+	ResourceTypeRepository   = "repository"
+	ResourceTypePullRequest  = "pull_request"
+	ResourceTypeIssue        = "issue"
+	ResourceTypeWorkflow     = "workflow"
+	ResourceTypeWorkflowRun  = "workflow_run"
+	ResourceTypeReviewThread = "review_thread"
+	ResourceTypeProject      = "project"
+	ResourceTypeProjectItem  = "project_item"
+	ResourceTypeGist         = "gist"
+	ResourceTypeDiscussion   = "discussion"
+	ResourceTypeBranch       = "branch"
+	ResourceTypeCommit       = "commit"
+	ResourceTypeRelease      = "release"
+)
+
+// Owner types of a project (v2), as they appear in project URLs ("orgs" or "users")
+const (
+	ProjectOwnerOrganization = "organization"
+	ProjectOwnerUser         = "user"
 )
 
 // MakeActivityID creates an activity ID with connector prefix
@@ -43,3 +61,61 @@ func MakePullRequestContextID(repoName, prNumber string) string {
 func MakeIssueContextID(repoName, issueNumber string) string {
 	return fmt.Sprintf("%s:%s:%s:%s", ConnectorID, ResourceTypeIssue, repoName, issueNumber)
 }
+
+// MakeWorkflowContextID creates a workflow context ID with connector prefix. workflowFile is the
+// file name of the workflow definition (e.g., "ci.yml").
+func MakeWorkflowContextID(repoName, workflowFile string) string {
+	return fmt.Sprintf("%s:%s:%s:%s", ConnectorID, ResourceTypeWorkflow, repoName, workflowFile)
+}
+
+// MakeWorkflowRunContextID creates a workflow run context ID with connector prefix
+func MakeWorkflowRunContextID(repoName, runID string) string {
+	return fmt.Sprintf("%s:%s:%s:%s", ConnectorID, ResourceTypeWorkflowRun, repoName, runID)
+}
+
+// MakeReviewThreadContextID creates a review thread context ID with connector prefix. A thread
+// is identified by the ID of its root review comment.
+func MakeReviewThreadContextID(repoName, prNumber, rootCommentID string) string {
+	return fmt.Sprintf("%s:%s:%s:%s:%s", ConnectorID, ResourceTypeReviewThread, repoName, prNumber, rootCommentID)
+}
+
+// MakeProjectContextID creates a project (v2) context ID with connector prefix. Organization and
+// user logins share a namespace, so the owner login identifies the project owner.
+func MakeProjectContextID(owner, projectNumber string) string {
+	return fmt.Sprintf("%s:%s:%s:%s", ConnectorID, ResourceTypeProject, owner, projectNumber)
+}
+
+// MakeProjectItemContextID creates a project item context ID with connector prefix. itemID is the
+// database ID of the item, shown as itemId in project URLs.
+func MakeProjectItemContextID(owner, projectNumber, itemID string) string {
+	return fmt.Sprintf("%s:%s:%s:%s:%s", ConnectorID, ResourceTypeProjectItem, owner, projectNumber, itemID)
+}
+
+// MakeGistContextID creates a gist context ID with connector prefix. Gist IDs are unique across
+// users, so the owner is not part of the ID.
+func MakeGistContextID(gistID string) string {
+	return fmt.Sprintf("%s:%s:%s", ConnectorID, ResourceTypeGist, gistID)
+}
+
+// MakeDiscussionContextID creates a discussion context ID with connector prefix
+func MakeDiscussionContextID(repoName, discussionNumber string) string {
+	return fmt.Sprintf("%s:%s:%s:%s", ConnectorID, ResourceTypeDiscussion, repoName, discussionNumber)
+}
+
+// MakeBranchContextID creates a branch context ID with connector prefix. branch is the branch
+// name without the "refs/heads/" prefix.
+func MakeBranchContextID(repoName, branch string) string {
+	return fmt.Sprintf("%s:%s:%s:%s", ConnectorID, ResourceTypeBranch, repoName, branch)
+}
+
+// MakeCommitContextID creates a commit context ID with connector prefix. sha is the full commit
+// SHA.
+func MakeCommitContextID(repoName, sha string) string {
+	return fmt.Sprintf("%s:%s:%s:%s", ConnectorID, ResourceTypeCommit, repoName, sha)
+}
+
+// MakeReleaseContextID creates a release context ID with connector prefix. A release is
+// identified by its tag name.
+func MakeReleaseContextID(repoName, tag string) string {
+	return fmt.Sprintf("%s:%s:%s:%s", ConnectorID, ResourceTypeRelease, repoName, tag)
+}
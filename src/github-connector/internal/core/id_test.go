@@ -25,3 +25,43 @@ func TestMakePullRequestContextID(t *testing.T) {
 func TestMakeIssueContextID(t *testing.T) {
 	assert.Equal(t, "github:issue:owner/repo:101", MakeIssueContextID("owner/repo", "101"))
 }
+
+func TestMakeWorkflowContextID(t *testing.T) {
+	assert.Equal(t, "github:workflow:owner/repo:ci.yml", MakeWorkflowContextID("owner/repo", "ci.yml"))
+}
+
+func TestMakeWorkflowRunContextID(t *testing.T) {
+	assert.Equal(t, "github:workflow_run:owner/repo:123456", MakeWorkflowRunContextID("owner/repo", "123456"))
+}
+
+func TestMakeReviewThreadContextID(t *testing.T) {
+	assert.Equal(t, "github:review_thread:owner/repo:42:1234567", MakeReviewThreadContextID("owner/repo", "42", "1234567"))
+}
+
+func TestMakeProjectContextID(t *testing.T) {
+	assert.Equal(t, "github:project:acme:5", MakeProjectContextID("acme", "5"))
+}
+
+func TestMakeProjectItemContextID(t *testing.T) {
+	assert.Equal(t, "github:project_item:acme:5:98765", MakeProjectItemContextID("acme", "5", "98765"))
+}
+
+func TestMakeGistContextID(t *testing.T) {
+	assert.Equal(t, "github:gist:6cad326836d38bd3a7ae", MakeGistContextID("6cad326836d38bd3a7ae"))
+}
+
+func TestMakeDiscussionContextID(t *testing.T) {
+	assert.Equal(t, "github:discussion:owner/repo:7", MakeDiscussionContextID("owner/repo", "7"))
+}
+
+func TestMakeBranchContextID(t *testing.T) {
+	assert.Equal(t, "github:branch:owner/repo:feature/login", MakeBranchContextID("owner/repo", "feature/login"))
+}
+
+func TestMakeCommitContextID(t *testing.T) {
+	assert.Equal(t, "github:commit:owner/repo:7fd1a60b01f91b314f59955a4e4d4e80d8edf11d", MakeCommitContextID("owner/repo", "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"))
+}
+
+func TestMakeReleaseContextID(t *testing.T) {
+	assert.Equal(t, "github:release:owner/repo:v1.2.3", MakeReleaseContextID("owner/repo", "v1.2.3"))
+}
@@ -1,11 +1,72 @@
 package core
 
-const (
-	ContextPatternPullRequest = `https://github\.com/(?P<owner>[^/]+)/(?P<repo>[^/]+)/pull/(?P<number>\d+)`
-	ContextPatternIssue       = `https://github\.com/(?P<owner>[^/]+)/(?P<repo>[^/]+)/issues/(?P<number>\d+)`
-	ContextPatternRepository  = `https://github\.com/(?P<owner>[^/]+)/(?P<repo>[^/|>)\]"'?]+)/?`
+// ContextPatternURL finds GitHub URLs embedded in free text. Characters used as delimiters
+// by Slack mrkdwn (<url|label>), Markdown links and quoted attributes terminate a URL.
+const ContextPatternURL = `https://(?:gist\.)?github\.com(?:/[^\s<>|()\[\]{}"'` + "`" + `]*)?`
 
-	// ContextExcludePatternRepository excludes GitHub special paths that are not repositories
-	// (e.g., user-attachments asset URLs like https://github.com/user-attachments/assets/...)
-	ContextExcludePatternRepository = `https://github\.com/user-attachments/`
+// URL patterns are matched against a URL whose query string and fragment have been removed.
+// Owner and repository captures follow GitHub's naming rules so that anchors, query strings
+// or surrounding punctuation never end up in a repository name.
+const (
+	ContextPatternPullRequestCommit = `^https://github\.com/(?P<owner>[A-Za-z0-9][A-Za-z0-9-]*)/(?P<repo>[A-Za-z0-9._-]+)/pull/(?P<number>\d+)/commits/(?P<sha>[0-9a-fA-F]{7,40})(?:/|$)`
+	ContextPatternPullRequest       = `^https://github\.com/(?P<owner>[A-Za-z0-9][A-Za-z0-9-]*)/(?P<repo>[A-Za-z0-9._-]+)/pull/(?P<number>\d+)(?:/|$)`
+	ContextPatternIssue             = `^https://github\.com/(?P<owner>[A-Za-z0-9][A-Za-z0-9-]*)/(?P<repo>[A-Za-z0-9._-]+)/issues/(?P<number>\d+)(?:/|$)`
+	ContextPatternCommit            = `^https://github\.com/(?P<owner>[A-Za-z0-9][A-Za-z0-9-]*)/(?P<repo>[A-Za-z0-9._-]+)/commit/(?P<sha>[0-9a-fA-F]{7,40})(?:/|$)`
+	ContextPatternCompare           = `^https://github\.com/(?P<owner>[A-Za-z0-9][A-Za-z0-9-]*)/(?P<repo>[A-Za-z0-9._-]+)/compare/(?P<spec>.+)$`
+	ContextPatternTree              = `^https://github\.com/(?P<owner>[A-Za-z0-9][A-Za-z0-9-]*)/(?P<repo>[A-Za-z0-9._-]+)/(?P<kind>blob|tree|blame)/(?P<ref>[^/]+)(?:/(?P<path>.+))?$`
+	ContextPatternRelease           = `^https://github\.com/(?P<owner>[A-Za-z0-9][A-Za-z0-9-]*)/(?P<repo>[A-Za-z0-9._-]+)/releases/tag/(?P<tag>[^/]+)$`
+	ContextPatternReleases          = `^https://github\.com/(?P<owner>[A-Za-z0-9][A-Za-z0-9-]*)/(?P<repo>[A-Za-z0-9._-]+)/(?:releases(?:/latest)?|tags)$`
+	ContextPatternDiscussion        = `^https://github\.com/(?P<owner>[A-Za-z0-9][A-Za-z0-9-]*)/(?P<repo>[A-Za-z0-9._-]+)/discussions/(?P<number>\d+)(?:/|$)`
+	ContextPatternWorkflowRun       = `^https://github\.com/(?P<owner>[A-Za-z0-9][A-Za-z0-9-]*)/(?P<repo>[A-Za-z0-9._-]+)/actions/runs/(?P<run_id>\d+)(?:/|$)`
+	ContextPatternWorkflow          = `^https://github\.com/(?P<owner>[A-Za-z0-9][A-Za-z0-9-]*)/(?P<repo>[A-Za-z0-9._-]+)/actions/workflows/(?P<workflow_file>[A-Za-z0-9._-]+\.ya?ml)(?:/|$)`
+	ContextPatternRepositoryProject = `^https://github\.com/(?P<owner>[A-Za-z0-9][A-Za-z0-9-]*)/(?P<repo>[A-Za-z0-9._-]+)/projects/(?P<number>\d+)(?:/|$)`
+	ContextPatternOwnerProject      = `^https://github\.com/(?P<owner_type>orgs|users)/(?P<owner>[A-Za-z0-9][A-Za-z0-9-]*)/projects/(?P<number>\d+)(?:/|$)`
+	ContextPatternGist              = `^https://gist\.github\.com/(?P<owner>[A-Za-z0-9][A-Za-z0-9-]*)/(?P<gist_id>[0-9a-fA-F]+)(?:/|$)`
+	ContextPatternRepository        = `^https://github\.com/(?P<owner>[A-Za-z0-9][A-Za-z0-9-]*)/(?P<repo>[A-Za-z0-9._-]+)(?:/|$)`
 )
+
+// ReservedPathSegments lists first path segments of github.com URLs that never name a
+// repository owner (e.g., https://github.com/orgs/foo/projects, https://github.com/settings/tokens).
+var ReservedPathSegments = []string{
+	"about",
+	"account",
+	"apps",
+	"blog",
+	"codespaces",
+	"collections",
+	"contact",
+	"customer-stories",
+	"dashboard",
+	"enterprise",
+	"enterprises",
+	"events",
+	"explore",
+	"features",
+	"git-guides",
+	"github-copilot",
+	"issues",
+	"join",
+	"login",
+	"logout",
+	"marketplace",
+	"new",
+	"notifications",
+	"orgs",
+	"organizations",
+	"pricing",
+	"pulls",
+	"readme",
+	"search",
+	"security",
+	"sessions",
+	"settings",
+	"site",
+	"sponsors",
+	"stars",
+	"team",
+	"topics",
+	"trending",
+	"user-attachments",
+	"users",
+	"watching",
+}
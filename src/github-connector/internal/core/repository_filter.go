@@ -0,0 +1,419 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RepositoryFilter decides which repositories the connector works with. It is built from the
+// repository_patterns configuration, one rule per line:
+//
+//	myorg/*                 include every repository of myorg
+//	!myorg/legacy-*         exclude repositories matching the glob
+//	myorg/*-[0-9]           character classes ([abc], [a-z], [!abc])
+//	**                      every repository
+//	!myorg/* archived:true  exclude archived repositories of myorg
+//	myorg/* fork:false      include only repositories that are not forks
+//
+// Within a glob, * matches any run of characters except '/', ** also matches '/', and ?
+// matches a single character. Rules are evaluated in order and the last matching rule wins.
+// When only exclusions are given, every other repository is included.
+//
+// Qualifiers (fork:, archived:, visibility:) need repository attributes. When attributes are
+// unknown (e.g., when matching URLs without API calls), qualifiers of inclusion rules are
+// ignored and exclusion rules with qualifiers are skipped.
+type RepositoryFilter struct {
+	rules      []*RepositoryRule
+	hasInclude bool
+}
+
+// RepositoryRule is a single parsed line of a RepositoryFilter.
+type RepositoryRule struct {
+	// Pattern is the rule as written, without surrounding whitespace
+	Pattern string
+	// Glob is the "owner/repo" glob of the rule, without '!' and qualifiers
+	Glob    string
+	Exclude bool
+
+	re         *regexp.Regexp
+	ownerRe    *regexp.Regexp
+	qualifiers []qualifier
+}
+
+// RepositoryAttributes are the repository properties tested by filter qualifiers.
+type RepositoryAttributes struct {
+	Fork       bool
+	Archived   bool
+	Visibility string
+}
+
+type qualifier struct {
+	key   string
+	value string
+}
+
+// PatternError reports a syntax error in a repository pattern.
+type PatternError struct {
+	Line    int
+	Column  int
+	Pattern string
+	Message string
+}
+
+func (e *PatternError) Error() string {
+	return fmt.Sprintf("repository pattern at line %d, column %d: %s. Got: '%s'", e.Line, e.Column, e.Message, e.Pattern)
+}
+
+// NewRepositoryFilterFromConfig builds a RepositoryFilter from the repository_patterns and
+// repository_patterns_ignore_case configuration fields.
+func NewRepositoryFilterFromConfig(cfg map[string]any) (*RepositoryFilter, error) {
+	var patterns []string
+	if patternsInterface, ok := cfg["repository_patterns"]; ok && patternsInterface != nil {
+		items, ok := patternsInterface.([]any)
+		if !ok {
+			return nil, fmt.Errorf("repository_patterns must be an array")
+		}
+		for i, p := range items {
+			patternStr, ok := p.(string)
+			if !ok {
+				return nil, fmt.Errorf("repository_patterns[%d] must be a string", i)
+			}
+			patterns = append(patterns, patternStr)
+		}
+	}
+
+	ignoreCase, _ := cfg["repository_patterns_ignore_case"].(bool)
+
+	return ParseRepositoryFilter(patterns, ignoreCase)
+}
+
+// ParseRepositoryFilter parses repository patterns into a RepositoryFilter. Blank lines are
+// ignored. Names are compared case-insensitively when ignoreCase is set.
+func ParseRepositoryFilter(patterns []string, ignoreCase bool) (*RepositoryFilter, error) {
+	filter := &RepositoryFilter{}
+	for i, line := range patterns {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		rule, err := parseRepositoryRule(line, i+1, ignoreCase)
+		if err != nil {
+			return nil, err
+		}
+		if !rule.Exclude {
+			filter.hasInclude = true
+		}
+		filter.rules = append(filter.rules, rule)
+	}
+	return filter, nil
+}
+
+// Rules returns the parsed rules in evaluation order.
+func (f *RepositoryFilter) Rules() []*RepositoryRule {
+	if f == nil {
+		return nil
+	}
+	return f.rules
+}
+
+// IsEmpty reports whether the filter has no rules and therefore allows every repository.
+func (f *RepositoryFilter) IsEmpty() bool {
+	return f == nil || len(f.rules) == 0
+}
+
+// NeedsAttributes reports whether any rule tests repository attributes.
+func (f *RepositoryFilter) NeedsAttributes() bool {
+	for _, rule := range f.Rules() {
+		if len(rule.qualifiers) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesOwner reports whether repositories of the owner may pass the filter, i.e., the filter
+// has no inclusion rules or an inclusion rule matches the owner.
+func (f *RepositoryFilter) MatchesOwner(owner string) bool {
+	if f.IsEmpty() || !f.hasInclude {
+		return true
+	}
+	for _, rule := range f.rules {
+		if !rule.Exclude && rule.MatchOwner(owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether the repository "owner/repo" passes the filter. attrs may be nil when
+// the repository attributes are unknown. A nil filter allows every repository.
+func (f *RepositoryFilter) Allows(name string, attrs *RepositoryAttributes) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	allowed := !f.hasInclude
+	for _, rule := range f.rules {
+		if rule.Match(name, attrs) {
+			allowed = !rule.Exclude
+		}
+	}
+	return allowed
+}
+
+// Match reports whether the rule applies to the repository, regardless of whether it
+// includes or excludes it.
+func (r *RepositoryRule) Match(name string, attrs *RepositoryAttributes) bool {
+	if !r.re.MatchString(name) {
+		return false
+	}
+	if len(r.qualifiers) == 0 {
+		return true
+	}
+	if attrs == nil {
+		return !r.Exclude
+	}
+	for _, q := range r.qualifiers {
+		if !q.match(attrs) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchOwner reports whether the owner part of the rule matches the owner (user or
+// organization) name.
+func (r *RepositoryRule) MatchOwner(owner string) bool {
+	return r.ownerRe.MatchString(owner)
+}
+
+// IsExact reports whether the rule names a single repository without wildcards.
+func (r *RepositoryRule) IsExact() bool {
+	return !strings.ContainsAny(r.Glob, "*?[")
+}
+
+func (q qualifier) match(attrs *RepositoryAttributes) bool {
+	switch q.key {
+	case "fork":
+		return fmt.Sprint(attrs.Fork) == q.value
+	case "archived":
+		return fmt.Sprint(attrs.Archived) == q.value
+	case "visibility":
+		return strings.EqualFold(attrs.Visibility, q.value)
+	}
+	return false
+}
+
+// RepositoryAttributesFromAPI extracts filter attributes from a GitHub repository object.
+func RepositoryAttributesFromAPI(repo map[string]any) *RepositoryAttributes {
+	attrs := &RepositoryAttributes{}
+	attrs.Fork, _ = repo["fork"].(bool)
+	attrs.Archived, _ = repo["archived"].(bool)
+	attrs.Visibility, _ = repo["visibility"].(string)
+	if attrs.Visibility == "" {
+		if private, _ := repo["private"].(bool); private {
+			attrs.Visibility = "private"
+		} else {
+			attrs.Visibility = "public"
+		}
+	}
+	return attrs
+}
+
+// parseRepositoryRule parses a single line: an optional '!', a glob and optional qualifiers
+// separated by whitespace. Columns in errors are 1-based byte offsets into the line.
+func parseRepositoryRule(line string, lineNo int, ignoreCase bool) (*RepositoryRule, error) {
+	fail := func(pos int, format string, args ...any) error {
+		return &PatternError{Line: lineNo, Column: pos + 1, Pattern: line, Message: fmt.Sprintf(format, args...)}
+	}
+
+	rule := &RepositoryRule{Pattern: strings.TrimSpace(line)}
+
+	pos := len(line) - len(strings.TrimLeft(line, " \t"))
+	if pos < len(line) && line[pos] == '!' {
+		rule.Exclude = true
+		pos++
+	}
+
+	end := pos
+	for end < len(line) && line[end] != ' ' && line[end] != '\t' {
+		end++
+	}
+	if end == pos {
+		return nil, fail(pos, "missing repository pattern after '!'")
+	}
+
+	rule.Glob = line[pos:end]
+	expr, ownerExpr, err := compileGlob(line, pos, end, fail)
+	if err != nil {
+		return nil, err
+	}
+	if ignoreCase {
+		expr = "(?i)" + expr
+		ownerExpr = "(?i)" + ownerExpr
+	}
+	rule.re = regexp.MustCompile(expr)
+	rule.ownerRe = regexp.MustCompile(ownerExpr)
+
+	for pos = end; pos < len(line); {
+		if line[pos] == ' ' || line[pos] == '\t' {
+			pos++
+			continue
+		}
+		end = pos
+		for end < len(line) && line[end] != ' ' && line[end] != '\t' {
+			end++
+		}
+		q, err := parseQualifier(line[pos:end])
+		if err != nil {
+			return nil, fail(pos, "%s", err)
+		}
+		rule.qualifiers = append(rule.qualifiers, q)
+		pos = end
+	}
+
+	return rule, nil
+}
+
+// compileGlob validates line[start:end] as an "owner/repo" glob and converts it to anchored
+// regular expressions for the full name and for the owner alone.
+func compileGlob(line string, start, end int, fail func(int, string, ...any) error) (string, string, error) {
+	glob := line[start:end]
+	if glob == "**" {
+		return "^.*$", "^.*$", nil
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	slashes := 0
+	segmentStart := start
+	ownerExpr := ""
+
+	for i := start; i < end; {
+		ch := line[i]
+		switch {
+		case ch == '/':
+			if slashes > 0 {
+				return "", "", fail(i, "pattern must have exactly one '/' separator (e.g., 'owner/repo')")
+			}
+			if i == segmentStart {
+				return "", "", fail(i, "owner part cannot be empty")
+			}
+			slashes++
+			ownerExpr = b.String() + "$"
+			b.WriteByte('/')
+			i++
+			segmentStart = i
+		case ch == '*':
+			run := 0
+			for i < end && line[i] == '*' {
+				run++
+				i++
+			}
+			if run > 2 {
+				return "", "", fail(i-run, "too many consecutive '*' (use '*' or '**')")
+			}
+			if run == 2 {
+				b.WriteString(".*")
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case ch == '?':
+			b.WriteString("[^/]")
+			i++
+		case ch == '[':
+			class, next, err := compileClass(line, i, end, fail)
+			if err != nil {
+				return "", "", err
+			}
+			b.WriteString(class)
+			i = next
+		case isNameChar(ch):
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+			i++
+		default:
+			return "", "", fail(i, "invalid character %q", ch)
+		}
+	}
+
+	if slashes == 0 {
+		return "", "", fail(start, "pattern must be in 'owner/repo' format (e.g., 'myorg/*', 'user/repo')")
+	}
+	if segmentStart == end {
+		return "", "", fail(end, "repo part cannot be empty")
+	}
+
+	b.WriteString("$")
+	return b.String(), ownerExpr, nil
+}
+
+// compileClass converts a character class starting at line[start] == '[' and returns the
+// regular expression and the position after the closing ']'.
+func compileClass(line string, start, end int, fail func(int, string, ...any) error) (string, int, error) {
+	var b strings.Builder
+	b.WriteString("[")
+
+	i := start + 1
+	if i < end && (line[i] == '!' || line[i] == '^') {
+		b.WriteString("^/")
+		i++
+	}
+
+	first := i
+	for ; i < end && line[i] != ']'; i++ {
+		ch := line[i]
+		switch {
+		case ch == '-' && i > first && i+1 < end && line[i+1] != ']':
+			if line[i-1] > line[i+1] {
+				return "", 0, fail(i-1, "invalid range %q in character class", line[i-1:i+2])
+			}
+			b.WriteByte('-')
+		case ch == '-':
+			b.WriteString(`\-`)
+		case isNameChar(ch):
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		default:
+			return "", 0, fail(i, "invalid character %q in character class", ch)
+		}
+	}
+
+	if i >= end {
+		return "", 0, fail(start, "unterminated character class")
+	}
+	if i == first {
+		return "", 0, fail(start, "empty character class")
+	}
+
+	b.WriteString("]")
+	return b.String(), i + 1, nil
+}
+
+func parseQualifier(s string) (qualifier, error) {
+	key, value, ok := strings.Cut(s, ":")
+	if !ok {
+		return qualifier{}, fmt.Errorf("invalid qualifier %q: expected key:value (e.g., 'fork:false')", s)
+	}
+	key = strings.ToLower(key)
+	value = strings.ToLower(value)
+
+	switch key {
+	case "fork", "archived":
+		if value != "true" && value != "false" {
+			return qualifier{}, fmt.Errorf("invalid value %q for %s: expected true or false", value, key)
+		}
+	case "visibility":
+		if value != "public" && value != "private" && value != "internal" {
+			return qualifier{}, fmt.Errorf("invalid value %q for visibility: expected public, private or internal", value)
+		}
+	default:
+		return qualifier{}, fmt.Errorf("unknown qualifier %q: expected fork, archived or visibility", key)
+	}
+
+	return qualifier{key: key, value: value}, nil
+}
+
+// isNameChar reports whether ch may appear in a GitHub owner or repository name.
+func isNameChar(ch byte) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' ||
+		ch == '-' || ch == '_' || ch == '.'
+}
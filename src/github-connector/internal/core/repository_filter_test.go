@@ -0,0 +1,204 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryFilterAllows(t *testing.T) {
+	tests := []struct {
+		name       string
+		patterns   []string
+		ignoreCase bool
+		repo       string
+		attrs      *RepositoryAttributes
+		want       bool
+	}{
+		{name: "no patterns allow everything", patterns: nil, repo: "octocat/hello", want: true},
+		{name: "exact match", patterns: []string{"octocat/hello"}, repo: "octocat/hello", want: true},
+		{name: "exact mismatch", patterns: []string{"octocat/hello"}, repo: "octocat/world", want: false},
+		{name: "owner wildcard", patterns: []string{"octocat/*"}, repo: "octocat/hello", want: true},
+		{name: "wildcard matches empty run", patterns: []string{"octocat/hello*"}, repo: "octocat/hello", want: true},
+		{name: "multiple wildcards", patterns: []string{"myorg/*-svc-*"}, repo: "myorg/billing-svc-v2", want: true},
+		{name: "wildcard owner and repo", patterns: []string{"*/*"}, repo: "octocat/hello", want: true},
+		{name: "double wildcard matches everything", patterns: []string{"**"}, repo: "octocat/hello", want: true},
+		{name: "double wildcard as owner", patterns: []string{"**/api"}, repo: "myorg/api", want: true},
+		{name: "question mark", patterns: []string{"myorg/api-v?"}, repo: "myorg/api-v2", want: true},
+		{name: "character class range", patterns: []string{"myorg/api-[0-9]"}, repo: "myorg/api-7", want: true},
+		{name: "negated character class", patterns: []string{"myorg/api-[!0-9]"}, repo: "myorg/api-7", want: false},
+		{name: "case sensitive by default", patterns: []string{"MyOrg/*"}, repo: "myorg/api", want: false},
+		{name: "case insensitive", patterns: []string{"MyOrg/*"}, ignoreCase: true, repo: "myorg/api", want: true},
+		{name: "exclusion after inclusion", patterns: []string{"myorg/*", "!myorg/legacy-*"}, repo: "myorg/legacy-api", want: false},
+		{name: "inclusion after exclusion wins", patterns: []string{"myorg/*", "!myorg/legacy-*", "myorg/legacy-keep"}, repo: "myorg/legacy-keep", want: true},
+		{name: "only exclusions include the rest", patterns: []string{"!myorg/secret"}, repo: "other/repo", want: true},
+		{name: "only exclusions exclude the match", patterns: []string{"!myorg/secret"}, repo: "myorg/secret", want: false},
+		{
+			name:     "exclude archived",
+			patterns: []string{"myorg/*", "!myorg/* archived:true"},
+			repo:     "myorg/old",
+			attrs:    &RepositoryAttributes{Archived: true, Visibility: "private"},
+			want:     false,
+		},
+		{
+			name:     "exclude archived keeps active repositories",
+			patterns: []string{"myorg/*", "!myorg/* archived:true"},
+			repo:     "myorg/new",
+			attrs:    &RepositoryAttributes{Visibility: "private"},
+			want:     true,
+		},
+		{
+			name:     "exclusion qualifiers skipped without attributes",
+			patterns: []string{"myorg/*", "!myorg/* archived:true"},
+			repo:     "myorg/old",
+			want:     true,
+		},
+		{
+			name:     "include only non-forks",
+			patterns: []string{"myorg/* fork:false"},
+			repo:     "myorg/fork",
+			attrs:    &RepositoryAttributes{Fork: true},
+			want:     false,
+		},
+		{
+			name:     "inclusion qualifiers ignored without attributes",
+			patterns: []string{"myorg/* fork:false"},
+			repo:     "myorg/fork",
+			want:     true,
+		},
+		{
+			name:     "visibility qualifier",
+			patterns: []string{"myorg/* visibility:public"},
+			repo:     "myorg/api",
+			attrs:    &RepositoryAttributes{Visibility: "internal"},
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := ParseRepositoryFilter(tt.patterns, tt.ignoreCase)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, filter.Allows(tt.repo, tt.attrs))
+		})
+	}
+}
+
+func TestRepositoryFilterMatchesOwner(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		owner    string
+		want     bool
+	}{
+		{name: "no patterns match every owner", patterns: nil, owner: "myorg", want: true},
+		{name: "only exclusions match every owner", patterns: []string{"!myorg/secret"}, owner: "myorg", want: true},
+		{name: "literal owner", patterns: []string{"myorg/*"}, owner: "myorg", want: true},
+		{name: "other owner", patterns: []string{"myorg/*"}, owner: "other", want: false},
+		{name: "owner glob", patterns: []string{"team-*/api"}, owner: "team-web", want: true},
+		{name: "double wildcard", patterns: []string{"**"}, owner: "anything", want: true},
+		{name: "exclusion does not select owner", patterns: []string{"myorg/*", "!other/*"}, owner: "other", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := ParseRepositoryFilter(tt.patterns, false)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, filter.MatchesOwner(tt.owner))
+		})
+	}
+}
+
+func TestParseRepositoryFilter_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		wantErr  string
+	}{
+		{
+			name:     "missing slash",
+			patterns: []string{"myorg"},
+			wantErr:  "repository pattern at line 1, column 1: pattern must be in 'owner/repo' format (e.g., 'myorg/*', 'user/repo'). Got: 'myorg'",
+		},
+		{
+			name:     "empty owner",
+			patterns: []string{"myorg/*", "/repo"},
+			wantErr:  "repository pattern at line 2, column 1: owner part cannot be empty. Got: '/repo'",
+		},
+		{
+			name:     "empty repo",
+			patterns: []string{"myorg/"},
+			wantErr:  "repository pattern at line 1, column 7: repo part cannot be empty. Got: 'myorg/'",
+		},
+		{
+			name:     "too many separators",
+			patterns: []string{"a/b/c"},
+			wantErr:  "repository pattern at line 1, column 4: pattern must have exactly one '/' separator (e.g., 'owner/repo'). Got: 'a/b/c'",
+		},
+		{
+			name:     "unterminated class",
+			patterns: []string{"myorg/api-[0-9"},
+			wantErr:  "repository pattern at line 1, column 11: unterminated character class. Got: 'myorg/api-[0-9'",
+		},
+		{
+			name:     "invalid range",
+			patterns: []string{"myorg/[z-a]"},
+			wantErr:  "repository pattern at line 1, column 8: invalid range \"z-a\" in character class. Got: 'myorg/[z-a]'",
+		},
+		{
+			name:     "whitespace inside pattern",
+			patterns: []string{"my org/*"},
+			wantErr:  "repository pattern at line 1, column 1: pattern must be in 'owner/repo' format (e.g., 'myorg/*', 'user/repo'). Got: 'my org/*'",
+		},
+		{
+			name:     "invalid character in name",
+			patterns: []string{"myorg/a+b"},
+			wantErr:  "repository pattern at line 1, column 8: invalid character '+'. Got: 'myorg/a+b'",
+		},
+		{
+			name:     "triple wildcard",
+			patterns: []string{"myorg/***"},
+			wantErr:  "repository pattern at line 1, column 7: too many consecutive '*' (use '*' or '**'). Got: 'myorg/***'",
+		},
+		{
+			name:     "missing pattern after negation",
+			patterns: []string{"!"},
+			wantErr:  "repository pattern at line 1, column 2: missing repository pattern after '!'. Got: '!'",
+		},
+		{
+			name:     "invalid qualifier value",
+			patterns: []string{"myorg/* fork:maybe"},
+			wantErr:  "repository pattern at line 1, column 9: invalid value \"maybe\" for fork: expected true or false. Got: 'myorg/* fork:maybe'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRepositoryFilter(tt.patterns, false)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNewRepositoryFilterFromConfig(t *testing.T) {
+	filter, err := NewRepositoryFilterFromConfig(map[string]any{
+		"repository_patterns":             []any{"MyOrg/*", "", "!myorg/secret"},
+		"repository_patterns_ignore_case": true,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, filter.Rules(), 2)
+	assert.True(t, filter.Allows("myorg/api", nil))
+	assert.False(t, filter.Allows("MYORG/SECRET", nil))
+
+	_, err = NewRepositoryFilterFromConfig(map[string]any{"repository_patterns": []any{1}})
+	assert.EqualError(t, err, "repository_patterns[0] must be a string")
+}
+
+func TestRepositoryAttributesFromAPI(t *testing.T) {
+	assert.Equal(t,
+		&RepositoryAttributes{Fork: true, Archived: false, Visibility: "private"},
+		RepositoryAttributesFromAPI(map[string]any{"fork": true, "archived": false, "private": true}))
+	assert.Equal(t,
+		&RepositoryAttributes{Visibility: "internal"},
+		RepositoryAttributesFromAPI(map[string]any{"visibility": "internal"}))
+}
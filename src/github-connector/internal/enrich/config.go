@@ -1,13 +1,49 @@
 package enrich
 
+import (
+	"fmt"
+	"github-connector/internal/core"
+	"regexp"
+	"strings"
+)
+
+// reJiraProjectKey matches a Jira project key such as "PROJ"
+var reJiraProjectKey = regexp.MustCompile(`^[A-Z][A-Z0-9]+$`)
+
 type config struct {
 	contextType      string
 	enrichmentParams map[string]any
+	repositoryFilter *core.RepositoryFilter
+	// jiraProjects are the upper-cased Jira project keys recognised in titles and branches, or
+	// empty to recognise any project
+	jiraProjects map[string]bool
 }
 
 func newConfig(contextType string, cfg map[string]any, params map[string]any) (*config, error) {
+	repositoryFilter, err := core.NewRepositoryFilterFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	jiraProjects := map[string]bool{}
+	if keys, ok := cfg["jira_project_keys"].([]any); ok {
+		for _, k := range keys {
+			key, _ := k.(string)
+			key = strings.ToUpper(strings.TrimSpace(key))
+			if key == "" {
+				continue
+			}
+			if !reJiraProjectKey.MatchString(key) {
+				return nil, fmt.Errorf("invalid jira_project_keys: %q", k)
+			}
+			jiraProjects[key] = true
+		}
+	}
+
 	return &config{
 		contextType:      contextType,
 		enrichmentParams: params,
+		repositoryFilter: repositoryFilter,
+		jiraProjects:     jiraProjects,
 	}, nil
 }
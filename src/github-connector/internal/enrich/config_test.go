@@ -1,6 +1,7 @@
 package enrich
 
 import (
+	"github-connector/internal/core"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,9 +24,46 @@ func TestNewConfig(t *testing.T) {
 			wantConfig: &config{
 				contextType:      "pull_request",
 				enrichmentParams: map[string]any{},
+				repositoryFilter: &core.RepositoryFilter{},
+				jiraProjects:     map[string]bool{},
 			},
 			wantErr: false,
 		},
+		{
+			name:        "valid config - jira project keys",
+			contextType: "pull_request",
+			cfg: map[string]any{
+				"jira_project_keys": []any{"proj", " OPS ", ""},
+			},
+			params: map[string]any{},
+			wantConfig: &config{
+				contextType:      "pull_request",
+				enrichmentParams: map[string]any{},
+				repositoryFilter: &core.RepositoryFilter{},
+				jiraProjects:     map[string]bool{"PROJ": true, "OPS": true},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "invalid config - invalid jira project key",
+			contextType: "pull_request",
+			cfg: map[string]any{
+				"jira_project_keys": []any{"PROJ-1"},
+			},
+			params:     map[string]any{},
+			wantConfig: nil,
+			wantErr:    true,
+		},
+		{
+			name:        "invalid config - invalid repository pattern",
+			contextType: "pull_request",
+			cfg: map[string]any{
+				"repository_patterns": []any{"!"},
+			},
+			params:     map[string]any{},
+			wantConfig: nil,
+			wantErr:    true,
+		},
 	}
 
 	for _, tt := range tests {
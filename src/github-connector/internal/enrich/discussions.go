@@ -0,0 +1,82 @@
+package enrich
+
+import (
+	"fmt"
+	"github-connector/internal/core"
+	"time"
+)
+
+func (e *ContextEnricher) enrichDiscussion(context *core.Context) (*core.Context, error) {
+	repo, ok := e.config.enrichmentParams["repo"].(string)
+	if !ok || repo == "" {
+		return nil, fmt.Errorf("repo not found in enrichment_params")
+	}
+	number, ok := e.config.enrichmentParams["discussion_number"].(string)
+	if !ok || number == "" {
+		return nil, fmt.Errorf("discussion_number not found in enrichment_params")
+	}
+
+	e.logger.Info(fmt.Sprintf("Enriching discussion: %s #%s", repo, number))
+
+	response, err := e.httpClient.FetchDiscussion(repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch discussion data: %w", err)
+	}
+
+	return e.applyDiscussionEnrichment(context, response)
+}
+
+func (e *ContextEnricher) applyDiscussionEnrichment(context *core.Context, apiResp map[string]any) (*core.Context, error) {
+	title := getStringValue(apiResp, "title")
+	description := getStringValue(apiResp, "body")
+	url := getStringValue(apiResp, "url")
+	createdAt, err := time.Parse(time.RFC3339, getStringValue(apiResp, "createdAt"))
+	if err != nil {
+		return nil, err
+	} else {
+		createdAt = createdAt.UTC()
+		context.CreatedAt = &createdAt
+	}
+	updatedAt, err := time.Parse(time.RFC3339, getStringValue(apiResp, "updatedAt"))
+	if err != nil {
+		return nil, err
+	} else {
+		updatedAt = updatedAt.UTC()
+		context.UpdatedAt = &updatedAt
+	}
+
+	context.Title = &title
+	context.Description = &description
+	context.Url = &url
+
+	metadataMap, _ := context.Metadata.(map[string]any)
+	if metadataMap == nil {
+		metadataMap = make(map[string]any)
+	}
+
+	labels := []string{}
+	nodes, _ := getNestedValue(apiResp, "labels", "nodes").([]any)
+	for _, node := range nodes {
+		if label, ok := node.(map[string]any); ok && getStringValue(label, "name") != "" {
+			labels = append(labels, getStringValue(label, "name"))
+		}
+	}
+
+	metadataMap["author"] = getNestedString(apiResp, "author", "login")
+	metadataMap["category"] = getNestedString(apiResp, "category", "name")
+	// Only categories that accept answers (e.g., Q&A) can be answered
+	metadataMap["answerable"] = getNestedValue(apiResp, "category", "isAnswerable")
+	metadataMap["is_answered"] = apiResp["isAnswered"]
+	metadataMap["answer_author"] = getNestedString(apiResp, "answer", "author", "login")
+	metadataMap["answer_url"] = getNestedString(apiResp, "answer", "url")
+	metadataMap["answer_chosen_at"] = getStringValue(apiResp, "answerChosenAt")
+	metadataMap["closed"] = apiResp["closed"]
+	metadataMap["locked"] = apiResp["locked"]
+	metadataMap["upvotes"] = apiResp["upvoteCount"]
+	metadataMap["comments"] = getNestedValue(apiResp, "comments", "totalCount")
+	metadataMap["labels"] = labels
+
+	context.Metadata = metadataMap
+
+	return context, nil
+}
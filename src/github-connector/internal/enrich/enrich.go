@@ -3,6 +3,7 @@ package enrich
 import (
 	"fmt"
 	"github-connector/internal/core"
+	"strconv"
 	"time"
 )
 
@@ -31,6 +32,10 @@ func NewContextEnricher(httpClient HTTPClient, contextType string, cfg, params m
 func (e *ContextEnricher) EnrichContext(context *core.Context) (*core.Context, error) {
 	e.logger.Info("Starting to enrich context")
 
+	if repo, _ := e.config.enrichmentParams["repo"].(string); repo != "" && e.isExcluded(repo, nil) {
+		return context, nil
+	}
+
 	switch e.config.contextType {
 	case core.ResourceTypeSource:
 		context.Title = ptrString("GitHub")
@@ -44,6 +49,26 @@ func (e *ContextEnricher) EnrichContext(context *core.Context) (*core.Context, e
 		return e.enrichPullRequest(context)
 	case core.ResourceTypeIssue:
 		return e.enrichIssue(context)
+	case core.ResourceTypeWorkflow:
+		return e.enrichWorkflow(context)
+	case core.ResourceTypeWorkflowRun:
+		return e.enrichWorkflowRun(context)
+	case core.ResourceTypeReviewThread:
+		return e.enrichReviewThread(context)
+	case core.ResourceTypeProject:
+		return e.enrichProject(context)
+	case core.ResourceTypeProjectItem:
+		return e.enrichProjectItem(context)
+	case core.ResourceTypeGist:
+		return e.enrichGist(context)
+	case core.ResourceTypeDiscussion:
+		return e.enrichDiscussion(context)
+	case core.ResourceTypeBranch:
+		return e.enrichBranch(context)
+	case core.ResourceTypeCommit:
+		return e.enrichCommit(context)
+	case core.ResourceTypeRelease:
+		return e.enrichRelease(context)
 	default:
 		return nil, fmt.Errorf("unsupported context type: %s", e.config.contextType)
 	}
@@ -61,6 +86,9 @@ func (e *ContextEnricher) enrichRepository(context *core.Context) (*core.Context
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch repository data: %w", err)
 	}
+	if e.isExcluded(repo, response) {
+		return context, nil
+	}
 
 	return e.applyRepositoryEnrichment(context, response)
 }
@@ -124,8 +152,18 @@ func (e *ContextEnricher) enrichPullRequest(context *core.Context) (*core.Contex
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch pull request data: %w", err)
 	}
+	if baseRepo, ok := getNestedValue(response, "base", "repo").(map[string]any); ok && e.isExcluded(repo, baseRepo) {
+		return context, nil
+	}
+
+	context, err = e.applyPullRequestEnrichment(context, response)
+	if err != nil {
+		return nil, err
+	}
+	e.applyRelatedWork(context, repo, number, response, getNestedString(response, "head", "ref"))
+	e.applyProjectItems(context, repo, number)
 
-	return e.applyPullRequestEnrichment(context, response)
+	return context, nil
 }
 
 func (e *ContextEnricher) applyPullRequestEnrichment(context *core.Context, apiResp map[string]any) (*core.Context, error) {
@@ -194,7 +232,14 @@ func (e *ContextEnricher) enrichIssue(context *core.Context) (*core.Context, err
 		return nil, fmt.Errorf("failed to fetch issue data: %w", err)
 	}
 
-	return e.applyIssueEnrichment(context, response)
+	context, err = e.applyIssueEnrichment(context, response)
+	if err != nil {
+		return nil, err
+	}
+	e.applyRelatedWork(context, repo, number, response, "")
+	e.applyProjectItems(context, repo, number)
+
+	return context, nil
 }
 
 func (e *ContextEnricher) applyIssueEnrichment(context *core.Context, apiResp map[string]any) (*core.Context, error) {
@@ -237,6 +282,280 @@ func (e *ContextEnricher) applyIssueEnrichment(context *core.Context, apiResp ma
 	return context, nil
 }
 
+// applyRelatedWork records the work linked from a pull request or issue in the "related"
+// metadata: references in its title and body, Jira keys in its title and branch, and the issues
+// and pull requests that referenced it. A timeline failure only drops the cross-references.
+func (e *ContextEnricher) applyRelatedWork(context *core.Context, repo, number string, apiResp map[string]any, branch string) {
+	n, _ := strconv.Atoi(number)
+	collector := newRelatedCollector(repo, n, e.config.repositoryFilter, e.config.jiraProjects)
+
+	title := getStringValue(apiResp, "title")
+	collector.addText(title, "title")
+	collector.addText(getStringValue(apiResp, "body"), "body")
+	collector.addJiraKeys(title, "title")
+	if branch != "" {
+		collector.addJiraKeys(branch, "branch")
+	}
+
+	events, err := e.httpClient.FetchIssueTimeline(repo, number)
+	if err != nil {
+		e.logger.Warn(fmt.Sprintf("Failed to fetch timeline of %s #%s: %s", repo, number, err.Error()))
+	} else {
+		collector.addTimeline(events)
+	}
+
+	metadataMap, _ := context.Metadata.(map[string]any)
+	if metadataMap == nil {
+		metadataMap = make(map[string]any)
+	}
+	metadataMap["related"] = collector.list()
+	context.Metadata = metadataMap
+}
+
+func (e *ContextEnricher) enrichWorkflow(context *core.Context) (*core.Context, error) {
+	repo, ok := e.config.enrichmentParams["repo"].(string)
+	if !ok || repo == "" {
+		return nil, fmt.Errorf("repo not found in enrichment_params")
+	}
+	workflowFile, ok := e.config.enrichmentParams["workflow_file"].(string)
+	if !ok || workflowFile == "" {
+		return nil, fmt.Errorf("workflow_file not found in enrichment_params")
+	}
+
+	e.logger.Info(fmt.Sprintf("Enriching workflow: %s %s", repo, workflowFile))
+
+	response, err := e.httpClient.FetchWorkflow(repo, workflowFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workflow data: %w", err)
+	}
+
+	return e.applyWorkflowEnrichment(context, repo, workflowFile, response)
+}
+
+func (e *ContextEnricher) applyWorkflowEnrichment(context *core.Context, repo, workflowFile string, apiResp map[string]any) (*core.Context, error) {
+	title := fmt.Sprintf("Workflow: %s", getStringValue(apiResp, "name"))
+	description := getStringValue(apiResp, "path")
+	// html_url points to the workflow file; link to the workflow's run list instead
+	url := fmt.Sprintf("https://github.com/%s/actions/workflows/%s", repo, workflowFile)
+	createdAt, err := time.Parse(time.RFC3339, getStringValue(apiResp, "created_at"))
+	if err != nil {
+		return nil, err
+	} else {
+		createdAt = createdAt.UTC()
+		context.CreatedAt = &createdAt
+	}
+	updatedAt, err := time.Parse(time.RFC3339, getStringValue(apiResp, "updated_at"))
+	if err != nil {
+		return nil, err
+	} else {
+		updatedAt = updatedAt.UTC()
+		context.UpdatedAt = &updatedAt
+	}
+
+	context.Title = &title
+	context.Description = &description
+	context.Url = &url
+
+	metadataMap, _ := context.Metadata.(map[string]any)
+	if metadataMap == nil {
+		metadataMap = make(map[string]any)
+	}
+
+	metadataMap["workflow_id"] = apiResp["id"]
+	metadataMap["path"] = apiResp["path"]
+	metadataMap["state"] = apiResp["state"]
+	metadataMap["file_url"] = apiResp["html_url"]
+
+	context.Metadata = metadataMap
+
+	return context, nil
+}
+
+func (e *ContextEnricher) enrichWorkflowRun(context *core.Context) (*core.Context, error) {
+	repo, ok := e.config.enrichmentParams["repo"].(string)
+	if !ok || repo == "" {
+		return nil, fmt.Errorf("repo not found in enrichment_params")
+	}
+	runID, ok := e.config.enrichmentParams["run_id"].(string)
+	if !ok || runID == "" {
+		return nil, fmt.Errorf("run_id not found in enrichment_params")
+	}
+
+	e.logger.Info(fmt.Sprintf("Enriching workflow run: %s %s", repo, runID))
+
+	response, err := e.httpClient.FetchWorkflowRun(repo, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workflow run data: %w", err)
+	}
+	if repoResp, ok := response["repository"].(map[string]any); ok && e.isExcluded(repo, repoResp) {
+		return context, nil
+	}
+
+	return e.applyWorkflowRunEnrichment(context, response)
+}
+
+func (e *ContextEnricher) applyWorkflowRunEnrichment(context *core.Context, apiResp map[string]any) (*core.Context, error) {
+	runNumber, _ := apiResp["run_number"].(float64)
+	title := fmt.Sprintf("%s #%d", getStringValue(apiResp, "name"), int(runNumber))
+	description := getStringValue(apiResp, "display_title")
+	url := getStringValue(apiResp, "html_url")
+	createdAt, err := time.Parse(time.RFC3339, getStringValue(apiResp, "created_at"))
+	if err != nil {
+		return nil, err
+	} else {
+		createdAt = createdAt.UTC()
+		context.CreatedAt = &createdAt
+	}
+	updatedAt, err := time.Parse(time.RFC3339, getStringValue(apiResp, "updated_at"))
+	if err != nil {
+		return nil, err
+	} else {
+		updatedAt = updatedAt.UTC()
+		context.UpdatedAt = &updatedAt
+	}
+
+	context.Title = &title
+	context.Description = &description
+	context.Url = &url
+
+	metadataMap, _ := context.Metadata.(map[string]any)
+	if metadataMap == nil {
+		metadataMap = make(map[string]any)
+	}
+
+	metadataMap["status"] = apiResp["status"]
+	metadataMap["conclusion"] = apiResp["conclusion"]
+	metadataMap["event"] = apiResp["event"]
+	metadataMap["head_branch"] = apiResp["head_branch"]
+	metadataMap["head_sha"] = apiResp["head_sha"]
+	metadataMap["run_number"] = apiResp["run_number"]
+	metadataMap["run_attempt"] = apiResp["run_attempt"]
+	metadataMap["workflow_id"] = apiResp["workflow_id"]
+	metadataMap["actor"] = getNestedString(apiResp, "actor", "login")
+	metadataMap["triggering_actor"] = getNestedString(apiResp, "triggering_actor", "login")
+	if startedAt, err := time.Parse(time.RFC3339, getStringValue(apiResp, "run_started_at")); err == nil && getStringValue(apiResp, "status") == "completed" {
+		metadataMap["duration_seconds"] = int(updatedAt.Sub(startedAt).Seconds())
+	}
+
+	context.Metadata = metadataMap
+
+	return context, nil
+}
+
+func (e *ContextEnricher) enrichReviewThread(context *core.Context) (*core.Context, error) {
+	repo, ok := e.config.enrichmentParams["repo"].(string)
+	if !ok || repo == "" {
+		return nil, fmt.Errorf("repo not found in enrichment_params")
+	}
+	number, ok := e.config.enrichmentParams["pr_number"].(string)
+	if !ok || number == "" {
+		return nil, fmt.Errorf("pr_number not found in enrichment_params")
+	}
+	commentID, ok := e.config.enrichmentParams["comment_id"].(string)
+	if !ok || commentID == "" {
+		return nil, fmt.Errorf("comment_id not found in enrichment_params")
+	}
+
+	e.logger.Info(fmt.Sprintf("Enriching review thread: %s #%s r%s", repo, number, commentID))
+
+	response, err := e.httpClient.FetchReviewThread(repo, number, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review thread data: %w", err)
+	}
+
+	return e.applyReviewThreadEnrichment(context, response)
+}
+
+func (e *ContextEnricher) applyReviewThreadEnrichment(context *core.Context, apiResp map[string]any) (*core.Context, error) {
+	nodes, _ := getNestedValue(apiResp, "comments", "nodes").([]any)
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("review thread has no comments")
+	}
+	root, _ := nodes[0].(map[string]any)
+	last, _ := nodes[len(nodes)-1].(map[string]any)
+
+	path := getStringValue(apiResp, "path")
+	title := fmt.Sprintf("Review thread on %s", path)
+	if line, ok := apiResp["line"].(float64); ok {
+		title = fmt.Sprintf("Review thread on %s:%d", path, int(line))
+	}
+	description := getStringValue(root, "body")
+	url := getStringValue(root, "url")
+	createdAt, err := time.Parse(time.RFC3339, getStringValue(root, "createdAt"))
+	if err != nil {
+		return nil, err
+	} else {
+		createdAt = createdAt.UTC()
+		context.CreatedAt = &createdAt
+	}
+	updatedAt, err := time.Parse(time.RFC3339, getStringValue(last, "createdAt"))
+	if err != nil {
+		return nil, err
+	} else {
+		updatedAt = updatedAt.UTC()
+		context.UpdatedAt = &updatedAt
+	}
+
+	context.Title = &title
+	context.Description = &description
+	context.Url = &url
+
+	metadataMap, _ := context.Metadata.(map[string]any)
+	if metadataMap == nil {
+		metadataMap = make(map[string]any)
+	}
+
+	comments := make([]map[string]any, 0, len(nodes))
+	participants := []string{}
+	seen := map[string]bool{}
+	for _, node := range nodes {
+		comment, ok := node.(map[string]any)
+		if !ok {
+			continue
+		}
+		author := getNestedString(comment, "author", "login")
+		if author != "" && !seen[author] {
+			seen[author] = true
+			participants = append(participants, author)
+		}
+		comments = append(comments, map[string]any{
+			"id":         comment["databaseId"],
+			"author":     author,
+			"body":       comment["body"],
+			"created_at": comment["createdAt"],
+		})
+	}
+
+	metadataMap["path"] = apiResp["path"]
+	metadataMap["line"] = apiResp["line"]
+	metadataMap["is_resolved"] = apiResp["isResolved"]
+	metadataMap["is_outdated"] = apiResp["isOutdated"]
+	metadataMap["resolved_by"] = getNestedString(apiResp, "resolvedBy", "login")
+	metadataMap["diff_hunk"] = root["diffHunk"]
+	metadataMap["comments_count"] = getNestedValue(apiResp, "comments", "totalCount")
+	metadataMap["participants"] = participants
+	metadataMap["comments"] = comments
+
+	context.Metadata = metadataMap
+
+	return context, nil
+}
+
+// isExcluded reports whether repository_patterns exclude the repository, in which case the
+// context is returned without enrichment. repoResp is the repository object from the API, or
+// nil before any request has been made.
+func (e *ContextEnricher) isExcluded(repo string, repoResp map[string]any) bool {
+	var attrs *core.RepositoryAttributes
+	if repoResp != nil {
+		attrs = core.RepositoryAttributesFromAPI(repoResp)
+	}
+	if e.config.repositoryFilter.Allows(repo, attrs) {
+		return false
+	}
+	e.logger.Info(fmt.Sprintf("Repository %s is excluded by repository_patterns, skipping enrichment", repo))
+	return true
+}
+
 // getStringValue safely extracts string value from map
 func getStringValue(m map[string]any, key string) string {
 	if val, ok := m[key]; ok {
@@ -265,6 +584,19 @@ func getNestedString(m map[string]any, keys ...string) string {
 	return ""
 }
 
+// getNestedValue safely extracts a nested value
+func getNestedValue(m map[string]any, keys ...string) any {
+	var current any = m
+	for _, key := range keys {
+		nested, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = nested[key]
+	}
+	return current
+}
+
 // extractLogins extracts login names from array of user objects
 func extractLogins(usersInterface any) []string {
 	if usersInterface == nil {
@@ -99,6 +99,8 @@ func TestEnrichContext(t *testing.T) {
 
 				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
 				mockHTTP.EXPECT().FetchPullRequest("owner/repo", "123").Return(response, nil).Times(1)
+				mockHTTP.EXPECT().FetchIssueTimeline("owner/repo", "123").Return([]map[string]any{}, nil).Times(1)
+				mockHTTP.EXPECT().FetchIssueProjectItems("owner/repo", "123").Return([]map[string]any{}, nil).Times(1)
 				return mockHTTP
 			},
 			resourceType: "pull_request",
@@ -131,6 +133,8 @@ func TestEnrichContext(t *testing.T) {
 					"merged":        true,
 					"merged_at":     "2025-11-13T05:34:49Z",
 					"merged_by":     "john",
+					"related":       []map[string]any{},
+					"projects":      []map[string]any{},
 				},
 			},
 			wantErr: false,
@@ -142,6 +146,8 @@ func TestEnrichContext(t *testing.T) {
 
 				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
 				mockHTTP.EXPECT().FetchIssue("owner/repo", "123").Return(response, nil).Times(1)
+				mockHTTP.EXPECT().FetchIssueTimeline("owner/repo", "123").Return([]map[string]any{}, nil).Times(1)
+				mockHTTP.EXPECT().FetchIssueProjectItems("owner/repo", "123").Return([]map[string]any{}, nil).Times(1)
 				return mockHTTP
 			},
 			resourceType: "issue",
@@ -165,10 +171,534 @@ func TestEnrichContext(t *testing.T) {
 					"labels":    []string{"bug", "enhancement", "good first issue", "UI", "signal: traces"},
 					"milestone": "",
 					"comments":  float64(2),
+					"related":   []map[string]any{},
+					"projects":  []map[string]any{},
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "skip context of excluded repository",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				return mockHTTP
+			},
+			resourceType: "issue",
+			cfg: map[string]any{
+				"active_auth_method":  "token",
+				"repository_patterns": []any{"owner/*", "!owner/repo"},
+			},
+			params: map[string]any{
+				"repo":         "owner/repo",
+				"issue_number": "123",
+			},
+			want:    &core.Context{},
+			wantErr: false,
+		},
+		{
+			name: "enrich workflow context",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/enrichment/workflow.json")
+
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchWorkflow("testorg/testrepo", "release.yml").Return(response, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "workflow",
+			cfg: map[string]any{
+				"active_auth_method": "token",
+			},
+			params: map[string]any{
+				"repo":          "testorg/testrepo",
+				"workflow_file": "release.yml",
+			},
+			want: &core.Context{
+				Title:       ptrString("Workflow: Release"),
+				Description: ptrString(".github/workflows/release.yml"),
+				Url:         ptrString("https://github.com/testorg/testrepo/actions/workflows/release.yml"),
+				CreatedAt:   ptrTime(time.Date(2024, 3, 24, 10, 15, 2, 0, time.UTC)),
+				UpdatedAt:   ptrTime(time.Date(2025, 10, 2, 8, 44, 19, 0, time.UTC)),
+				Metadata: map[string]any{
+					"workflow_id": float64(90654321),
+					"path":        ".github/workflows/release.yml",
+					"state":       "active",
+					"file_url":    "https://github.com/testorg/testrepo/blob/main/.github/workflows/release.yml",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "enrich workflow run context",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/actions/workflow_run.json")
+
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchWorkflowRun("testorg/testrepo", "19429567890").Return(response, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "workflow_run",
+			cfg: map[string]any{
+				"active_auth_method": "token",
+			},
+			params: map[string]any{
+				"repo":   "testorg/testrepo",
+				"run_id": "19429567890",
+			},
+			want: &core.Context{
+				Title:       ptrString("Release #214"),
+				Description: ptrString("chore: release v0.6.0"),
+				Url:         ptrString("https://github.com/testorg/testrepo/actions/runs/19429567890"),
+				CreatedAt:   ptrTime(time.Date(2025, 11, 18, 1, 58, 12, 0, time.UTC)),
+				UpdatedAt:   ptrTime(time.Date(2025, 11, 18, 2, 9, 42, 0, time.UTC)),
+				Metadata: map[string]any{
+					"status":           "completed",
+					"conclusion":       "success",
+					"event":            "workflow_dispatch",
+					"head_branch":      "main",
+					"head_sha":         "4fb5eb96ecc5141ff2383d720508bd0ccaa1b820",
+					"run_number":       float64(214),
+					"run_attempt":      float64(1),
+					"workflow_id":      float64(90654321),
+					"actor":            "ymtdzzz",
+					"triggering_actor": "ymtdzzz",
+					"duration_seconds": 570,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "skip workflow run context excluded by attributes",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/actions/workflow_run.json")
+
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchWorkflowRun("testorg/testrepo", "19429567890").Return(response, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "workflow_run",
+			cfg: map[string]any{
+				"active_auth_method":  "token",
+				"repository_patterns": []any{"!testorg/* visibility:public"},
+			},
+			params: map[string]any{
+				"repo":   "testorg/testrepo",
+				"run_id": "19429567890",
+			},
+			want:    &core.Context{},
+			wantErr: false,
+		},
+		{
+			name: "enrich review thread context",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/enrichment/review_thread.json")
+
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchReviewThread("testorg/testrepo", "52580", "2532700510").Return(response, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "review_thread",
+			cfg: map[string]any{
+				"active_auth_method": "token",
+			},
+			params: map[string]any{
+				"repo":       "testorg/testrepo",
+				"pr_number":  "52580",
+				"comment_id": "2532700510",
+			},
+			want: &core.Context{
+				Title:       ptrString("Review thread on path/to/file.rb:42"),
+				Description: ptrString("Should this handle nil?"),
+				Url:         ptrString("https://github.com/testorg/testrepo/pull/52580#discussion_r2532700510"),
+				CreatedAt:   ptrTime(time.Date(2025, 11, 17, 4, 56, 30, 0, time.UTC)),
+				UpdatedAt:   ptrTime(time.Date(2025, 11, 17, 5, 21, 45, 0, time.UTC)),
+				Metadata: map[string]any{
+					"path":           "path/to/file.rb",
+					"line":           float64(42),
+					"is_resolved":    true,
+					"is_outdated":    false,
+					"resolved_by":    "john",
+					"diff_hunk":      "@@ -38,6 +38,8 @@ def process(item)\n   value = item.value\n+  normalized = normalize(value)\n+  store(normalized)",
+					"comments_count": float64(3),
+					"participants":   []string{"ymtdzzz", "john"},
+					"comments": []map[string]any{
+						{"id": float64(2532700510), "author": "ymtdzzz", "body": "Should this handle nil?", "created_at": "2025-11-17T04:56:30Z"},
+						{"id": float64(2532711122), "author": "john", "body": "Good catch, added a guard.", "created_at": "2025-11-17T05:10:02Z"},
+						{"id": float64(2532723344), "author": "ymtdzzz", "body": "Thanks!", "created_at": "2025-11-17T05:21:45Z"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "enrich project context",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/enrichment/project.json")
+
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchProject("organization", "testorg", "5").Return(response, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "project",
+			cfg: map[string]any{
+				"active_auth_method": "token",
+			},
+			params: map[string]any{
+				"owner":          "testorg",
+				"owner_type":     "organization",
+				"project_number": "5",
+			},
+			want: &core.Context{
+				Title:       ptrString("Platform Roadmap"),
+				Description: ptrString("Quarterly plan of the platform team"),
+				Url:         ptrString("https://github.com/orgs/testorg/projects/5"),
+				CreatedAt:   ptrTime(time.Date(2025, 6, 2, 8, 15, 0, 0, time.UTC)),
+				UpdatedAt:   ptrTime(time.Date(2025, 11, 18, 9, 35, 12, 0, time.UTC)),
+				Metadata: map[string]any{
+					"closed":      false,
+					"public":      true,
+					"items_count": float64(42),
+					"fields":      []string{"Title", "Status", "Iteration", "Estimate"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "skip project context of owner excluded by repository_patterns",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				return mock_enrich.NewMockHTTPClient(ctrl)
+			},
+			resourceType: "project",
+			cfg: map[string]any{
+				"active_auth_method":  "token",
+				"repository_patterns": []any{"otherorg/*"},
+			},
+			params: map[string]any{
+				"owner":          "testorg",
+				"owner_type":     "organization",
+				"project_number": "5",
+			},
+			want:    &core.Context{},
+			wantErr: false,
+		},
+		{
+			name: "enrich project item context",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/enrichment/project_item.json")
+
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchProjectItem("user", "octocat", "5", "118270345").Return(response, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "project_item",
+			cfg: map[string]any{
+				"active_auth_method": "token",
+			},
+			params: map[string]any{
+				"owner":          "octocat",
+				"owner_type":     "user",
+				"project_number": "5",
+				"item_id":        "118270345",
+			},
+			want: &core.Context{
+				Title:       ptrString("Retry failed uploads"),
+				Description: ptrString("Uploads that fail with a 5xx should be retried."),
+				Url:         ptrString("https://github.com/testorg/testrepo/issues/340"),
+				CreatedAt:   ptrTime(time.Date(2025, 11, 18, 9, 30, 0, 0, time.UTC)),
+				UpdatedAt:   ptrTime(time.Date(2025, 11, 18, 9, 35, 12, 0, time.UTC)),
+				Metadata: map[string]any{
+					"project_title": "Platform Roadmap",
+					"content_type":  "issue",
+					"is_archived":   false,
+					"status":        "In Progress",
+					"fields": map[string]any{
+						"Title":     "Retry failed uploads",
+						"Status":    "In Progress",
+						"Iteration": map[string]any{"title": "Sprint 12", "start_date": "2025-11-17", "duration": float64(14)},
+						"Estimate":  float64(3),
+						"Due":       "2025-11-28",
+					},
+					"repo":               "testorg/testrepo",
+					"number":             340,
+					"content_context_id": "github:issue:testorg/testrepo:340",
+					"state":              "OPEN",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "skip project item of excluded repository",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/enrichment/project_item.json")
+
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchProjectItem("organization", "testorg", "5", "118270345").Return(response, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "project_item",
+			cfg: map[string]any{
+				"active_auth_method":  "token",
+				"repository_patterns": []any{"testorg/*", "!testorg/testrepo"},
+			},
+			params: map[string]any{
+				"owner":          "testorg",
+				"project_number": "5",
+				"item_id":        "118270345",
+			},
+			want:    &core.Context{},
+			wantErr: false,
+		},
+		{
+			name: "enrich gist context",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/enrichment/gist.json")
+
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchGist("6cad326836d38bd3a7ae").Return(response, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "gist",
+			cfg: map[string]any{
+				"active_auth_method": "token",
+			},
+			params: map[string]any{
+				"owner":   "octocat",
+				"gist_id": "6cad326836d38bd3a7ae",
+			},
+			want: &core.Context{
+				Title:       ptrString("Retry helper with exponential backoff"),
+				Description: ptrString("Retry helper with exponential backoff"),
+				Url:         ptrString("https://gist.github.com/octocat/6cad326836d38bd3a7ae"),
+				CreatedAt:   ptrTime(time.Date(2025, 11, 12, 3, 14, 7, 0, time.UTC)),
+				UpdatedAt:   ptrTime(time.Date(2025, 11, 17, 22, 41, 30, 0, time.UTC)),
+				Metadata: map[string]any{
+					"owner":     "octocat",
+					"public":    true,
+					"files":     []string{"README.md", "retry.go", "retry_test.go"},
+					"languages": []string{"Markdown", "Go"},
+					"comments":  float64(2),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "enrich discussion context",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/enrichment/discussion.json")
+
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchDiscussion("testorg/testrepo", "7").Return(response, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "discussion",
+			cfg: map[string]any{
+				"active_auth_method": "token",
+			},
+			params: map[string]any{
+				"repo":              "testorg/testrepo",
+				"discussion_number": "7",
+			},
+			want: &core.Context{
+				Title:       ptrString("RFC: Move uploads to a background queue"),
+				Description: ptrString("Uploads currently block the request. This RFC proposes a queue."),
+				Url:         ptrString("https://github.com/testorg/testrepo/discussions/7"),
+				CreatedAt:   ptrTime(time.Date(2025, 11, 10, 1, 2, 3, 0, time.UTC)),
+				UpdatedAt:   ptrTime(time.Date(2025, 11, 18, 10, 20, 30, 0, time.UTC)),
+				Metadata: map[string]any{
+					"author":           "ymtdzzz",
+					"category":         "RFC",
+					"answerable":       true,
+					"is_answered":      true,
+					"answer_author":    "john",
+					"answer_url":       "https://github.com/testorg/testrepo/discussions/7#discussioncomment-14801234",
+					"answer_chosen_at": "2025-11-18T10:20:30Z",
+					"closed":           false,
+					"locked":           false,
+					"upvotes":          float64(5),
+					"comments":         float64(12),
+					"labels":           []string{"rfc", "storage"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "skip discussion of excluded repository",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				return mock_enrich.NewMockHTTPClient(ctrl)
+			},
+			resourceType: "discussion",
+			cfg: map[string]any{
+				"active_auth_method":  "token",
+				"repository_patterns": []any{"!testorg/testrepo"},
+			},
+			params: map[string]any{
+				"repo":              "testorg/testrepo",
+				"discussion_number": "7",
+			},
+			want:    &core.Context{},
+			wantErr: false,
+		},
+		{
+			name: "enrich commit context",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/enrichment/commit.json")
+
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchCommit("testorg/testrepo", "6dcb09b5b57875f334f61aebed695e2e4193db5e").Return(response, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "commit",
+			cfg: map[string]any{
+				"active_auth_method": "token",
+			},
+			params: map[string]any{
+				"repo": "testorg/testrepo",
+				"sha":  "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			},
+			want: &core.Context{
+				Title:       ptrString("Fix upload retry on timeout"),
+				Description: ptrString("Fix upload retry on timeout\n\nRetries were skipped when the connection timed out."),
+				Url:         ptrString("https://github.com/testorg/testrepo/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e"),
+				CreatedAt:   ptrTime(time.Date(2025, 11, 17, 0, 12, 45, 0, time.UTC)),
+				UpdatedAt:   ptrTime(time.Date(2025, 11, 17, 1, 30, 0, 0, time.UTC)),
+				Metadata: map[string]any{
+					"sha":                 "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+					"author":              "ymtdzzz",
+					"committer":           "web-flow",
+					"additions":           float64(21),
+					"deletions":           float64(6),
+					"changed_files":       2,
+					"parents_count":       1,
+					"verified":            true,
+					"verification_reason": "valid",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "enrich branch context",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				branch := loadJSONTestData(t, "../../testdata/enrichment/branch.json")
+				repository := loadJSONTestData(t, "../../testdata/enrichment/repository.json")
+				comparison := loadJSONTestData(t, "../../testdata/enrichment/compare.json")
+
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchBranch("testorg/testrepo", "feature/upload-queue").Return(branch, nil).Times(1)
+				mockHTTP.EXPECT().FetchRepository("testorg/testrepo").Return(repository, nil).Times(1)
+				mockHTTP.EXPECT().FetchComparison("testorg/testrepo", "main", "feature/upload-queue").Return(comparison, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "branch",
+			cfg: map[string]any{
+				"active_auth_method": "token",
+			},
+			params: map[string]any{
+				"repo":   "testorg/testrepo",
+				"branch": "feature/upload-queue",
+			},
+			want: &core.Context{
+				Title:     ptrString("feature/upload-queue"),
+				Url:       ptrString("https://github.com/testorg/testrepo/tree/feature/upload-queue"),
+				UpdatedAt: ptrTime(time.Date(2025, 11, 17, 1, 30, 0, 0, time.UTC)),
+				Metadata: map[string]any{
+					"deleted":                false,
+					"protected":              true,
+					"required_status_checks": []string{"ci/test", "ci/lint"},
+					"last_commit_sha":        "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+					"last_commit_message":    "Fix upload retry on timeout",
+					"default_branch":         "main",
+					"is_default":             false,
+					"ahead_by":               float64(3),
+					"behind_by":              float64(12),
+					"comparison_status":      "diverged",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "enrich deleted branch context",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchBranch("testorg/testrepo", "feature/upload-queue").Return(nil, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "branch",
+			cfg: map[string]any{
+				"active_auth_method": "token",
+			},
+			params: map[string]any{
+				"repo":   "testorg/testrepo",
+				"branch": "feature/upload-queue",
+			},
+			want: &core.Context{
+				Metadata: map[string]any{
+					"deleted": true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "enrich release context",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/enrichment/release.json")
+
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchRelease("testorg/testrepo", "v1.4.0").Return(response, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "release",
+			cfg: map[string]any{
+				"active_auth_method": "token",
+			},
+			params: map[string]any{
+				"repo": "testorg/testrepo",
+				"tag":  "v1.4.0",
+			},
+			want: &core.Context{
+				Title:       ptrString("v1.4.0 Upload queue"),
+				Description: ptrString("Uploads are processed in the background."),
+				Url:         ptrString("https://github.com/testorg/testrepo/releases/tag/v1.4.0"),
+				CreatedAt:   ptrTime(time.Date(2025, 11, 17, 1, 30, 0, 0, time.UTC)),
+				UpdatedAt:   ptrTime(time.Date(2025, 11, 17, 2, 0, 0, 0, time.UTC)),
+				Metadata: map[string]any{
+					"tag_name":         "v1.4.0",
+					"target_commitish": "main",
+					"author":           "ymtdzzz",
+					"draft":            false,
+					"prerelease":       false,
+					"published_at":     "2025-11-17T02:00:00Z",
+					"assets": []map[string]any{
+						{
+							"name":           "testrepo_linux_amd64.tar.gz",
+							"content_type":   "application/gzip",
+							"size":           float64(5242880),
+							"download_count": float64(42),
+							"url":            "https://github.com/testorg/testrepo/releases/download/v1.4.0/testrepo_linux_amd64.tar.gz",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "skip repository context excluded by attributes",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/enrichment/repository.json")
+
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchRepository("owner/repo").Return(response, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "repository",
+			cfg: map[string]any{
+				"active_auth_method":  "token",
+				"repository_patterns": []any{"!owner/* visibility:public"},
+			},
+			params: map[string]any{
+				"repo": "owner/repo",
+			},
+			want:    &core.Context{},
+			wantErr: false,
+		},
 		{
 			name: "invalid resource type",
 			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
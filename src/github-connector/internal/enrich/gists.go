@@ -0,0 +1,88 @@
+package enrich
+
+import (
+	"fmt"
+	"github-connector/internal/core"
+	"sort"
+	"time"
+)
+
+func (e *ContextEnricher) enrichGist(context *core.Context) (*core.Context, error) {
+	gistID, ok := e.config.enrichmentParams["gist_id"].(string)
+	if !ok || gistID == "" {
+		return nil, fmt.Errorf("gist_id not found in enrichment_params")
+	}
+	if owner, _ := e.config.enrichmentParams["owner"].(string); owner != "" && !e.ownerAllowed(owner) {
+		return context, nil
+	}
+
+	e.logger.Info(fmt.Sprintf("Enriching gist: %s", gistID))
+
+	response, err := e.httpClient.FetchGist(gistID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch gist data: %w", err)
+	}
+
+	return e.applyGistEnrichment(context, response)
+}
+
+func (e *ContextEnricher) applyGistEnrichment(context *core.Context, apiResp map[string]any) (*core.Context, error) {
+	files, _ := apiResp["files"].(map[string]any)
+	filenames := make([]string, 0, len(files))
+	for name := range files {
+		filenames = append(filenames, name)
+	}
+	// GitHub lists the files of a gist by name
+	sort.Strings(filenames)
+
+	languages := []string{}
+	seen := map[string]bool{}
+	for _, name := range filenames {
+		file, _ := files[name].(map[string]any)
+		if language := getStringValue(file, "language"); language != "" && !seen[language] {
+			seen[language] = true
+			languages = append(languages, language)
+		}
+	}
+
+	description := getStringValue(apiResp, "description")
+	title := description
+	if title == "" && len(filenames) > 0 {
+		// Gists without a description are shown by their first file name
+		title = filenames[0]
+	}
+	url := getStringValue(apiResp, "html_url")
+	createdAt, err := time.Parse(time.RFC3339, getStringValue(apiResp, "created_at"))
+	if err != nil {
+		return nil, err
+	} else {
+		createdAt = createdAt.UTC()
+		context.CreatedAt = &createdAt
+	}
+	updatedAt, err := time.Parse(time.RFC3339, getStringValue(apiResp, "updated_at"))
+	if err != nil {
+		return nil, err
+	} else {
+		updatedAt = updatedAt.UTC()
+		context.UpdatedAt = &updatedAt
+	}
+
+	context.Title = &title
+	context.Description = &description
+	context.Url = &url
+
+	metadataMap, _ := context.Metadata.(map[string]any)
+	if metadataMap == nil {
+		metadataMap = make(map[string]any)
+	}
+
+	metadataMap["owner"] = getNestedString(apiResp, "owner", "login")
+	metadataMap["public"] = apiResp["public"]
+	metadataMap["files"] = filenames
+	metadataMap["languages"] = languages
+	metadataMap["comments"] = apiResp["comments"]
+
+	context.Metadata = metadataMap
+
+	return context, nil
+}
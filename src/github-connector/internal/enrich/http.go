@@ -5,4 +5,31 @@ type HTTPClient interface {
 	FetchRepository(repo string) (map[string]any, error)
 	FetchPullRequest(repo, number string) (map[string]any, error)
 	FetchIssue(repo, number string) (map[string]any, error)
+	// FetchIssueTimeline returns the timeline events of an issue or pull request
+	FetchIssueTimeline(repo, number string) ([]map[string]any, error)
+	FetchWorkflow(repo, workflowFile string) (map[string]any, error)
+	FetchWorkflowRun(repo, runID string) (map[string]any, error)
+	// FetchReviewThread returns the GraphQL PullRequestReviewThread containing the review comment
+	// with the given database ID, including isResolved, path, line and its comments.
+	FetchReviewThread(repo, prNumber, commentID string) (map[string]any, error)
+	// FetchProject returns the GraphQL ProjectV2 of an organization or user, including its
+	// fields and item count. ownerType is core.ProjectOwnerOrganization or core.ProjectOwnerUser.
+	FetchProject(ownerType, owner, projectNumber string) (map[string]any, error)
+	// FetchProjectItem returns the GraphQL ProjectV2Item with the given database ID, including
+	// its content and field values.
+	FetchProjectItem(ownerType, owner, projectNumber, itemID string) (map[string]any, error)
+	// FetchIssueProjectItems returns the GraphQL ProjectV2Items of an issue or pull request,
+	// including their project and field values.
+	FetchIssueProjectItems(repo, number string) ([]map[string]any, error)
+	FetchGist(gistID string) (map[string]any, error)
+	// FetchDiscussion returns the GraphQL Discussion, including its category, answer, labels and
+	// comment count.
+	FetchDiscussion(repo, number string) (map[string]any, error)
+	FetchCommit(repo, sha string) (map[string]any, error)
+	// FetchBranch returns the branch, including its protection, or nil without an error when the
+	// branch does not exist (e.g., it was deleted after merging).
+	FetchBranch(repo, branch string) (map[string]any, error)
+	// FetchComparison compares head with base, returning ahead_by, behind_by and status
+	FetchComparison(repo, base, head string) (map[string]any, error)
+	FetchRelease(repo, tag string) (map[string]any, error)
 }
@@ -0,0 +1,277 @@
+package enrich
+
+import (
+	"fmt"
+	"github-connector/internal/core"
+	"strconv"
+	"time"
+)
+
+func (e *ContextEnricher) enrichProject(context *core.Context) (*core.Context, error) {
+	ownerType, owner, number, err := e.projectParams()
+	if err != nil {
+		return nil, err
+	}
+	if !e.ownerAllowed(owner) {
+		return context, nil
+	}
+
+	e.logger.Info(fmt.Sprintf("Enriching project: %s #%s", owner, number))
+
+	response, err := e.httpClient.FetchProject(ownerType, owner, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch project data: %w", err)
+	}
+
+	return e.applyProjectEnrichment(context, response)
+}
+
+func (e *ContextEnricher) applyProjectEnrichment(context *core.Context, apiResp map[string]any) (*core.Context, error) {
+	title := getStringValue(apiResp, "title")
+	description := getStringValue(apiResp, "shortDescription")
+	url := getStringValue(apiResp, "url")
+	createdAt, err := time.Parse(time.RFC3339, getStringValue(apiResp, "createdAt"))
+	if err != nil {
+		return nil, err
+	} else {
+		createdAt = createdAt.UTC()
+		context.CreatedAt = &createdAt
+	}
+	updatedAt, err := time.Parse(time.RFC3339, getStringValue(apiResp, "updatedAt"))
+	if err != nil {
+		return nil, err
+	} else {
+		updatedAt = updatedAt.UTC()
+		context.UpdatedAt = &updatedAt
+	}
+
+	context.Title = &title
+	context.Description = &description
+	context.Url = &url
+
+	metadataMap, _ := context.Metadata.(map[string]any)
+	if metadataMap == nil {
+		metadataMap = make(map[string]any)
+	}
+
+	fields := []string{}
+	nodes, _ := getNestedValue(apiResp, "fields", "nodes").([]any)
+	for _, node := range nodes {
+		if field, ok := node.(map[string]any); ok && getStringValue(field, "name") != "" {
+			fields = append(fields, getStringValue(field, "name"))
+		}
+	}
+
+	metadataMap["closed"] = apiResp["closed"]
+	metadataMap["public"] = apiResp["public"]
+	metadataMap["items_count"] = getNestedValue(apiResp, "items", "totalCount")
+	metadataMap["fields"] = fields
+
+	context.Metadata = metadataMap
+
+	return context, nil
+}
+
+func (e *ContextEnricher) enrichProjectItem(context *core.Context) (*core.Context, error) {
+	ownerType, owner, number, err := e.projectParams()
+	if err != nil {
+		return nil, err
+	}
+	itemID, ok := e.config.enrichmentParams["item_id"].(string)
+	if !ok || itemID == "" {
+		return nil, fmt.Errorf("item_id not found in enrichment_params")
+	}
+	if !e.ownerAllowed(owner) {
+		return context, nil
+	}
+
+	e.logger.Info(fmt.Sprintf("Enriching project item: %s #%s item %s", owner, number, itemID))
+
+	response, err := e.httpClient.FetchProjectItem(ownerType, owner, number, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch project item data: %w", err)
+	}
+
+	// Issues and pull requests of excluded repositories are not described
+	if repo := getNestedString(response, "content", "repository", "nameWithOwner"); repo != "" && e.isExcluded(repo, nil) {
+		return context, nil
+	}
+
+	return e.applyProjectItemEnrichment(context, response)
+}
+
+func (e *ContextEnricher) applyProjectItemEnrichment(context *core.Context, apiResp map[string]any) (*core.Context, error) {
+	content, _ := apiResp["content"].(map[string]any)
+	title := getStringValue(content, "title")
+	description := getStringValue(content, "body")
+	url := getStringValue(content, "url")
+	if url == "" {
+		// Draft issues have no page of their own
+		url = fmt.Sprintf("%s?itemId=%v", getNestedString(apiResp, "project", "url"), e.config.enrichmentParams["item_id"])
+	}
+	createdAt, err := time.Parse(time.RFC3339, getStringValue(apiResp, "createdAt"))
+	if err != nil {
+		return nil, err
+	} else {
+		createdAt = createdAt.UTC()
+		context.CreatedAt = &createdAt
+	}
+	updatedAt, err := time.Parse(time.RFC3339, getStringValue(apiResp, "updatedAt"))
+	if err != nil {
+		return nil, err
+	} else {
+		updatedAt = updatedAt.UTC()
+		context.UpdatedAt = &updatedAt
+	}
+
+	context.Title = &title
+	context.Description = &description
+	context.Url = &url
+
+	metadataMap, _ := context.Metadata.(map[string]any)
+	if metadataMap == nil {
+		metadataMap = make(map[string]any)
+	}
+
+	fields := projectFieldValues(apiResp["fieldValues"])
+	metadataMap["project_title"] = getNestedString(apiResp, "project", "title")
+	metadataMap["content_type"] = projectContentType(getStringValue(content, "__typename"))
+	metadataMap["is_archived"] = apiResp["isArchived"]
+	metadataMap["status"] = fields["Status"]
+	metadataMap["fields"] = fields
+
+	repo := getNestedString(content, "repository", "nameWithOwner")
+	if number, ok := content["number"].(float64); ok && repo != "" {
+		metadataMap["repo"] = repo
+		metadataMap["number"] = int(number)
+		switch metadataMap["content_type"] {
+		case core.ResourceTypeIssue:
+			metadataMap["content_context_id"] = core.MakeIssueContextID(repo, strconv.Itoa(int(number)))
+		case core.ResourceTypePullRequest:
+			metadataMap["content_context_id"] = core.MakePullRequestContextID(repo, strconv.Itoa(int(number)))
+		}
+		metadataMap["state"] = content["state"]
+	}
+
+	context.Metadata = metadataMap
+
+	return context, nil
+}
+
+// applyProjectItems records the projects (v2) an issue or pull request is on, with its status
+// and field values on each board, in the "projects" metadata. A failure only drops the projects.
+func (e *ContextEnricher) applyProjectItems(context *core.Context, repo, number string) {
+	items, err := e.httpClient.FetchIssueProjectItems(repo, number)
+	if err != nil {
+		e.logger.Warn(fmt.Sprintf("Failed to fetch projects of %s #%s: %s", repo, number, err.Error()))
+		return
+	}
+
+	projects := []map[string]any{}
+	for _, item := range items {
+		project, _ := item["project"].(map[string]any)
+		owner := getNestedString(project, "owner", "login")
+		projectNumber, ok := project["number"].(float64)
+		if !ok || owner == "" {
+			continue
+		}
+		fields := projectFieldValues(item["fieldValues"])
+		entry := map[string]any{
+			"project_owner":  owner,
+			"project_number": int(projectNumber),
+			"project_title":  project["title"],
+			"url":            project["url"],
+			"status":         fields["Status"],
+			"fields":         fields,
+			"is_archived":    item["isArchived"],
+			"context_id":     core.MakeProjectContextID(owner, strconv.Itoa(int(projectNumber))),
+		}
+		if itemID, ok := item["databaseId"].(float64); ok {
+			entry["item_context_id"] = core.MakeProjectItemContextID(owner, strconv.Itoa(int(projectNumber)), strconv.FormatInt(int64(itemID), 10))
+		}
+		projects = append(projects, entry)
+	}
+
+	metadataMap, _ := context.Metadata.(map[string]any)
+	if metadataMap == nil {
+		metadataMap = make(map[string]any)
+	}
+	metadataMap["projects"] = projects
+	context.Metadata = metadataMap
+}
+
+// projectParams reads the project identification from enrichment_params
+func (e *ContextEnricher) projectParams() (ownerType, owner, number string, err error) {
+	owner, ok := e.config.enrichmentParams["owner"].(string)
+	if !ok || owner == "" {
+		return "", "", "", fmt.Errorf("owner not found in enrichment_params")
+	}
+	number, ok = e.config.enrichmentParams["project_number"].(string)
+	if !ok || number == "" {
+		return "", "", "", fmt.Errorf("project_number not found in enrichment_params")
+	}
+	ownerType, _ = e.config.enrichmentParams["owner_type"].(string)
+	if ownerType != core.ProjectOwnerUser {
+		ownerType = core.ProjectOwnerOrganization
+	}
+	return ownerType, owner, number, nil
+}
+
+// ownerAllowed reports whether repository_patterns allow repositories of the owner, in which case
+// the owner's projects are enriched
+func (e *ContextEnricher) ownerAllowed(owner string) bool {
+	if e.config.repositoryFilter.MatchesOwner(owner) {
+		return true
+	}
+	e.logger.Info(fmt.Sprintf("Owner %s is not matched by repository_patterns, skipping enrichment", owner))
+	return false
+}
+
+// projectFieldValues converts the GraphQL field values of a project item to a map from field
+// name to value. Single select, text, number and date fields map to their value; iteration
+// fields map to the iteration title, start date and duration in days.
+func projectFieldValues(fieldValues any) map[string]any {
+	values := map[string]any{}
+	fieldValuesMap, _ := fieldValues.(map[string]any)
+	nodes, _ := fieldValuesMap["nodes"].([]any)
+	for _, n := range nodes {
+		node, ok := n.(map[string]any)
+		if !ok {
+			continue
+		}
+		name := getNestedString(node, "field", "name")
+		if name == "" {
+			continue
+		}
+		switch getStringValue(node, "__typename") {
+		case "ProjectV2ItemFieldSingleSelectValue":
+			values[name] = node["name"]
+		case "ProjectV2ItemFieldTextValue":
+			values[name] = node["text"]
+		case "ProjectV2ItemFieldNumberValue":
+			values[name] = node["number"]
+		case "ProjectV2ItemFieldDateValue":
+			values[name] = node["date"]
+		case "ProjectV2ItemFieldIterationValue":
+			values[name] = map[string]any{
+				"title":      node["title"],
+				"start_date": node["startDate"],
+				"duration":   node["duration"],
+			}
+		}
+	}
+	return values
+}
+
+// projectContentType returns the context resource type of a project item's content, or
+// "draft_issue" for draft issues that exist only on the board
+func projectContentType(typename string) string {
+	switch typename {
+	case "Issue":
+		return core.ResourceTypeIssue
+	case "PullRequest":
+		return core.ResourceTypePullRequest
+	default:
+		return "draft_issue"
+	}
+}
@@ -0,0 +1,41 @@
+package enrich
+
+import (
+	"errors"
+	"github-connector/internal/core"
+	mock_enrich "github-connector/mock/enrich"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestApplyProjectItems(t *testing.T) {
+	item := loadJSONTestData(t, "../../testdata/enrichment/project_item.json")
+	item["project"].(map[string]any)["owner"] = map[string]any{"__typename": "Organization", "login": "testorg"}
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+	mockHTTP.EXPECT().FetchIssueProjectItems("testorg/testrepo", "340").Return([]map[string]any{item, {"project": map[string]any{}}}, nil).Times(1)
+	mockHTTP.EXPECT().FetchIssueProjectItems("testorg/testrepo", "341").Return(nil, errors.New("rate limited")).Times(1)
+
+	enricher, err := NewContextEnricher(mockHTTP, "issue", map[string]any{"active_auth_method": "token"}, map[string]any{}, core.NewNoopLogger())
+	assert.NoError(t, err)
+
+	context := &core.Context{}
+	enricher.applyProjectItems(context, "testorg/testrepo", "340")
+	projects := context.Metadata.(map[string]any)["projects"].([]map[string]any)
+	assert.Len(t, projects, 1)
+	assert.Equal(t, "Platform Roadmap", projects[0]["project_title"])
+	assert.Equal(t, 5, projects[0]["project_number"])
+	assert.Equal(t, "In Progress", projects[0]["status"])
+	assert.Equal(t, "github:project:testorg:5", projects[0]["context_id"])
+	assert.Equal(t, "github:project_item:testorg:5:118270345", projects[0]["item_context_id"])
+
+	// A failure leaves the context without projects
+	context = &core.Context{}
+	enricher.applyProjectItems(context, "testorg/testrepo", "341")
+	assert.Nil(t, context.Metadata)
+}
@@ -0,0 +1,269 @@
+package enrich
+
+import (
+	"fmt"
+	"github-connector/internal/core"
+	"strings"
+	"time"
+)
+
+func (e *ContextEnricher) enrichCommit(context *core.Context) (*core.Context, error) {
+	repo, ok := e.config.enrichmentParams["repo"].(string)
+	if !ok || repo == "" {
+		return nil, fmt.Errorf("repo not found in enrichment_params")
+	}
+	sha, ok := e.config.enrichmentParams["sha"].(string)
+	if !ok || sha == "" {
+		return nil, fmt.Errorf("sha not found in enrichment_params")
+	}
+
+	e.logger.Info(fmt.Sprintf("Enriching commit: %s@%s", repo, core.ShortSHA(sha)))
+
+	response, err := e.httpClient.FetchCommit(repo, sha)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch commit data: %w", err)
+	}
+
+	return e.applyCommitEnrichment(context, response)
+}
+
+func (e *ContextEnricher) applyCommitEnrichment(context *core.Context, apiResp map[string]any) (*core.Context, error) {
+	commit, _ := apiResp["commit"].(map[string]any)
+	message := getStringValue(commit, "message")
+	title, _, _ := strings.Cut(message, "\n")
+	url := getStringValue(apiResp, "html_url")
+	createdAt, err := time.Parse(time.RFC3339, getNestedString(commit, "author", "date"))
+	if err != nil {
+		return nil, err
+	} else {
+		createdAt = createdAt.UTC()
+		context.CreatedAt = &createdAt
+	}
+	updatedAt, err := time.Parse(time.RFC3339, getNestedString(commit, "committer", "date"))
+	if err != nil {
+		return nil, err
+	} else {
+		updatedAt = updatedAt.UTC()
+		context.UpdatedAt = &updatedAt
+	}
+
+	context.Title = &title
+	context.Description = &message
+	context.Url = &url
+
+	metadataMap, _ := context.Metadata.(map[string]any)
+	if metadataMap == nil {
+		metadataMap = make(map[string]any)
+	}
+
+	// Commits by authors without a GitHub account only have a git author name
+	author := getNestedString(apiResp, "author", "login")
+	if author == "" {
+		author = getNestedString(commit, "author", "name")
+	}
+	committer := getNestedString(apiResp, "committer", "login")
+	if committer == "" {
+		committer = getNestedString(commit, "committer", "name")
+	}
+	files, _ := apiResp["files"].([]any)
+	parents, _ := apiResp["parents"].([]any)
+
+	metadataMap["sha"] = apiResp["sha"]
+	metadataMap["author"] = author
+	metadataMap["committer"] = committer
+	metadataMap["additions"] = getNestedValue(apiResp, "stats", "additions")
+	metadataMap["deletions"] = getNestedValue(apiResp, "stats", "deletions")
+	metadataMap["changed_files"] = len(files)
+	metadataMap["parents_count"] = len(parents)
+	metadataMap["verified"] = getNestedValue(commit, "verification", "verified")
+	metadataMap["verification_reason"] = getNestedString(commit, "verification", "reason")
+
+	context.Metadata = metadataMap
+
+	return context, nil
+}
+
+func (e *ContextEnricher) enrichBranch(context *core.Context) (*core.Context, error) {
+	repo, ok := e.config.enrichmentParams["repo"].(string)
+	if !ok || repo == "" {
+		return nil, fmt.Errorf("repo not found in enrichment_params")
+	}
+	branch, ok := e.config.enrichmentParams["branch"].(string)
+	if !ok || branch == "" {
+		return nil, fmt.Errorf("branch not found in enrichment_params")
+	}
+
+	e.logger.Info(fmt.Sprintf("Enriching branch: %s:%s", repo, branch))
+
+	response, err := e.httpClient.FetchBranch(repo, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch branch data: %w", err)
+	}
+
+	metadataMap, _ := context.Metadata.(map[string]any)
+	if metadataMap == nil {
+		metadataMap = make(map[string]any)
+	}
+	if response == nil {
+		// Branches are usually deleted once their pull request is merged
+		metadataMap["deleted"] = true
+		context.Metadata = metadataMap
+		return context, nil
+	}
+	metadataMap["deleted"] = false
+	context.Metadata = metadataMap
+
+	context, err = e.applyBranchEnrichment(context, response)
+	if err != nil {
+		return nil, err
+	}
+	e.applyBranchComparison(context, repo, branch)
+
+	return context, nil
+}
+
+func (e *ContextEnricher) applyBranchEnrichment(context *core.Context, apiResp map[string]any) (*core.Context, error) {
+	title := getStringValue(apiResp, "name")
+	url := getNestedString(apiResp, "_links", "html")
+	commit, _ := apiResp["commit"].(map[string]any)
+	updatedAt, err := time.Parse(time.RFC3339, getNestedString(commit, "commit", "committer", "date"))
+	if err != nil {
+		return nil, err
+	} else {
+		updatedAt = updatedAt.UTC()
+		context.UpdatedAt = &updatedAt
+	}
+
+	context.Title = &title
+	context.Url = &url
+
+	metadataMap, _ := context.Metadata.(map[string]any)
+	if metadataMap == nil {
+		metadataMap = make(map[string]any)
+	}
+
+	requiredChecks := []string{}
+	checks, _ := getNestedValue(apiResp, "protection", "required_status_checks", "contexts").([]any)
+	for _, check := range checks {
+		if name, ok := check.(string); ok {
+			requiredChecks = append(requiredChecks, name)
+		}
+	}
+	lastCommitTitle, _, _ := strings.Cut(getNestedString(commit, "commit", "message"), "\n")
+
+	metadataMap["protected"] = apiResp["protected"]
+	metadataMap["required_status_checks"] = requiredChecks
+	metadataMap["last_commit_sha"] = commit["sha"]
+	metadataMap["last_commit_message"] = lastCommitTitle
+
+	context.Metadata = metadataMap
+
+	return context, nil
+}
+
+// applyBranchComparison records how far the branch is ahead of and behind the default branch of
+// the repository. A failure only drops the comparison.
+func (e *ContextEnricher) applyBranchComparison(context *core.Context, repo, branch string) {
+	metadataMap, _ := context.Metadata.(map[string]any)
+
+	repository, err := e.httpClient.FetchRepository(repo)
+	if err != nil {
+		e.logger.Warn(fmt.Sprintf("Failed to fetch default branch of %s: %s", repo, err.Error()))
+		return
+	}
+	defaultBranch := getStringValue(repository, "default_branch")
+	metadataMap["default_branch"] = defaultBranch
+	metadataMap["is_default"] = defaultBranch == branch
+	if defaultBranch == "" || defaultBranch == branch {
+		return
+	}
+
+	comparison, err := e.httpClient.FetchComparison(repo, defaultBranch, branch)
+	if err != nil {
+		e.logger.Warn(fmt.Sprintf("Failed to compare %s with %s in %s: %s", branch, defaultBranch, repo, err.Error()))
+		return
+	}
+	metadataMap["ahead_by"] = comparison["ahead_by"]
+	metadataMap["behind_by"] = comparison["behind_by"]
+	metadataMap["comparison_status"] = comparison["status"]
+}
+
+func (e *ContextEnricher) enrichRelease(context *core.Context) (*core.Context, error) {
+	repo, ok := e.config.enrichmentParams["repo"].(string)
+	if !ok || repo == "" {
+		return nil, fmt.Errorf("repo not found in enrichment_params")
+	}
+	tag, ok := e.config.enrichmentParams["tag"].(string)
+	if !ok || tag == "" {
+		return nil, fmt.Errorf("tag not found in enrichment_params")
+	}
+
+	e.logger.Info(fmt.Sprintf("Enriching release: %s %s", repo, tag))
+
+	response, err := e.httpClient.FetchRelease(repo, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch release data: %w", err)
+	}
+
+	return e.applyReleaseEnrichment(context, response)
+}
+
+func (e *ContextEnricher) applyReleaseEnrichment(context *core.Context, apiResp map[string]any) (*core.Context, error) {
+	title := getStringValue(apiResp, "name")
+	if title == "" {
+		title = getStringValue(apiResp, "tag_name")
+	}
+	description := getStringValue(apiResp, "body")
+	url := getStringValue(apiResp, "html_url")
+	createdAt, err := time.Parse(time.RFC3339, getStringValue(apiResp, "created_at"))
+	if err != nil {
+		return nil, err
+	} else {
+		createdAt = createdAt.UTC()
+		context.CreatedAt = &createdAt
+	}
+	// Drafts are not published yet
+	if publishedAt, err := time.Parse(time.RFC3339, getStringValue(apiResp, "published_at")); err == nil {
+		publishedAt = publishedAt.UTC()
+		context.UpdatedAt = &publishedAt
+	} else {
+		context.UpdatedAt = &createdAt
+	}
+
+	context.Title = &title
+	context.Description = &description
+	context.Url = &url
+
+	metadataMap, _ := context.Metadata.(map[string]any)
+	if metadataMap == nil {
+		metadataMap = make(map[string]any)
+	}
+
+	assets := []map[string]any{}
+	nodes, _ := apiResp["assets"].([]any)
+	for _, node := range nodes {
+		asset, ok := node.(map[string]any)
+		if !ok {
+			continue
+		}
+		assets = append(assets, map[string]any{
+			"name":           asset["name"],
+			"content_type":   asset["content_type"],
+			"size":           asset["size"],
+			"download_count": asset["download_count"],
+			"url":            asset["browser_download_url"],
+		})
+	}
+
+	metadataMap["tag_name"] = apiResp["tag_name"]
+	metadataMap["target_commitish"] = apiResp["target_commitish"]
+	metadataMap["author"] = getNestedString(apiResp, "author", "login")
+	metadataMap["draft"] = apiResp["draft"]
+	metadataMap["prerelease"] = apiResp["prerelease"]
+	metadataMap["published_at"] = getStringValue(apiResp, "published_at")
+	metadataMap["assets"] = assets
+
+	context.Metadata = metadataMap
+
+	return context, nil
+}
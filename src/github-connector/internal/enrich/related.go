@@ -0,0 +1,218 @@
+package enrich
+
+import (
+	"fmt"
+	"github-connector/internal/core"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Relations of linked work, from strongest to weakest
+const (
+	relationCloses          = "closes"
+	relationCrossReferenced = "cross_referenced"
+	relationMentions        = "mentions"
+)
+
+var relationRank = map[string]int{
+	relationCloses:          3,
+	relationCrossReferenced: 2,
+	relationMentions:        1,
+}
+
+var (
+	// reIgnoredMarkup matches fenced code blocks, inline code and HTML comments, whose contents
+	// are not references (PR templates often contain "Closes #" inside comments)
+	reIgnoredMarkup = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`|<!--.*?-->")
+	// reReferenceURL matches links to GitHub issues and pull requests
+	reReferenceURL = regexp.MustCompile(`https://github\.com/([A-Za-z0-9][A-Za-z0-9-]*)/([A-Za-z0-9._-]+)/(issues|pull)/(\d+)`)
+	// reShortReference matches "#12" and "owner/repo#34"; group 1 is the reference itself
+	reShortReference = regexp.MustCompile(`(?:^|[^\w/#.-])((?:([A-Za-z0-9][A-Za-z0-9-]*)/([A-Za-z0-9._-]+))?#(\d+))\b`)
+	// reClosingKeyword matches a closing keyword at the end of the text preceding a reference
+	reClosingKeyword = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?)$`)
+	// reJiraKey matches Jira issue keys such as "PROJ-123"; group 2 is the project key
+	reJiraKey = regexp.MustCompile(`\b(([A-Z][A-Z0-9]+)-[1-9][0-9]*)\b`)
+)
+
+// notJiraProjects are prefixes of common tokens that look like Jira keys, such as "UTF-8",
+// "SHA-256" or "ISO-8601". They are ignored unless listed in jira_project_keys.
+var notJiraProjects = map[string]bool{
+	"AES": true, "CVE": true, "CWE": true, "ECMA": true, "GPT": true, "HTTP": true, "IEEE": true,
+	"ISO": true, "PEP": true, "RFC": true, "RSA": true, "SHA": true, "SSL": true, "TLS": true,
+	"UTF": true, "WCAG": true,
+}
+
+// relatedCollector gathers the work linked from a pull request or issue. Each item is recorded
+// once with its strongest relation; references to the item itself and to repositories excluded
+// by repository_patterns are dropped.
+type relatedCollector struct {
+	repo   string
+	number int
+	filter *core.RepositoryFilter
+	// jiraProjects limits the recognised Jira projects; empty recognises any project
+	jiraProjects map[string]bool
+	items        []map[string]any
+	index        map[string]int
+}
+
+func newRelatedCollector(repo string, number int, filter *core.RepositoryFilter, jiraProjects map[string]bool) *relatedCollector {
+	return &relatedCollector{
+		repo:         repo,
+		number:       number,
+		filter:       filter,
+		jiraProjects: jiraProjects,
+		items:        []map[string]any{},
+		index:        map[string]int{},
+	}
+}
+
+// addText records the GitHub references in a Markdown text such as a title or body. References
+// preceded by a closing keyword ("Closes #12") are recorded as closing.
+func (c *relatedCollector) addText(text, source string) {
+	text = reIgnoredMarkup.ReplaceAllStringFunc(text, func(s string) string {
+		return strings.Repeat(" ", len(s))
+	})
+
+	for _, m := range reReferenceURL.FindAllStringSubmatchIndex(text, -1) {
+		itemType := core.ResourceTypeIssue
+		if text[m[6]:m[7]] == "pull" {
+			itemType = core.ResourceTypePullRequest
+		}
+		number, _ := strconv.Atoi(text[m[8]:m[9]])
+		c.addGitHub(text[m[2]:m[3]]+"/"+text[m[4]:m[5]], number, itemType, closingRelation(text[:m[0]]), source)
+	}
+
+	for _, m := range reShortReference.FindAllStringSubmatchIndex(text, -1) {
+		repo := c.repo
+		if m[4] >= 0 {
+			repo = text[m[4]:m[5]] + "/" + text[m[6]:m[7]]
+		}
+		number, _ := strconv.Atoi(text[m[8]:m[9]])
+		// "#12" may be an issue or a pull request; the type stays unknown
+		c.addGitHub(repo, number, "", closingRelation(text[:m[2]]), source)
+	}
+}
+
+// addJiraKeys records the Jira issue keys in text, such as a title or branch name. Only keys of
+// jiraProjects are recorded when it is set; otherwise keys of common tokens such as "UTF-8" are
+// skipped.
+func (c *relatedCollector) addJiraKeys(text, source string) {
+	for _, m := range reJiraKey.FindAllStringSubmatch(text, -1) {
+		if !c.isJiraProject(m[2]) {
+			continue
+		}
+		key := "jira:" + m[1]
+		if _, ok := c.index[key]; ok {
+			continue
+		}
+		c.index[key] = len(c.items)
+		c.items = append(c.items, map[string]any{
+			"kind":     "jira",
+			"key":      m[1],
+			"relation": relationMentions,
+			"source":   source,
+		})
+	}
+}
+
+// isJiraProject reports whether keys of project are recorded
+func (c *relatedCollector) isJiraProject(project string) bool {
+	if len(c.jiraProjects) > 0 {
+		return c.jiraProjects[project]
+	}
+	return !notJiraProjects[project]
+}
+
+// addTimeline records the issues and pull requests that referenced this one, from the
+// cross-referenced events of the issue timeline API
+func (c *relatedCollector) addTimeline(events []map[string]any) {
+	for _, event := range events {
+		if getStringValue(event, "event") != "cross-referenced" {
+			continue
+		}
+		issue, ok := getNestedValue(event, "source", "issue").(map[string]any)
+		if !ok {
+			continue
+		}
+		number, ok := issue["number"].(float64)
+		if !ok {
+			continue
+		}
+		repo := getNestedString(issue, "repository", "full_name")
+		if repo == "" {
+			continue
+		}
+		itemType := core.ResourceTypeIssue
+		if _, isPR := issue["pull_request"]; isPR {
+			itemType = core.ResourceTypePullRequest
+		}
+		c.addGitHub(repo, int(number), itemType, relationCrossReferenced, "timeline")
+	}
+}
+
+// list returns the collected items in the order they were first found
+func (c *relatedCollector) list() []map[string]any {
+	return c.items
+}
+
+func (c *relatedCollector) addGitHub(repo string, number int, itemType, relation, source string) {
+	if strings.EqualFold(repo, c.repo) && number == c.number {
+		return
+	}
+	if !c.filter.Allows(repo, nil) {
+		return
+	}
+
+	key := fmt.Sprintf("github:%s#%d", strings.ToLower(repo), number)
+	if i, ok := c.index[key]; ok {
+		item := c.items[i]
+		if relationRank[relation] > relationRank[item["relation"].(string)] {
+			item["relation"] = relation
+			item["source"] = source
+		}
+		if item["type"] == "" && itemType != "" {
+			setGitHubType(item, itemType)
+		}
+		return
+	}
+
+	item := map[string]any{
+		"kind":     "github",
+		"repo":     repo,
+		"number":   number,
+		"relation": relation,
+		"source":   source,
+	}
+	setGitHubType(item, itemType)
+	c.index[key] = len(c.items)
+	c.items = append(c.items, item)
+}
+
+// setGitHubType records the type of a GitHub reference along with its URL and, once the type
+// is known, the ID of the context representing it
+func setGitHubType(item map[string]any, itemType string) {
+	repo := item["repo"].(string)
+	number := fmt.Sprintf("%d", item["number"])
+
+	item["type"] = itemType
+	switch itemType {
+	case core.ResourceTypePullRequest:
+		item["url"] = fmt.Sprintf("https://github.com/%s/pull/%s", repo, number)
+		item["context_id"] = core.MakePullRequestContextID(repo, number)
+	case core.ResourceTypeIssue:
+		item["url"] = fmt.Sprintf("https://github.com/%s/issues/%s", repo, number)
+		item["context_id"] = core.MakeIssueContextID(repo, number)
+	default:
+		// GitHub redirects issue URLs to the pull request when the number is a pull request
+		item["url"] = fmt.Sprintf("https://github.com/%s/issues/%s", repo, number)
+	}
+}
+
+// closingRelation returns the relation of a reference given the text preceding it
+func closingRelation(preceding string) string {
+	if reClosingKeyword.MatchString(strings.TrimRight(preceding, " \t:")) {
+		return relationCloses
+	}
+	return relationMentions
+}
@@ -0,0 +1,157 @@
+package enrich
+
+import (
+	"github-connector/internal/core"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelatedCollector_AddText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []map[string]any
+	}{
+		{
+			name: "closing keyword with short reference",
+			text: "Closes #12",
+			want: []map[string]any{
+				{"kind": "github", "repo": "owner/repo", "number": 12, "type": "", "relation": "closes", "source": "body", "url": "https://github.com/owner/repo/issues/12"},
+			},
+		},
+		{
+			name: "closing keyword with colon and cross-repository reference",
+			text: "fixes: other/lib#34",
+			want: []map[string]any{
+				{"kind": "github", "repo": "other/lib", "number": 34, "type": "", "relation": "closes", "source": "body", "url": "https://github.com/other/lib/issues/34"},
+			},
+		},
+		{
+			name: "mention by URL",
+			text: "Follow-up of https://github.com/owner/repo/pull/7.",
+			want: []map[string]any{
+				{"kind": "github", "repo": "owner/repo", "number": 7, "type": "pull_request", "relation": "mentions", "source": "body", "url": "https://github.com/owner/repo/pull/7", "context_id": "github:pull_request:owner/repo:7"},
+			},
+		},
+		{
+			name: "closing keyword with issue URL",
+			text: "Resolves https://github.com/owner/repo/issues/8",
+			want: []map[string]any{
+				{"kind": "github", "repo": "owner/repo", "number": 8, "type": "issue", "relation": "closes", "source": "body", "url": "https://github.com/owner/repo/issues/8", "context_id": "github:issue:owner/repo:8"},
+			},
+		},
+		{
+			name: "strongest relation wins and URL fills the type",
+			text: "See #12.\n\nCloses #12\nhttps://github.com/owner/repo/issues/12",
+			want: []map[string]any{
+				{"kind": "github", "repo": "owner/repo", "number": 12, "type": "issue", "relation": "closes", "source": "body", "url": "https://github.com/owner/repo/issues/12", "context_id": "github:issue:owner/repo:12"},
+			},
+		},
+		{
+			name: "ignores code, comments, self references and anchors",
+			text: "<!-- Closes #1 -->\n`#2`\n```\nfixes #3\n```\nThis is #42. https://github.com/owner/repo/pull/9#issuecomment-1 color#fff abc#4",
+			want: []map[string]any{
+				{"kind": "github", "repo": "owner/repo", "number": 9, "type": "pull_request", "relation": "mentions", "source": "body", "url": "https://github.com/owner/repo/pull/9", "context_id": "github:pull_request:owner/repo:9"},
+			},
+		},
+		{
+			name: "excluded repositories are dropped",
+			text: "Relates to secret/repo#5",
+			want: []map[string]any{},
+		},
+	}
+
+	filter, err := core.ParseRepositoryFilter([]string{"!secret/*"}, false)
+	assert.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newRelatedCollector("owner/repo", 42, filter, nil)
+			c.addText(tt.text, "body")
+			assert.Equal(t, tt.want, c.list())
+		})
+	}
+}
+
+func TestRelatedCollector_AddJiraKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		projects map[string]bool
+		texts    []string
+		want     []map[string]any
+	}{
+		{
+			name:  "any project",
+			texts: []string{"PROJ-123: Fix login for OPS-7", "PROJ-123-fix-login", "feature/proj-9-lowercase"},
+			want: []map[string]any{
+				{"kind": "jira", "key": "PROJ-123", "relation": "mentions", "source": "title"},
+				{"kind": "jira", "key": "OPS-7", "relation": "mentions", "source": "title"},
+			},
+		},
+		{
+			name:  "common tokens are not keys",
+			texts: []string{"Decode UTF-8 and hash with SHA-256 (ISO-8601 dates, RFC-3339, TLS-1.3) for OPS-7"},
+			want: []map[string]any{
+				{"kind": "jira", "key": "OPS-7", "relation": "mentions", "source": "title"},
+			},
+		},
+		{
+			name:     "only configured projects",
+			projects: map[string]bool{"PROJ": true, "SHA": true},
+			texts:    []string{"PROJ-123: Fix login for OPS-7 with SHA-256"},
+			want: []map[string]any{
+				{"kind": "jira", "key": "PROJ-123", "relation": "mentions", "source": "title"},
+				{"kind": "jira", "key": "SHA-256", "relation": "mentions", "source": "title"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newRelatedCollector("owner/repo", 42, nil, tt.projects)
+			for i, text := range tt.texts {
+				source := "title"
+				if i > 0 {
+					source = "branch"
+				}
+				c.addJiraKeys(text, source)
+			}
+			assert.Equal(t, tt.want, c.list())
+		})
+	}
+}
+
+func TestRelatedCollector_AddTimeline(t *testing.T) {
+	c := newRelatedCollector("owner/repo", 42, nil, nil)
+	c.addText("Mentioned in #10", "body")
+	c.addTimeline([]map[string]any{
+		{"event": "labeled"},
+		{
+			"event": "cross-referenced",
+			"source": map[string]any{
+				"type": "issue",
+				"issue": map[string]any{
+					"number":       float64(10),
+					"pull_request": map[string]any{"url": "https://api.github.com/repos/owner/repo/pulls/10"},
+					"repository":   map[string]any{"full_name": "owner/repo"},
+				},
+			},
+		},
+		{
+			"event": "cross-referenced",
+			"source": map[string]any{
+				"type": "issue",
+				"issue": map[string]any{
+					"number":     float64(3),
+					"repository": map[string]any{"full_name": "other/lib"},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, []map[string]any{
+		{"kind": "github", "repo": "owner/repo", "number": 10, "type": "pull_request", "relation": "cross_referenced", "source": "timeline", "url": "https://github.com/owner/repo/pull/10", "context_id": "github:pull_request:owner/repo:10"},
+		{"kind": "github", "repo": "other/lib", "number": 3, "type": "issue", "relation": "cross_referenced", "source": "timeline", "url": "https://github.com/other/lib/issues/3", "context_id": "github:issue:other/lib:3"},
+	}, c.list())
+}
@@ -0,0 +1,341 @@
+package fetch
+
+import (
+	"fmt"
+	"github-connector/internal/core"
+	"github-connector/internal/paginate"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// maxApprovalChecks limits how many workflow runs per repository are checked for deployment
+	// reviews, since each check is a separate request
+	maxApprovalChecks = 20
+	// workflowRunLookback is how long before the target date a run may have been created and
+	// still be active within the day (e.g., re-runs or runs waiting for a deployment review)
+	workflowRunLookback = 7 * 24 * time.Hour
+	// workflowsDir is where the definitions of the workflows of a repository are stored
+	workflowsDir = ".github/workflows/"
+)
+
+// fetchActionsActivities fetches workflow runs triggered by the user and deployment reviews
+// made by the user in the given repositories. Failures for a repository (e.g., Actions disabled
+// or missing permissions) are logged and skipped.
+func (f *ActivityFetcher) fetchActionsActivities(repos []string) []*Activity {
+	activities := []*Activity{}
+	// Runs started within the day may have been created earlier (e.g., re-runs)
+	created := f.config.startTime.Add(-workflowRunLookback).Format("2006-01-02") + ".." + f.config.endTime.Format("2006-01-02")
+
+	// Deployment reviews are made on runs of any actor, so the runs of the user are then
+	// filtered from the same listing instead of being listed again
+	actor := f.config.username
+	if f.config.includeDeployments {
+		actor = ""
+	}
+
+	for _, repo := range repos {
+		runs, err := f.httpClient.FetchWorkflowRuns(repo, actor, created, paginate.Options{Limits: f.config.pagination})
+		if err != nil {
+			f.logger.Warn(fmt.Sprintf("Skipping GitHub Actions activities of %s: %s", repo, err.Error()))
+			continue
+		}
+
+		if f.config.includeWorkflowRuns {
+			for _, run := range runs {
+				if !f.inDateRange(run, "run_started_at") || !triggeredBy(run, f.config.username) {
+					continue
+				}
+				activity, err := transformWorkflowRun(repo, run)
+				if err != nil {
+					f.logger.Debug(fmt.Sprintf("Skipping workflow run: %s", err.Error()))
+					continue
+				}
+				activities = append(activities, activity)
+			}
+		}
+
+		if f.config.includeDeployments {
+			activities = append(activities, f.fetchDeploymentReviews(repo, runs)...)
+		}
+	}
+
+	f.logger.Info(fmt.Sprintf("Fetched %d GitHub Actions activities from %d repositories", len(activities), len(repos)))
+	return activities
+}
+
+// fetchDeploymentReviews returns the deployment reviews the user made on the workflow runs of
+// repo that were active within the day.
+func (f *ActivityFetcher) fetchDeploymentReviews(repo string, runs []map[string]any) []*Activity {
+	activities := []*Activity{}
+	checked := 0
+	for _, run := range runs {
+		if checked >= maxApprovalChecks {
+			f.logger.Debug(fmt.Sprintf("Checked deployment reviews of %d runs in %s, stopping", checked, repo))
+			break
+		}
+		if !f.inDateRange(run, "updated_at") {
+			continue
+		}
+		runID, ok := run["id"].(float64)
+		if !ok {
+			continue
+		}
+
+		checked++
+		approvals, err := f.httpClient.FetchRunApprovals(repo, int64(runID))
+		if err != nil {
+			f.logger.Warn(fmt.Sprintf("Skipping deployment reviews of run %d in %s: %s", int64(runID), repo, err.Error()))
+			continue
+		}
+		for _, approval := range approvals {
+			user, _ := approval["user"].(map[string]any)
+			if login, _ := user["login"].(string); !strings.EqualFold(login, f.config.username) {
+				continue
+			}
+			activity, err := transformDeploymentReview(repo, run, approval)
+			if err != nil {
+				f.logger.Debug(fmt.Sprintf("Skipping deployment review: %s", err.Error()))
+				continue
+			}
+			activities = append(activities, activity)
+		}
+	}
+
+	return activities
+}
+
+// actionsRepositories returns the repositories to scan for GitHub Actions activities: those the
+// user had events in, plus repositories named exactly by repository_patterns.
+func (f *ActivityFetcher) actionsRepositories(events []map[string]any) []string {
+	repos := []string{}
+	seen := map[string]bool{}
+	add := func(repo string) {
+		if repo != "" && !seen[strings.ToLower(repo)] {
+			seen[strings.ToLower(repo)] = true
+			repos = append(repos, repo)
+		}
+	}
+
+	for _, event := range events {
+		repo, _ := event["repo"].(map[string]any)
+		name, _ := repo["name"].(string)
+		add(name)
+	}
+
+	filter := f.config.repositoryFilter
+	for _, rule := range filter.Rules() {
+		if !rule.Exclude && rule.IsExact() && filter.Allows(rule.Glob, nil) {
+			add(rule.Glob)
+		}
+	}
+
+	return repos
+}
+
+// inDateRange reports whether the timestamp field of obj falls within the target date
+func (f *ActivityFetcher) inDateRange(obj map[string]any, field string) bool {
+	str, _ := obj[field].(string)
+	t, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return false
+	}
+	return !t.Before(f.config.startTime) && !t.After(f.config.endTime)
+}
+
+// triggeredBy reports whether the run was started or re-run by login
+func triggeredBy(run map[string]any, login string) bool {
+	for _, key := range []string{"actor", "triggering_actor"} {
+		user, _ := run[key].(map[string]any)
+		if userLogin, _ := user["login"].(string); strings.EqualFold(userLogin, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// transformWorkflowRun transforms a workflow run attempt to an Activity
+func transformWorkflowRun(repoName string, run map[string]any) (*Activity, error) {
+	runIDFloat, ok := run["id"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("invalid id in workflow run")
+	}
+	runID := int64(runIDFloat)
+
+	startedAt, err := time.Parse(time.RFC3339, getString(run, "run_started_at"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp format: %w", err)
+	}
+	startedAt = startedAt.UTC()
+
+	name := getString(run, "name")
+	runNumber := getInt(run, "run_number")
+	attempt := getInt(run, "run_attempt")
+	status := getString(run, "status")
+	conclusion := getString(run, "conclusion")
+	workflowFile := workflowFileOf(run)
+
+	var title string
+	switch {
+	case attempt > 1:
+		title = fmt.Sprintf("Re-ran workflow %s #%d in %s", name, runNumber, repoName)
+	case getString(run, "event") == "workflow_dispatch":
+		title = fmt.Sprintf("Dispatched workflow %s #%d in %s", name, runNumber, repoName)
+	default:
+		title = fmt.Sprintf("Ran workflow %s #%d in %s", name, runNumber, repoName)
+	}
+
+	result := status
+	if conclusion != "" {
+		result = conclusion
+	}
+	description := fmt.Sprintf("%s on %s: %s", getString(run, "display_title"), getString(run, "head_branch"), result)
+	url := getString(run, "html_url")
+
+	triggeringActor, _ := run["triggering_actor"].(map[string]any)
+
+	metadata := map[string]any{
+		"workflow_name":    name,
+		"run_id":           runID,
+		"run_number":       runNumber,
+		"run_attempt":      attempt,
+		"event":            run["event"],
+		"status":           status,
+		"conclusion":       run["conclusion"],
+		"head_branch":      run["head_branch"],
+		"head_sha":         run["head_sha"],
+		"triggering_actor": triggeringActor["login"],
+	}
+	if workflowFile != "" {
+		metadata["workflow_file"] = workflowFile
+	}
+	if status == "completed" {
+		if updatedAt, err := time.Parse(time.RFC3339, getString(run, "updated_at")); err == nil {
+			metadata["duration_seconds"] = int(updatedAt.Sub(startedAt).Seconds())
+		}
+	}
+
+	gen := core.NewContextGenerator()
+	contexts := workflowRunContexts(gen, repoName, workflowFile, runID)
+	// head_branch is the tag name for runs triggered by tags, so only the commit is linked
+	if sha := getString(run, "head_sha"); sha != "" {
+		contexts = append(contexts, gen.CreateCommitContext(repoName, sha))
+	}
+
+	return &Activity{
+		Id:           core.MakeActivityID(fmt.Sprintf("workflow_run:%d:%d", runID, attempt)),
+		Timestamp:    startedAt,
+		Title:        title,
+		Description:  description,
+		Source:       core.ConnectorID,
+		ActivityType: "workflow_run",
+		Url:          &url,
+		Metadata:     metadata,
+		Contexts:     contexts,
+	}, nil
+}
+
+// transformDeploymentReview transforms a deployment review of a workflow run to an Activity.
+// The approvals API does not report when a review was made, so the run's last update is used.
+func transformDeploymentReview(repoName string, run, approval map[string]any) (*Activity, error) {
+	runIDFloat, ok := run["id"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("invalid id in workflow run")
+	}
+	runID := int64(runIDFloat)
+
+	timestamp, err := time.Parse(time.RFC3339, getString(run, "updated_at"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp format: %w", err)
+	}
+	timestamp = timestamp.UTC()
+
+	environments := []string{}
+	if envs, ok := approval["environments"].([]any); ok {
+		for _, env := range envs {
+			if envMap, ok := env.(map[string]any); ok {
+				environments = append(environments, getString(envMap, "name"))
+			}
+		}
+	}
+
+	state := getString(approval, "state")
+	verb := "Approved"
+	if state == "rejected" {
+		verb = "Rejected"
+	}
+
+	name := getString(run, "name")
+	runNumber := getInt(run, "run_number")
+	workflowFile := workflowFileOf(run)
+
+	title := fmt.Sprintf("%s deployment to %s in %s", verb, strings.Join(environments, ", "), repoName)
+	description := getString(approval, "comment")
+	if description == "" {
+		description = fmt.Sprintf("%s #%d", name, runNumber)
+	}
+	url := getString(run, "html_url")
+
+	metadata := map[string]any{
+		"state":         state,
+		"environments":  environments,
+		"comment":       approval["comment"],
+		"workflow_name": name,
+		"run_id":        runID,
+		"run_number":    runNumber,
+	}
+
+	if workflowFile != "" {
+		metadata["workflow_file"] = workflowFile
+	}
+
+	gen := core.NewContextGenerator()
+	contexts := workflowRunContexts(gen, repoName, workflowFile, runID)
+
+	return &Activity{
+		Id:           core.MakeActivityID(fmt.Sprintf("deployment_review:%d:%s:%s", runID, state, strings.Join(environments, ","))),
+		Timestamp:    timestamp,
+		Title:        title,
+		Description:  description,
+		Source:       core.ConnectorID,
+		ActivityType: "deployment_review",
+		Url:          &url,
+		Metadata:     metadata,
+		Contexts:     contexts,
+	}, nil
+}
+
+func getString(m map[string]any, key string) string {
+	str, _ := m[key].(string)
+	return str
+}
+
+func getInt(m map[string]any, key string) int {
+	num, _ := m[key].(float64)
+	return int(num)
+}
+
+// workflowFileOf returns the file name of the workflow definition of a run, or "" for a dynamic
+// workflow (e.g., "dynamic/pages/pages-build-deployment"), which has no file under
+// .github/workflows and cannot be looked up by file name.
+func workflowFileOf(run map[string]any) string {
+	workflowPath := getString(run, "path")
+	if !strings.HasPrefix(workflowPath, workflowsDir) {
+		return ""
+	}
+	return path.Base(workflowPath)
+}
+
+// workflowRunContexts returns the context hierarchy of a workflow run, with the workflow context
+// when the workflow file is known
+func workflowRunContexts(gen *core.ContextGenerator, repoName, workflowFile string, runID int64) []*core.Context {
+	contexts := []*core.Context{
+		gen.CreateSourceContext(),
+		gen.CreateRepositoryContext(repoName),
+	}
+	if workflowFile != "" {
+		contexts = append(contexts, gen.CreateWorkflowContext(repoName, workflowFile))
+	}
+	return append(contexts, gen.CreateWorkflowRunContext(repoName, runID))
+}
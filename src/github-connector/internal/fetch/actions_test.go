@@ -0,0 +1,197 @@
+package fetch
+
+import (
+	"errors"
+	"github-connector/internal/core"
+	mock_fetch "github-connector/mock/fetch"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTransformWorkflowRun(t *testing.T) {
+	run := loadJSONTestData(t, "../../testdata/actions/workflow_run.json")
+
+	got, err := transformWorkflowRun("testorg/testrepo", run)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "github:workflow_run:19429567890:1", got.Id)
+	assert.Equal(t, time.Date(2025, 11, 18, 2, 0, 12, 0, time.UTC), got.Timestamp)
+	assert.Equal(t, "Dispatched workflow Release #214 in testorg/testrepo", got.Title)
+	assert.Equal(t, "chore: release v0.6.0 on main: success", got.Description)
+	assert.Equal(t, "workflow_run", got.ActivityType)
+	assert.Equal(t, "https://github.com/testorg/testrepo/actions/runs/19429567890", *got.Url)
+
+	metadata := got.Metadata.(map[string]any)
+	assert.Equal(t, "release.yml", metadata["workflow_file"])
+	assert.Equal(t, 570, metadata["duration_seconds"])
+	assert.Equal(t, "ymtdzzz", metadata["triggering_actor"])
+
+	contextIDs := []string{}
+	for _, ctx := range got.Contexts {
+		contextIDs = append(contextIDs, ctx.Id)
+	}
+	assert.Equal(t, []string{
+		"github:source",
+		"github:repository:testorg/testrepo",
+		"github:workflow:testorg/testrepo:release.yml",
+		"github:workflow_run:testorg/testrepo:19429567890",
+		"github:commit:testorg/testrepo:4fb5eb96ecc5141ff2383d720508bd0ccaa1b820",
+	}, contextIDs)
+
+	run["run_attempt"] = float64(2)
+	rerun, err := transformWorkflowRun("testorg/testrepo", run)
+	assert.NoError(t, err)
+	assert.Equal(t, "github:workflow_run:19429567890:2", rerun.Id)
+	assert.Equal(t, "Re-ran workflow Release #214 in testorg/testrepo", rerun.Title)
+}
+
+func TestTransformWorkflowRun_DynamicWorkflow(t *testing.T) {
+	run := loadJSONTestData(t, "../../testdata/actions/workflow_run.json")
+	run["path"] = "dynamic/pages/pages-build-deployment"
+
+	got, err := transformWorkflowRun("testorg/testrepo", run)
+	assert.NoError(t, err)
+
+	assert.NotContains(t, got.Metadata.(map[string]any), "workflow_file")
+	contextIDs := []string{}
+	for _, ctx := range got.Contexts {
+		contextIDs = append(contextIDs, ctx.Id)
+	}
+	assert.Equal(t, []string{
+		"github:source",
+		"github:repository:testorg/testrepo",
+		"github:workflow_run:testorg/testrepo:19429567890",
+		"github:commit:testorg/testrepo:4fb5eb96ecc5141ff2383d720508bd0ccaa1b820",
+	}, contextIDs)
+
+	review, err := transformDeploymentReview("testorg/testrepo", run, loadJSONTestData(t, "../../testdata/actions/approval.json"))
+	assert.NoError(t, err)
+	for _, ctx := range review.Contexts {
+		assert.NotEqual(t, core.ResourceTypeWorkflow, ctx.ResourceType)
+	}
+}
+
+func TestTransformDeploymentReview(t *testing.T) {
+	run := loadJSONTestData(t, "../../testdata/actions/workflow_run.json")
+	approval := loadJSONTestData(t, "../../testdata/actions/approval.json")
+
+	got, err := transformDeploymentReview("testorg/testrepo", run, approval)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "github:deployment_review:19429567890:approved:production", got.Id)
+	assert.Equal(t, time.Date(2025, 11, 18, 2, 9, 42, 0, time.UTC), got.Timestamp)
+	assert.Equal(t, "Approved deployment to production in testorg/testrepo", got.Title)
+	assert.Equal(t, "Ship it!", got.Description)
+	assert.Equal(t, "deployment_review", got.ActivityType)
+	assert.Equal(t, []string{"production"}, got.Metadata.(map[string]any)["environments"])
+}
+
+func TestFetchActivities_Actions(t *testing.T) {
+	workflowRun := func(id float64, actor, startedAt string) map[string]any {
+		run := loadJSONTestData(t, "../../testdata/actions/workflow_run.json")
+		run["id"] = id
+		run["run_started_at"] = startedAt
+		run["updated_at"] = startedAt
+		run["actor"].(map[string]any)["login"] = actor
+		run["triggering_actor"].(map[string]any)["login"] = actor
+		return run
+	}
+	approval := func(login string) map[string]any {
+		approval := loadJSONTestData(t, "../../testdata/actions/approval.json")
+		approval["user"].(map[string]any)["login"] = login
+		return approval
+	}
+	deleteEvent := loadJSONTestData(t, "../../testdata/events/delete.json")
+	deleteEvent["created_at"] = "2025-11-18T09:00:00Z"
+	deleteEvent["actor"].(map[string]any)["login"] = "username"
+	deleteEvent["repo"].(map[string]any)["name"] = "testorg/testrepo"
+
+	tests := []struct {
+		name        string
+		cfg         map[string]any
+		getMockHTTP func(*gomock.Controller) HTTPClient
+		wantIDs     []string
+	}{
+		{
+			name: "workflow runs of event and exact pattern repositories",
+			cfg: map[string]any{
+				"include_workflow_runs": true,
+				"repository_patterns":   []any{"testorg/*", "username/tools"},
+			},
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-18")).Return([]map[string]any{deleteEvent}, nil).Times(1)
+				mockHTTP.EXPECT().FetchWorkflowRuns("testorg/testrepo", "username", "2025-11-11..2025-11-18", workflowRunOptions).Return([]map[string]any{
+					workflowRun(3, "username", "2025-11-18T12:00:00Z"),
+					workflowRun(2, "someone", "2025-11-18T11:00:00Z"),
+					workflowRun(1, "username", "2025-11-17T23:00:00Z"),
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchWorkflowRuns("username/tools", "username", "2025-11-11..2025-11-18", workflowRunOptions).Return(nil, errors.New("404 Not Found")).Times(1)
+				return mockHTTP
+			},
+			wantIDs: []string{"github:workflow_run:3:1", "github:6031147775"},
+		},
+		{
+			name: "deployment reviews made by the user",
+			cfg: map[string]any{
+				"include_deployment_reviews": true,
+			},
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-18")).Return([]map[string]any{deleteEvent}, nil).Times(1)
+				mockHTTP.EXPECT().FetchWorkflowRuns("testorg/testrepo", "", "2025-11-11..2025-11-18", workflowRunOptions).Return([]map[string]any{
+					workflowRun(5, "someone", "2025-11-18T08:00:00Z"),
+					workflowRun(4, "someone", "2025-11-18T07:00:00Z"),
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchRunApprovals("testorg/testrepo", int64(5)).Return([]map[string]any{approval("username")}, nil).Times(1)
+				mockHTTP.EXPECT().FetchRunApprovals("testorg/testrepo", int64(4)).Return([]map[string]any{approval("someone")}, nil).Times(1)
+				return mockHTTP
+			},
+			wantIDs: []string{"github:6031147775", "github:deployment_review:5:approved:production"},
+		},
+		{
+			name: "workflow runs and deployment reviews share one listing",
+			cfg: map[string]any{
+				"include_workflow_runs":      true,
+				"include_deployment_reviews": true,
+			},
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-18")).Return([]map[string]any{deleteEvent}, nil).Times(1)
+				mockHTTP.EXPECT().FetchWorkflowRuns("testorg/testrepo", "", "2025-11-11..2025-11-18", workflowRunOptions).Return([]map[string]any{
+					workflowRun(7, "username", "2025-11-18T10:00:00Z"),
+					workflowRun(6, "someone", "2025-11-18T09:30:00Z"),
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchRunApprovals("testorg/testrepo", int64(7)).Return(nil, nil).Times(1)
+				mockHTTP.EXPECT().FetchRunApprovals("testorg/testrepo", int64(6)).Return([]map[string]any{approval("username")}, nil).Times(1)
+				return mockHTTP
+			},
+			wantIDs: []string{"github:workflow_run:7:1", "github:deployment_review:6:approved:production", "github:6031147775"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			tt.cfg["username"] = "username"
+			fetcher, err := NewActivityFetcher(tt.getMockHTTP(ctrl), tt.cfg, "2025-11-18", core.NewNoopLogger())
+			if err != nil {
+				t.Fatalf("Failed to create ActivityFetcher: %v", err)
+			}
+
+			got, err := fetcher.FetchActivities()
+			assert.NoError(t, err)
+
+			ids := make([]string, 0, len(got))
+			for _, activity := range got {
+				ids = append(ids, activity.Id)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
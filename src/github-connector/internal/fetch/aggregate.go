@@ -0,0 +1,189 @@
+package fetch
+
+import (
+	"fmt"
+	"github-connector/internal/core"
+	"strings"
+	"time"
+)
+
+// aggregateActivities merges bursts of near-identical activities into a single activity:
+//   - consecutive pushes to the same branch of the same repository
+//   - review comments belonging to the same pull request review
+//
+// activities must be ordered newest first, as returned by the Events API. A merged activity
+// keeps the ID of its oldest member, so that it stays stable as more activities join it, and
+// the timestamp of its newest member. Counts and the time span are recorded in metadata.
+func aggregateActivities(activities []*Activity) []*Activity {
+	result := []*Activity{}
+	groups := map[*Activity][]*Activity{}
+	reviews := map[string]*Activity{}
+	var lastPush *Activity
+
+	for _, activity := range activities {
+		switch activity.ActivityType {
+		case "push":
+			if lastPush != nil && samePushTarget(lastPush, activity) {
+				groups[lastPush] = append(groups[lastPush], activity)
+				continue
+			}
+			lastPush = activity
+		case "pr_review_comment":
+			lastPush = nil
+			if key := reviewKey(activity); key != "" {
+				if head, ok := reviews[key]; ok {
+					groups[head] = append(groups[head], activity)
+					continue
+				}
+				reviews[key] = activity
+			}
+		default:
+			lastPush = nil
+		}
+
+		groups[activity] = []*Activity{activity}
+		result = append(result, activity)
+	}
+
+	for i, head := range result {
+		group := groups[head]
+		if len(group) < 2 {
+			continue
+		}
+		switch head.ActivityType {
+		case "push":
+			result[i] = mergePushes(group)
+		case "pr_review_comment":
+			result[i] = mergeReviewComments(group)
+		}
+	}
+
+	return result
+}
+
+// samePushTarget reports whether two push activities target the same repository and branch
+func samePushTarget(a, b *Activity) bool {
+	return repositoryName(a) == repositoryName(b) && metadataValue(a, "branch") == metadataValue(b, "branch")
+}
+
+// reviewKey identifies the review a review comment belongs to, or "" if unknown
+func reviewKey(activity *Activity) string {
+	reviewID := metadataValue(activity, "review_id")
+	if reviewID == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s#%v", repositoryName(activity), reviewID)
+}
+
+// mergePushes merges consecutive pushes, newest first, into one activity covering the
+// commit range of the whole group.
+func mergePushes(group []*Activity) *Activity {
+	newest, oldest := group[0], group[len(group)-1]
+	merged := mergeGroup(group)
+
+	metadata := merged.Metadata.(map[string]any)
+	metadata["before_commit"] = metadataValue(oldest, "before_commit")
+
+	merged.Description = fmt.Sprintf("Pushed %d times to %v in %s", len(group), metadataValue(newest, "branch"), repositoryName(newest))
+
+	return merged
+}
+
+// mergeReviewComments merges the comments of a single review, newest first, into one activity
+// whose description lists the comments in the order they were written.
+func mergeReviewComments(group []*Activity) *Activity {
+	newest := group[0]
+	merged := mergeGroup(group)
+
+	bodies := make([]string, 0, len(group))
+	commentIDs := make([]any, 0, len(group))
+	filePaths := []string{}
+	seenPaths := map[string]bool{}
+	threadIDs := []any{}
+	seenThreads := map[any]bool{}
+	contexts := []*core.Context{}
+	seenContexts := map[string]bool{}
+	for i := len(group) - 1; i >= 0; i-- {
+		activity := group[i]
+		bodies = append(bodies, activity.Description)
+		commentIDs = append(commentIDs, metadataValue(activity, "comment_id"))
+		if path, ok := metadataValue(activity, "file_path").(string); ok && path != "" && !seenPaths[path] {
+			seenPaths[path] = true
+			filePaths = append(filePaths, path)
+		}
+		if threadID := metadataValue(activity, "thread_id"); threadID != nil && !seenThreads[threadID] {
+			seenThreads[threadID] = true
+			threadIDs = append(threadIDs, threadID)
+		}
+		// A review spans several threads; keep every thread context
+		for _, c := range activity.Contexts {
+			if !seenContexts[c.Id] {
+				seenContexts[c.Id] = true
+				contexts = append(contexts, c)
+			}
+		}
+	}
+
+	metadata := merged.Metadata.(map[string]any)
+	metadata["comment_ids"] = commentIDs
+	metadata["file_paths"] = filePaths
+	metadata["thread_ids"] = threadIDs
+	delete(metadata, "comment_id")
+	delete(metadata, "file_path")
+	delete(metadata, "thread_id")
+	delete(metadata, "in_reply_to_id")
+	merged.Contexts = contexts
+
+	merged.Title = fmt.Sprintf("Commented %d times on PR #%v in %s", len(group), metadataValue(newest, "pr_number"), repositoryName(newest))
+	merged.Description = strings.Join(bodies, "\n\n")
+
+	return merged
+}
+
+// mergeGroup copies the newest activity of a group, takes the ID of the oldest one and records
+// the aggregation in metadata.
+func mergeGroup(group []*Activity) *Activity {
+	newest, oldest := group[0], group[len(group)-1]
+
+	merged := *newest
+	merged.Id = oldest.Id
+
+	metadata := map[string]any{}
+	if original, ok := newest.Metadata.(map[string]any); ok {
+		for k, v := range original {
+			metadata[k] = v
+		}
+	}
+
+	ids := make([]string, 0, len(group))
+	for i := len(group) - 1; i >= 0; i-- {
+		ids = append(ids, group[i].Id)
+	}
+
+	metadata["aggregated_count"] = len(group)
+	metadata["aggregated_activity_ids"] = ids
+	metadata["first_activity_at"] = oldest.Timestamp.Format(time.RFC3339)
+	metadata["last_activity_at"] = newest.Timestamp.Format(time.RFC3339)
+	merged.Metadata = metadata
+
+	return &merged
+}
+
+// repositoryName returns the "owner/repo" of the activity's repository context
+func repositoryName(activity *Activity) string {
+	for _, c := range activity.Contexts {
+		if c.ResourceType != core.ResourceTypeRepository {
+			continue
+		}
+		metadata, _ := c.Metadata.(map[string]any)
+		params, _ := metadata["enrichment_params"].(map[string]any)
+		repo, _ := params["repo"].(string)
+		return repo
+	}
+	return ""
+}
+
+func metadataValue(activity *Activity, key string) any {
+	metadata, _ := activity.Metadata.(map[string]any)
+	return metadata[key]
+}
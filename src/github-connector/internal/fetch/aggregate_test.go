@@ -0,0 +1,135 @@
+package fetch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pushEvent(t *testing.T, id, createdAt, ref, before, head string) map[string]any {
+	t.Helper()
+	event := loadJSONTestData(t, "../../testdata/events/push.json")
+	event["id"] = id
+	event["created_at"] = createdAt
+	payload := event["payload"].(map[string]any)
+	payload["ref"] = ref
+	payload["before"] = before
+	payload["head"] = head
+	return event
+}
+
+func reviewCommentEvent(t *testing.T, id, createdAt string, reviewID, commentID float64, path, body string) map[string]any {
+	return reviewReplyEvent(t, id, createdAt, reviewID, commentID, 0, path, body)
+}
+
+func reviewReplyEvent(t *testing.T, id, createdAt string, reviewID, commentID, inReplyToID float64, path, body string) map[string]any {
+	t.Helper()
+	event := loadJSONTestData(t, "../../testdata/events/pr_review_comment.json")
+	event["id"] = id
+	event["created_at"] = createdAt
+	comment := event["payload"].(map[string]any)["comment"].(map[string]any)
+	comment["pull_request_review_id"] = reviewID
+	comment["id"] = commentID
+	comment["path"] = path
+	comment["body"] = body
+	if inReplyToID != 0 {
+		comment["in_reply_to_id"] = inReplyToID
+	}
+	return event
+}
+
+func transformAll(t *testing.T, events ...map[string]any) []*Activity {
+	t.Helper()
+	activities := make([]*Activity, 0, len(events))
+	for _, event := range events {
+		activity, err := transformEvent(event)
+		if err != nil {
+			t.Fatalf("Failed to transform event: %v", err)
+		}
+		activities = append(activities, activity)
+	}
+	return activities
+}
+
+func TestAggregateActivities_ConsecutivePushes(t *testing.T) {
+	// Events API order: newest first
+	activities := transformAll(t,
+		pushEvent(t, "3", "2025-11-12T12:30:00Z", "refs/heads/main", "bbb", "ccc"),
+		pushEvent(t, "2", "2025-11-12T12:10:00Z", "refs/heads/main", "aaa", "bbb"),
+		pushEvent(t, "1", "2025-11-12T12:00:00Z", "refs/heads/main", "000", "aaa"),
+	)
+
+	got := aggregateActivities(activities)
+
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "github:1", got[0].Id)
+		assert.Equal(t, time.Date(2025, 11, 12, 12, 30, 0, 0, time.UTC), got[0].Timestamp)
+		assert.Equal(t, "Pushed 3 times to refs/heads/main in ymtdzzz/otel-tui", got[0].Description)
+		assert.Equal(t, "https://github.com/ymtdzzz/otel-tui/commit/ccc", *got[0].Url)
+		assert.Equal(t, map[string]any{
+			"branch":                  "refs/heads/main",
+			"before_commit":           "000",
+			"aggregated_count":        3,
+			"aggregated_activity_ids": []string{"github:1", "github:2", "github:3"},
+			"first_activity_at":       "2025-11-12T12:00:00Z",
+			"last_activity_at":        "2025-11-12T12:30:00Z",
+		}, got[0].Metadata)
+	}
+	// The original activities are left untouched
+	assert.Equal(t, "Pushed to refs/heads/main in ymtdzzz/otel-tui", activities[0].Description)
+}
+
+func TestAggregateActivities_PushesSplitByOtherActivity(t *testing.T) {
+	activities := transformAll(t,
+		pushEvent(t, "4", "2025-11-12T13:00:00Z", "refs/heads/main", "ccc", "ddd"),
+		pushEvent(t, "3", "2025-11-12T12:30:00Z", "refs/heads/feature", "xxx", "yyy"),
+		pushEvent(t, "2", "2025-11-12T12:10:00Z", "refs/heads/main", "aaa", "bbb"),
+		loadJSONTestData(t, "../../testdata/events/delete.json"),
+		pushEvent(t, "1", "2025-11-12T12:00:00Z", "refs/heads/main", "000", "aaa"),
+	)
+
+	got := aggregateActivities(activities)
+
+	assert.Len(t, got, 5)
+}
+
+func TestAggregateActivities_ReviewComments(t *testing.T) {
+	activities := transformAll(t,
+		reviewCommentEvent(t, "13", "2025-11-12T10:02:00Z", 100, 3, "b.go", "third"),
+		pushEvent(t, "20", "2025-11-12T10:01:30Z", "refs/heads/main", "aaa", "bbb"),
+		reviewReplyEvent(t, "12", "2025-11-12T10:01:00Z", 100, 2, 1, "a.go", "second"),
+		reviewCommentEvent(t, "30", "2025-11-12T10:00:30Z", 200, 9, "c.go", "other review"),
+		reviewCommentEvent(t, "11", "2025-11-12T10:00:00Z", 100, 1, "a.go", "first"),
+	)
+
+	got := aggregateActivities(activities)
+
+	if assert.Len(t, got, 3) {
+		merged := got[0]
+		assert.Equal(t, "github:11", merged.Id)
+		assert.Equal(t, "Commented 3 times on PR #52580 in testorg/testrepo", merged.Title)
+		assert.Equal(t, "first\n\nsecond\n\nthird", merged.Description)
+
+		metadata := merged.Metadata.(map[string]any)
+		assert.Equal(t, []any{int64(1), int64(2), int64(3)}, metadata["comment_ids"])
+		assert.Equal(t, []string{"a.go", "b.go"}, metadata["file_paths"])
+		assert.Equal(t, []any{int64(1), int64(3)}, metadata["thread_ids"])
+		assert.Equal(t, 3, metadata["aggregated_count"])
+		assert.NotContains(t, metadata, "comment_id")
+
+		threads := []string{}
+		for _, c := range merged.Contexts {
+			if c.ResourceType == "review_thread" {
+				threads = append(threads, c.Id)
+			}
+		}
+		assert.Equal(t, []string{
+			"github:review_thread:testorg/testrepo:52580:1",
+			"github:review_thread:testorg/testrepo:52580:3",
+		}, threads)
+
+		assert.Equal(t, "push", got[1].ActivityType)
+		assert.Equal(t, "github:30", got[2].Id)
+	}
+}
@@ -2,13 +2,34 @@ package fetch
 
 import (
 	"fmt"
+	"github-connector/internal/core"
+	"github-connector/internal/paginate"
+	"strings"
 	"time"
 )
 
+// Fetch modes
+const (
+	// FetchModePublic reads /users/{username}/events only
+	FetchModePublic = "public"
+	// FetchModeAuthenticated also reads the organization feeds of the token owner
+	FetchModeAuthenticated = "authenticated"
+)
+
 type config struct {
-	username           string
-	repositoryPatterns []string
-	startTime, endTime time.Time
+	username              string
+	fetchMode             string
+	repositoryFilter      *core.RepositoryFilter
+	excludeBotActors      bool
+	excludedActors        map[string]bool
+	excludedActivityTypes map[string]bool
+	aggregateActivities   bool
+	includeWorkflowRuns   bool
+	includeDeployments    bool
+	includeProjects       bool
+	includeDiscussions    bool
+	pagination            paginate.Limits
+	startTime, endTime    time.Time
 }
 
 func newConfig(cfg map[string]any, targetDate string) (*config, error) {
@@ -17,15 +38,32 @@ func newConfig(cfg map[string]any, targetDate string) (*config, error) {
 		return nil, fmt.Errorf("missing username")
 	}
 
-	var repositoryPatterns []string
-	if patternsInterface, ok := cfg["repository_patterns"]; ok && patternsInterface != nil {
-		if patterns, ok := patternsInterface.([]any); ok {
-			for _, p := range patterns {
-				if patternStr, ok := p.(string); ok && patternStr != "" {
-					repositoryPatterns = append(repositoryPatterns, patternStr)
-				}
-			}
-		}
+	repositoryFilter, err := core.NewRepositoryFilterFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	fetchMode, _ := cfg["fetch_mode"].(string)
+	switch fetchMode {
+	case "":
+		fetchMode = FetchModePublic
+	case FetchModePublic, FetchModeAuthenticated:
+	default:
+		return nil, fmt.Errorf("invalid fetch_mode: %s", fetchMode)
+	}
+
+	excludeBotActors, _ := cfg["exclude_bot_actors"].(bool)
+
+	aggregateActivities, _ := cfg["aggregate_activities"].(bool)
+
+	includeWorkflowRuns, _ := cfg["include_workflow_runs"].(bool)
+	includeDeployments, _ := cfg["include_deployment_reviews"].(bool)
+	includeProjects, _ := cfg["include_project_changes"].(bool)
+	includeDiscussions, _ := cfg["include_discussions"].(bool)
+
+	pagination, err := paginate.LimitsFromConfig(cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	startTime, endTime, err := parseDateRange(targetDate)
@@ -34,13 +72,36 @@ func newConfig(cfg map[string]any, targetDate string) (*config, error) {
 	}
 
 	return &config{
-		username:           username,
-		repositoryPatterns: repositoryPatterns,
-		startTime:          startTime,
-		endTime:            endTime,
+		username:              username,
+		fetchMode:             fetchMode,
+		repositoryFilter:      repositoryFilter,
+		excludeBotActors:      excludeBotActors,
+		excludedActors:        stringSet(cfg["excluded_actors"]),
+		excludedActivityTypes: stringSet(cfg["excluded_activity_types"]),
+		aggregateActivities:   aggregateActivities,
+		includeWorkflowRuns:   includeWorkflowRuns,
+		includeDeployments:    includeDeployments,
+		includeProjects:       includeProjects,
+		includeDiscussions:    includeDiscussions,
+		pagination:            pagination,
+		startTime:             startTime,
+		endTime:               endTime,
 	}, nil
 }
 
+// stringSet converts a configuration array of strings to a lower-cased set
+func stringSet(value any) map[string]bool {
+	set := map[string]bool{}
+	if items, ok := value.([]any); ok {
+		for _, item := range items {
+			if str, ok := item.(string); ok && strings.TrimSpace(str) != "" {
+				set[strings.ToLower(strings.TrimSpace(str))] = true
+			}
+		}
+	}
+	return set
+}
+
 func parseDateRange(targetDate string) (time.Time, time.Time, error) {
 	var t time.Time
 	var err error
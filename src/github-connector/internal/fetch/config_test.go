@@ -1,6 +1,8 @@
 package fetch
 
 import (
+	"github-connector/internal/core"
+	"github-connector/internal/paginate"
 	"testing"
 	"time"
 
@@ -23,10 +25,15 @@ func TestNewConfig(t *testing.T) {
 			},
 			targetDate: "2025-12-12T12:00:00+09:00",
 			wantConfig: &config{
-				username:           "octocat",
-				repositoryPatterns: []string{"octocat/*"},
-				startTime:          time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
-				endTime:            time.Date(2025, 12, 12, 23, 59, 59, 999999999, time.UTC),
+				username:              "octocat",
+				fetchMode:             FetchModePublic,
+				repositoryFilter:      mustParseFilter(t, "octocat/*"),
+				excludedActors:        map[string]bool{},
+				excludedActivityTypes: map[string]bool{},
+				aggregateActivities:   false,
+				pagination:            paginate.DefaultLimits,
+				startTime:             time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
+				endTime:               time.Date(2025, 12, 12, 23, 59, 59, 999999999, time.UTC),
 			},
 			wantErr: false,
 		},
@@ -38,13 +45,92 @@ func TestNewConfig(t *testing.T) {
 			},
 			targetDate: "2025-12-12",
 			wantConfig: &config{
-				username:           "octocat",
-				repositoryPatterns: []string{"octocat/*"},
-				startTime:          time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
-				endTime:            time.Date(2025, 12, 12, 23, 59, 59, 999999999, time.UTC),
+				username:              "octocat",
+				fetchMode:             FetchModePublic,
+				repositoryFilter:      mustParseFilter(t, "octocat/*"),
+				excludedActors:        map[string]bool{},
+				excludedActivityTypes: map[string]bool{},
+				aggregateActivities:   false,
+				pagination:            paginate.DefaultLimits,
+				startTime:             time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
+				endTime:               time.Date(2025, 12, 12, 23, 59, 59, 999999999, time.UTC),
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid config - actor, activity type and aggregation options",
+			cfg: map[string]any{
+				"username":                "octocat",
+				"exclude_bot_actors":      true,
+				"excluded_actors":         []any{"Release-Robot", " "},
+				"excluded_activity_types": []any{"push", "delete"},
+				"aggregate_activities":    true,
+			},
+			targetDate: "2025-12-12",
+			wantConfig: &config{
+				username:              "octocat",
+				fetchMode:             FetchModePublic,
+				repositoryFilter:      mustParseFilter(t),
+				excludeBotActors:      true,
+				excludedActors:        map[string]bool{"release-robot": true},
+				excludedActivityTypes: map[string]bool{"push": true, "delete": true},
+				aggregateActivities:   true,
+				pagination:            paginate.DefaultLimits,
+				startTime:             time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
+				endTime:               time.Date(2025, 12, 12, 23, 59, 59, 999999999, time.UTC),
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config - pagination limits",
+			cfg: map[string]any{
+				"username":             "octocat",
+				"pagination_max_pages": float64(3),
+			},
+			targetDate: "2025-12-12",
+			wantConfig: &config{
+				username:              "octocat",
+				fetchMode:             FetchModePublic,
+				repositoryFilter:      mustParseFilter(t),
+				excludedActors:        map[string]bool{},
+				excludedActivityTypes: map[string]bool{},
+				aggregateActivities:   false,
+				pagination:            paginate.Limits{MaxPages: 3, MaxItems: paginate.DefaultLimits.MaxItems},
+				startTime:             time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
+				endTime:               time.Date(2025, 12, 12, 23, 59, 59, 999999999, time.UTC),
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid config - invalid pagination limit",
+			cfg: map[string]any{
+				"username":             "octocat",
+				"pagination_max_items": float64(0),
+			},
+			targetDate: "2025-12-12",
+			wantConfig: nil,
+			wantErr:    true,
+		},
+		{
+			name: "invalid config - invalid fetch mode",
+			cfg: map[string]any{
+				"username":   "octocat",
+				"fetch_mode": "everything",
+			},
+			targetDate: "2025-12-12",
+			wantConfig: nil,
+			wantErr:    true,
+		},
+		{
+			name: "invalid config - invalid repository pattern",
+			cfg: map[string]any{
+				"username":            "octocat",
+				"repository_patterns": []any{"octocat/[a-"},
+			},
+			targetDate: "2025-12-12T12:00:00+09:00",
+			wantConfig: nil,
+			wantErr:    true,
+		},
 		{
 			name: "invalid config - missing username",
 			cfg: map[string]any{
@@ -78,3 +164,12 @@ func TestNewConfig(t *testing.T) {
 		})
 	}
 }
+
+func mustParseFilter(t *testing.T, patterns ...string) *core.RepositoryFilter {
+	t.Helper()
+	filter, err := core.ParseRepositoryFilter(patterns, false)
+	if err != nil {
+		t.Fatalf("Failed to parse repository patterns: %v", err)
+	}
+	return filter
+}
@@ -0,0 +1,110 @@
+package fetch
+
+import (
+	"fmt"
+	"github-connector/internal/core"
+	"github-connector/internal/paginate"
+	"time"
+)
+
+// fetchDiscussionActivities fetches the discussion comments and answers the user wrote within the
+// date range. The Events API does not report discussions, so they are read from the user's
+// discussion comments through GraphQL.
+func (f *ActivityFetcher) fetchDiscussionActivities() []*Activity {
+	opts := paginate.Options{
+		Limits:    f.config.pagination,
+		Watermark: f.config.startTime,
+		TimeField: "createdAt",
+	}
+	comments, err := f.httpClient.FetchDiscussionComments(f.config.username, opts)
+	if err != nil {
+		f.logger.Warn(fmt.Sprintf("Skipping discussion activities: %s", err.Error()))
+		return nil
+	}
+
+	activities := []*Activity{}
+	for _, comment := range comments {
+		if !f.inDateRange(comment, "createdAt") {
+			continue
+		}
+		repo := getString(getMap(getMap(comment, "discussion"), "repository"), "nameWithOwner")
+		if repo == "" || !repositoryAllowed(f.config.repositoryFilter, repo, f.lookupRepositoryAttributes) {
+			continue
+		}
+		activity, err := transformDiscussionComment(repo, comment)
+		if err != nil {
+			f.logger.Debug(fmt.Sprintf("Skipping discussion comment: %s", err.Error()))
+			continue
+		}
+		activities = append(activities, activity)
+	}
+
+	f.logger.Info(fmt.Sprintf("Fetched %d discussion activities", len(activities)))
+	return activities
+}
+
+// transformDiscussionComment transforms a GraphQL discussion comment to an Activity. A comment
+// currently marked as the answer of its discussion becomes a discussion_answer activity.
+func transformDiscussionComment(repo string, comment map[string]any) (*Activity, error) {
+	commentID := getInt(comment, "databaseId")
+	if commentID <= 0 {
+		return nil, fmt.Errorf("missing databaseId in discussion comment")
+	}
+	timestamp, err := time.Parse(time.RFC3339, getString(comment, "createdAt"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp format: %w", err)
+	}
+	timestamp = timestamp.UTC()
+
+	discussion := getMap(comment, "discussion")
+	number := getInt(discussion, "number")
+	if number <= 0 {
+		return nil, fmt.Errorf("invalid discussion in discussion comment")
+	}
+	discussionTitle := getString(discussion, "title")
+
+	isAnswer, _ := comment["isAnswer"].(bool)
+	isReply := getMap(comment, "replyTo") != nil
+
+	activityType := "discussion_comment"
+	title := fmt.Sprintf("Commented on Discussion #%d", number)
+	if isAnswer {
+		activityType = "discussion_answer"
+		title = fmt.Sprintf("Answered Discussion #%d", number)
+	} else if isReply {
+		title = fmt.Sprintf("Replied in Discussion #%d", number)
+	}
+	url := getString(comment, "url")
+
+	metadata := map[string]any{
+		"comment_id":        int64(commentID),
+		"discussion_number": number,
+		"discussion_title":  discussionTitle,
+		"category":          getString(getMap(discussion, "category"), "name"),
+		"is_answer":         isAnswer,
+		"is_reply":          isReply,
+	}
+
+	gen := core.NewContextGenerator()
+	discussionContext := gen.CreateDiscussionContext(repo, number)
+	if discussionTitle != "" {
+		discussionContext.Title = &discussionTitle
+	}
+	contexts := []*core.Context{
+		gen.CreateSourceContext(),
+		gen.CreateRepositoryContext(repo),
+		discussionContext,
+	}
+
+	return &Activity{
+		Id:           core.MakeActivityID(fmt.Sprintf("discussion_comment:%d", commentID)),
+		Timestamp:    timestamp,
+		Title:        title,
+		Description:  getString(comment, "body"),
+		Source:       core.ConnectorID,
+		ActivityType: activityType,
+		Url:          &url,
+		Metadata:     metadata,
+		Contexts:     contexts,
+	}, nil
+}
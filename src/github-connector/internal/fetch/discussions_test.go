@@ -0,0 +1,118 @@
+package fetch
+
+import (
+	"errors"
+	"github-connector/internal/core"
+	mock_fetch "github-connector/mock/fetch"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func discussionComments(t *testing.T) []map[string]any {
+	t.Helper()
+	nodes := loadJSONTestData(t, "../../testdata/discussions/comments.json")["nodes"].([]any)
+	comments := make([]map[string]any, 0, len(nodes))
+	for _, node := range nodes {
+		comments = append(comments, node.(map[string]any))
+	}
+	return comments
+}
+
+// ascendingDiscussionComments returns the comments of the fixture oldest first
+func ascendingDiscussionComments(t *testing.T) []map[string]any {
+	comments := discussionComments(t)
+	slices.Reverse(comments)
+	return comments
+}
+
+func TestTransformDiscussionComment(t *testing.T) {
+	comments := discussionComments(t)
+
+	got, err := transformDiscussionComment("testorg/testrepo", comments[1])
+	assert.NoError(t, err)
+	assert.Equal(t, "github:discussion_comment:14801234", got.Id)
+	assert.Equal(t, "discussion_answer", got.ActivityType)
+	assert.Equal(t, "Answered Discussion #7", got.Title)
+	assert.Equal(t, "A queue per tenant avoids one tenant starving the others.", got.Description)
+	assert.Equal(t, "https://github.com/testorg/testrepo/discussions/7#discussioncomment-14801234", *got.Url)
+	assert.Equal(t, map[string]any{
+		"comment_id":        int64(14801234),
+		"discussion_number": 7,
+		"discussion_title":  "RFC: Move uploads to a background queue",
+		"category":          "RFC",
+		"is_answer":         true,
+		"is_reply":          false,
+	}, got.Metadata)
+	if assert.Len(t, got.Contexts, 3) {
+		assert.Equal(t, "github:discussion:testorg/testrepo:7", got.Contexts[2].Id)
+		assert.Equal(t, "RFC: Move uploads to a background queue", *got.Contexts[2].Title)
+	}
+
+	got, err = transformDiscussionComment("testorg/testrepo", comments[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "discussion_comment", got.ActivityType)
+	assert.Equal(t, "Replied in Discussion #7", got.Title)
+
+	_, err = transformDiscussionComment("testorg/testrepo", map[string]any{"databaseId": float64(1), "createdAt": "2025-11-18T10:00:00Z"})
+	assert.ErrorContains(t, err, "invalid discussion")
+}
+
+func TestFetchActivities_Discussions(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []map[string]any
+		err      error
+		wantIDs  []string
+	}{
+		{
+			name:     "comments within the date range of allowed repositories",
+			comments: discussionComments(t),
+			wantIDs:  []string{"github:discussion_comment:14801240", "github:discussion_comment:14801234"},
+		},
+		{
+			name:     "comments listed oldest first",
+			comments: ascendingDiscussionComments(t),
+			wantIDs:  []string{"github:discussion_comment:14801240", "github:discussion_comment:14801234"},
+		},
+		{
+			name:    "failure skips discussions",
+			err:     errors.New("GitHub GraphQL error: rate limited"),
+			wantIDs: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+			mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-18")).Return([]map[string]any{}, nil).Times(1)
+			opts := eventOptions("2025-11-18")
+			opts.TimeField = "createdAt"
+			mockHTTP.EXPECT().FetchDiscussionComments("username", opts).Return(tt.comments, tt.err).Times(1)
+
+			cfg := map[string]any{
+				"username":            "username",
+				"include_discussions": true,
+				"repository_patterns": []any{"testorg/*"},
+			}
+			fetcher, err := NewActivityFetcher(mockHTTP, cfg, "2025-11-18", core.NewNoopLogger())
+			if err != nil {
+				t.Fatalf("Failed to create ActivityFetcher: %v", err)
+			}
+
+			got, err := fetcher.FetchActivities()
+			assert.NoError(t, err)
+
+			ids := []string{}
+			for _, activity := range got {
+				ids = append(ids, activity.Id)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package fetch
+
+import (
+	"encoding/json"
+	"fmt"
+	"github-connector/internal/core"
+	"strings"
+	"time"
+)
+
+// event is a GitHub Events API event. Its payload is decoded according to Type by decodePayload.
+type event struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Actor     user            `json:"actor"`
+	Repo      eventRepo       `json:"repo"`
+	CreatedAt string          `json:"created_at"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+type eventRepo struct {
+	Name string `json:"name"`
+}
+
+type user struct {
+	Login string `json:"login"`
+}
+
+type team struct {
+	Name string `json:"name"`
+}
+
+type label struct {
+	Name string `json:"name"`
+}
+
+type branchRef struct {
+	Ref  string   `json:"ref"`
+	SHA  string   `json:"sha"`
+	Repo *refRepo `json:"repo"`
+}
+
+// refRepo is the repository of a pull request branch. Trimmed Events API payloads carry only
+// the repository name and API URL, so the full name is derived from the URL when missing.
+type refRepo struct {
+	FullName string `json:"full_name"`
+	URL      string `json:"url"`
+}
+
+// fullName returns the "owner/repo" of the repository, or "" if it cannot be determined
+func (r *refRepo) fullName() string {
+	if r == nil {
+		return ""
+	}
+	if r.FullName != "" {
+		return r.FullName
+	}
+	name, ok := strings.CutPrefix(r.URL, core.GithubAPIBaseURL+"/repos/")
+	if !ok || strings.Count(name, "/") != 1 {
+		return ""
+	}
+	return name
+}
+
+type pushPayload struct {
+	Ref    string `json:"ref"`
+	Before string `json:"before"`
+	Head   string `json:"head"`
+}
+
+type pullRequestPayload struct {
+	Action            string       `json:"action"`
+	Number            int          `json:"number"`
+	PullRequest       *pullRequest `json:"pull_request"`
+	RequestedReviewer *user        `json:"requested_reviewer"`
+	RequestedTeam     *team        `json:"requested_team"`
+	Assignee          *user        `json:"assignee"`
+	Label             *label       `json:"label"`
+}
+
+// pullRequest is the pull request object of event payloads. The Events API omits most fields
+// (e.g., title and draft) for some event types, so optional fields are pointers.
+type pullRequest struct {
+	Number int       `json:"number"`
+	Title  *string   `json:"title"`
+	Draft  *bool     `json:"draft"`
+	Merged bool      `json:"merged"`
+	Base   branchRef `json:"base"`
+	Head   branchRef `json:"head"`
+}
+
+type issuesPayload struct {
+	Action string `json:"action"`
+	Issue  *issue `json:"issue"`
+}
+
+type issue struct {
+	Number      int             `json:"number"`
+	Title       string          `json:"title"`
+	HTMLURL     string          `json:"html_url"`
+	State       string          `json:"state"`
+	User        user            `json:"user"`
+	Labels      []label         `json:"labels"`
+	PullRequest json.RawMessage `json:"pull_request"`
+}
+
+// isPullRequest reports whether the issue is a pull request, which the API marks with a
+// pull_request object
+func (i *issue) isPullRequest() bool {
+	return len(i.PullRequest) > 0 && string(i.PullRequest) != "null"
+}
+
+type issueCommentPayload struct {
+	Action  string   `json:"action"`
+	Issue   *issue   `json:"issue"`
+	Comment *comment `json:"comment"`
+}
+
+type comment struct {
+	ID                  int64  `json:"id"`
+	Body                string `json:"body"`
+	HTMLURL             string `json:"html_url"`
+	CreatedAt           string `json:"created_at"`
+	User                user   `json:"user"`
+	Path                string `json:"path"`
+	CommitID            string `json:"commit_id"`
+	PullRequestReviewID *int64 `json:"pull_request_review_id"`
+	InReplyToID         *int64 `json:"in_reply_to_id"`
+}
+
+type releasePayload struct {
+	Action  string   `json:"action"`
+	Release *release `json:"release"`
+}
+
+type release struct {
+	ID         int64  `json:"id"`
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	HTMLURL    string `json:"html_url"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+type deletePayload struct {
+	Ref        string `json:"ref"`
+	RefType    string `json:"ref_type"`
+	PusherType string `json:"pusher_type"`
+}
+
+type prReviewCommentPayload struct {
+	PullRequest *pullRequest `json:"pull_request"`
+	Comment     *comment     `json:"comment"`
+}
+
+type prReviewPayload struct {
+	PullRequest *pullRequest `json:"pull_request"`
+	Review      *review      `json:"review"`
+}
+
+type review struct {
+	Body        string `json:"body"`
+	HTMLURL     string `json:"html_url"`
+	State       string `json:"state"`
+	SubmittedAt string `json:"submitted_at"`
+	User        user   `json:"user"`
+}
+
+// decodeEvent converts an event returned by the HTTP client into an event. Fields of the wrong
+// JSON type are reported as errors rather than causing a panic.
+func decodeEvent(raw map[string]any) (*event, error) {
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	var e event
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, fmt.Errorf("invalid event: %w", err)
+	}
+	if e.Type == "" {
+		return nil, fmt.Errorf("missing event type")
+	}
+	if e.ID == "" {
+		return nil, fmt.Errorf("missing id in %s", e.Type)
+	}
+	if e.Repo.Name == "" {
+		return nil, fmt.Errorf("invalid repo in %s", e.Type)
+	}
+	return &e, nil
+}
+
+// decodePayload decodes the payload of e into payload
+func (e *event) decodePayload(payload any) error {
+	if len(e.Payload) == 0 || string(e.Payload) == "null" {
+		return fmt.Errorf("invalid payload in %s", e.Type)
+	}
+	if err := json.Unmarshal(e.Payload, payload); err != nil {
+		return fmt.Errorf("invalid payload in %s: %w", e.Type, err)
+	}
+	return nil
+}
+
+// timestamp returns the time the event was created, in UTC
+func (e *event) timestamp() (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, e.CreatedAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp format: %w", err)
+	}
+	return t.UTC(), nil
+}
+
+// valueOrNil returns the value p points to, or nil if the field was absent
+func valueOrNil[T any](p *T) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
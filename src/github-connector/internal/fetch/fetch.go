@@ -3,6 +3,9 @@ package fetch
 import (
 	"fmt"
 	"github-connector/internal/core"
+	"github-connector/internal/paginate"
+	"sort"
+	"strings"
 )
 
 // ActivityFetcher defines the structure for fetching activities from GitHub
@@ -10,6 +13,8 @@ type ActivityFetcher struct {
 	httpClient HTTPClient
 	config     *config
 	logger     core.Logger
+
+	repositoryAttributes map[string]*core.RepositoryAttributes
 }
 
 // NewActivityFetcher creates a new ActivityFetcher instance
@@ -20,9 +25,10 @@ func NewActivityFetcher(httpClient HTTPClient, cfg map[string]any, targetDate st
 	}
 
 	return &ActivityFetcher{
-		httpClient: httpClient,
-		config:     config,
-		logger:     logger,
+		httpClient:           httpClient,
+		config:               config,
+		logger:               logger,
+		repositoryAttributes: map[string]*core.RepositoryAttributes{},
 	}, nil
 }
 
@@ -37,9 +43,12 @@ func (f *ActivityFetcher) FetchActivities() ([]*Activity, error) {
 
 	f.logger.Info(fmt.Sprintf("Fetched %d events", len(allEvents)))
 
-	filteredEvents := filterEventsByRepository(allEvents, f.config.repositoryPatterns)
+	filteredEvents := filterEventsByRepository(allEvents, f.config.repositoryFilter, f.lookupRepositoryAttributes)
 	f.logger.Info(fmt.Sprintf("After repository filtering: %d events", len(filteredEvents)))
 
+	filteredEvents = filterEventsByActor(filteredEvents, f.config.excludeBotActors, f.config.excludedActors)
+	f.logger.Info(fmt.Sprintf("After actor filtering: %d events", len(filteredEvents)))
+
 	activities := []*Activity{}
 	for _, event := range filteredEvents {
 		activity, err := transformEvent(event)
@@ -52,37 +61,122 @@ func (f *ActivityFetcher) FetchActivities() ([]*Activity, error) {
 		}
 	}
 
+	if f.config.includeWorkflowRuns || f.config.includeDeployments {
+		activities = append(activities, f.fetchActionsActivities(f.actionsRepositories(filteredEvents))...)
+	}
+	if f.config.includeProjects {
+		activities = append(activities, f.fetchProjectActivities(filteredEvents)...)
+	}
+	if f.config.includeDiscussions {
+		activities = append(activities, f.fetchDiscussionActivities()...)
+	}
+	// Keep the Events API order (newest first) so that aggregation sees adjacent activities
+	sort.SliceStable(activities, func(i, j int) bool {
+		return activities[i].Timestamp.After(activities[j].Timestamp)
+	})
+
+	activities = filterActivitiesByType(activities, f.config.excludedActivityTypes)
+
+	if f.config.aggregateActivities {
+		activities = aggregateActivities(activities)
+		f.logger.Info(fmt.Sprintf("After aggregation: %d activities", len(activities)))
+	}
+
 	f.logger.Info("Finished fetching activities")
 
 	return activities, nil
 }
 
+// fetchAllEvents fetches the user's events within the date range. In the authenticated fetch
+// mode, the events the user performed in the organization feeds selected by repository_patterns
+// are merged in, de-duplicated by event ID.
 func (f *ActivityFetcher) fetchAllEvents() ([]map[string]any, error) {
-	allEvents := []map[string]any{}
+	events, err := f.httpClient.FetchActivities(f.config.username, f.eventOptions())
+	if err != nil {
+		return nil, fmt.Errorf("error fetching activities: %w", err)
+	}
+	allEvents := filterEventsByDate(events, f.config.startTime, f.config.endTime)
 
-	// GitHub Events API returns max 300 events (3 pages with per_page=100)
-	for page := 1; page <= 3; page++ {
-		events, err := f.httpClient.FetchActivities(f.config.username, page)
+	if f.config.fetchMode != FetchModeAuthenticated {
+		return allEvents, nil
+	}
+
+	orgs, err := f.organizationsToFetch()
+	if err != nil {
+		f.logger.Warn(fmt.Sprintf("Skipping organization events: %s", err.Error()))
+		return allEvents, nil
+	}
+
+	for _, org := range orgs {
+		orgEvents, err := f.httpClient.FetchOrgActivities(f.config.username, org, f.eventOptions())
 		if err != nil {
-			return nil, fmt.Errorf("error fetching activities on page %d: %w", page, err)
+			f.logger.Warn(fmt.Sprintf("Skipping events of organization %s: %s", org, err.Error()))
+			continue
 		}
+		orgEvents = filterEventsByDate(orgEvents, f.config.startTime, f.config.endTime)
 
-		if len(events) == 0 {
-			f.logger.Debug(fmt.Sprintf("No more events found at page %d, stopping pagination", page))
-			break
-		}
+		// The organization feed also contains events of other members
+		orgEvents = filterEventsByActorLogin(orgEvents, f.config.username)
+		f.logger.Debug(fmt.Sprintf("Organization %s: %d events", org, len(orgEvents)))
+		allEvents = append(allEvents, orgEvents...)
+	}
+
+	return mergeEventFeeds(allEvents), nil
+}
 
-		// Filter events by date and check if we should stop
-		filteredEvents, shouldStop := filterEventsByDate(events, f.config.startTime, f.config.endTime)
-		allEvents = append(allEvents, filteredEvents...)
+// eventOptions returns the pagination options of event feeds, which are listed newest first
+// and can stop at the first event before the target date
+func (f *ActivityFetcher) eventOptions() paginate.Options {
+	return paginate.Options{
+		Limits:    f.config.pagination,
+		Watermark: f.config.startTime,
+		TimeField: "created_at",
+	}
+}
+
+// organizationsToFetch returns the organizations of the token owner matched by
+// repository_patterns, or none when the token does not belong to the configured user.
+func (f *ActivityFetcher) organizationsToFetch() ([]string, error) {
+	login, err := f.httpClient.FetchAuthenticatedUser()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch authenticated user: %w", err)
+	}
+	if !strings.EqualFold(login, f.config.username) {
+		f.logger.Warn(fmt.Sprintf("Token belongs to %s, not %s; organization events are not fetched", login, f.config.username))
+		return nil, nil
+	}
 
-		f.logger.Debug(fmt.Sprintf("Page %d: %d events fetched, %d filtered", page, len(events), len(filteredEvents)))
+	orgs, err := f.httpClient.FetchUserOrganizations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch organizations: %w", err)
+	}
 
-		if shouldStop {
-			f.logger.Debug("Reached events outside date range, stopping pagination")
-			break
+	selected := []string{}
+	for _, org := range orgs {
+		if f.config.repositoryFilter.MatchesOwner(org) {
+			selected = append(selected, org)
 		}
 	}
 
-	return allEvents, nil
+	f.logger.Debug(fmt.Sprintf("Fetching events of organizations: %v", selected))
+	return selected, nil
+}
+
+// lookupRepositoryAttributes fetches the attributes tested by repository filter qualifiers.
+// Failures are logged and treated as unknown attributes so that events are not dropped.
+func (f *ActivityFetcher) lookupRepositoryAttributes(repo string) *core.RepositoryAttributes {
+	if attrs, ok := f.repositoryAttributes[repo]; ok {
+		return attrs
+	}
+
+	var attrs *core.RepositoryAttributes
+	response, err := f.httpClient.FetchRepository(repo)
+	if err != nil {
+		f.logger.Warn(fmt.Sprintf("Failed to fetch attributes of %s: %s", repo, err.Error()))
+	} else {
+		attrs = core.RepositoryAttributesFromAPI(response)
+	}
+
+	f.repositoryAttributes[repo] = attrs
+	return attrs
 }
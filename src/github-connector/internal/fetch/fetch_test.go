@@ -3,6 +3,7 @@ package fetch
 import (
 	"encoding/json"
 	"github-connector/internal/core"
+	"github-connector/internal/paginate"
 	mock_fetch "github-connector/mock/fetch"
 	"os"
 	"testing"
@@ -29,6 +30,15 @@ func loadJSONTestData(t *testing.T, path string) map[string]any {
 	return data
 }
 
+// eventOptions returns the default pagination options of event feeds for the target date
+func eventOptions(targetDate string) paginate.Options {
+	startTime, _, _ := parseDateRange(targetDate)
+	return paginate.Options{Limits: paginate.DefaultLimits, Watermark: startTime, TimeField: "created_at"}
+}
+
+// workflowRunOptions are the default pagination options of workflow runs
+var workflowRunOptions = paginate.Options{Limits: paginate.DefaultLimits}
+
 func TestFetchActivities(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -44,8 +54,7 @@ func TestFetchActivities(t *testing.T) {
 				response := loadJSONTestData(t, "../../testdata/events/delete.json")
 
 				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
-				mockHTTP.EXPECT().FetchActivities("username", 1).Return([]map[string]any{response}, nil).Times(1)
-				mockHTTP.EXPECT().FetchActivities("username", 2).Return([]map[string]any{}, nil).Times(1)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-18")).Return([]map[string]any{response}, nil).Times(1)
 				return mockHTTP
 			},
 			cfg: map[string]any{
@@ -97,6 +106,21 @@ func TestFetchActivities(t *testing.T) {
 								},
 							},
 						},
+						{
+							ConnectorId:  "github",
+							Id:           "github:branch:ymtdzzz/otel-tui:chore/golangci-lint",
+							Title:        ptrString("chore/golangci-lint"),
+							Name:         "branch:chore/golangci-lint",
+							ParentId:     "github:repository:ymtdzzz/otel-tui",
+							ResourceType: "branch",
+							Url:          ptrString("https://github.com/ymtdzzz/otel-tui/tree/chore/golangci-lint"),
+							Metadata: map[string]any{
+								"enrichment_params": map[string]any{
+									"repo":   "ymtdzzz/otel-tui",
+									"branch": "chore/golangci-lint",
+								},
+							},
+						},
 					},
 				},
 			},
@@ -108,8 +132,7 @@ func TestFetchActivities(t *testing.T) {
 				response := loadJSONTestData(t, "../../testdata/events/issue_comment.json")
 
 				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
-				mockHTTP.EXPECT().FetchActivities("username", 1).Return([]map[string]any{response}, nil).Times(1)
-				mockHTTP.EXPECT().FetchActivities("username", 2).Return([]map[string]any{}, nil).Times(1)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-08")).Return([]map[string]any{response}, nil).Times(1)
 				return mockHTTP
 			},
 			cfg: map[string]any{
@@ -127,7 +150,7 @@ func TestFetchActivities(t *testing.T) {
 					Url:          ptrString("https://github.com/ymtdzzz/otel-tui/issues/340#issuecomment-3506104349"),
 					Timestamp:    time.Date(2025, 11, 8, 7, 32, 14, 0, time.UTC),
 					Metadata: map[string]any{
-						"comment_id":         float64(3506104349),
+						"comment_id":         int64(3506104349),
 						"issue_number":       340,
 						"comment_author":     "ymtdzzz",
 						"comment_created_at": "2025-11-08T07:32:14Z",
@@ -188,8 +211,7 @@ func TestFetchActivities(t *testing.T) {
 				response := loadJSONTestData(t, "../../testdata/events/issues.json")
 
 				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
-				mockHTTP.EXPECT().FetchActivities("username", 1).Return([]map[string]any{response}, nil).Times(1)
-				mockHTTP.EXPECT().FetchActivities("username", 2).Return([]map[string]any{}, nil).Times(1)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-17")).Return([]map[string]any{response}, nil).Times(1)
 				return mockHTTP
 			},
 			cfg: map[string]any{
@@ -269,8 +291,7 @@ func TestFetchActivities(t *testing.T) {
 				response := loadJSONTestData(t, "../../testdata/events/pr_comment.json")
 
 				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
-				mockHTTP.EXPECT().FetchActivities("username", 1).Return([]map[string]any{response}, nil).Times(1)
-				mockHTTP.EXPECT().FetchActivities("username", 2).Return([]map[string]any{}, nil).Times(1)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-17")).Return([]map[string]any{response}, nil).Times(1)
 				return mockHTTP
 			},
 			cfg: map[string]any{
@@ -288,7 +309,7 @@ func TestFetchActivities(t *testing.T) {
 					Url:          ptrString("https://github.com/testorg/testrepo/pull/52580#issuecomment-3540831349"),
 					Timestamp:    time.Date(2025, 11, 17, 9, 43, 25, 0, time.UTC),
 					Metadata: map[string]any{
-						"comment_id":         float64(3540831349),
+						"comment_id":         int64(3540831349),
 						"issue_number":       52580,
 						"comment_author":     "ymtdzzz",
 						"comment_created_at": "2025-11-17T09:43:25Z",
@@ -325,7 +346,7 @@ func TestFetchActivities(t *testing.T) {
 						{
 							ConnectorId:  "github",
 							Id:           "github:pull_request:testorg/testrepo:52580",
-							Title:        ptrString("PR #52580"),
+							Title:        ptrString("Fix: Resolve crash on launch"),
 							Name:         "PR #52580",
 							Description:  nil,
 							ParentId:     "github:repository:testorg/testrepo",
@@ -349,8 +370,7 @@ func TestFetchActivities(t *testing.T) {
 				response := loadJSONTestData(t, "../../testdata/events/pr_review.json")
 
 				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
-				mockHTTP.EXPECT().FetchActivities("username", 1).Return([]map[string]any{response}, nil).Times(1)
-				mockHTTP.EXPECT().FetchActivities("username", 2).Return([]map[string]any{}, nil).Times(1)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-13")).Return([]map[string]any{response}, nil).Times(1)
 				return mockHTTP
 			},
 			cfg: map[string]any{
@@ -363,7 +383,7 @@ func TestFetchActivities(t *testing.T) {
 					ActivityType: "pr_review",
 					Source:       "github",
 					Id:           "github:4645219660",
-					Title:        "Reviewed PR #52742 in testorg/testrepo",
+					Title:        "Approved PR #52742 in testorg/testrepo",
 					Description:  "This is a great change! Approved.",
 					Url:          ptrString("https://github.com/testorg/testrepo/pull/52742#pullrequestreview-3457546608"),
 					Timestamp:    time.Date(2025, 11, 13, 5, 34, 50, 0, time.UTC),
@@ -420,6 +440,36 @@ func TestFetchActivities(t *testing.T) {
 								},
 							},
 						},
+						{
+							ConnectorId:  "github",
+							Id:           "github:branch:testorg/testrepo:main",
+							Title:        ptrString("main"),
+							Name:         "branch:main",
+							ParentId:     "github:repository:testorg/testrepo",
+							ResourceType: "branch",
+							Url:          ptrString("https://github.com/testorg/testrepo/tree/main"),
+							Metadata: map[string]any{
+								"enrichment_params": map[string]any{
+									"repo":   "testorg/testrepo",
+									"branch": "main",
+								},
+							},
+						},
+						{
+							ConnectorId:  "github",
+							Id:           "github:branch:testorg/testrepo:feature/my-awesome-feature",
+							Title:        ptrString("feature/my-awesome-feature"),
+							Name:         "branch:feature/my-awesome-feature",
+							ParentId:     "github:repository:testorg/testrepo",
+							ResourceType: "branch",
+							Url:          ptrString("https://github.com/testorg/testrepo/tree/feature/my-awesome-feature"),
+							Metadata: map[string]any{
+								"enrichment_params": map[string]any{
+									"repo":   "testorg/testrepo",
+									"branch": "feature/my-awesome-feature",
+								},
+							},
+						},
 					},
 				},
 			},
@@ -431,8 +481,7 @@ func TestFetchActivities(t *testing.T) {
 				response := loadJSONTestData(t, "../../testdata/events/pr_review_comment.json")
 
 				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
-				mockHTTP.EXPECT().FetchActivities("username", 1).Return([]map[string]any{response}, nil).Times(1)
-				mockHTTP.EXPECT().FetchActivities("username", 2).Return([]map[string]any{}, nil).Times(1)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-17")).Return([]map[string]any{response}, nil).Times(1)
 				return mockHTTP
 			},
 			cfg: map[string]any{
@@ -450,7 +499,10 @@ func TestFetchActivities(t *testing.T) {
 					Url:          ptrString("https://github.com/testorg/testrepo/pull/52580#discussion_r2532700510"),
 					Timestamp:    time.Date(2025, 11, 17, 4, 56, 32, 0, time.UTC),
 					Metadata: map[string]any{
-						"comment_id":     float64(2532700510),
+						"comment_id":     int64(2532700510),
+						"review_id":      int64(1213456789),
+						"thread_id":      int64(2532700510),
+						"in_reply_to_id": nil,
 						"pr_number":      52580,
 						"comment_author": "ymtdzzz",
 						"file_path":      "path/to/file.rb",
@@ -503,6 +555,68 @@ func TestFetchActivities(t *testing.T) {
 								},
 							},
 						},
+						{
+							ConnectorId:  "github",
+							Id:           "github:branch:testorg/testrepo:main",
+							Title:        ptrString("main"),
+							Name:         "branch:main",
+							ParentId:     "github:repository:testorg/testrepo",
+							ResourceType: "branch",
+							Url:          ptrString("https://github.com/testorg/testrepo/tree/main"),
+							Metadata: map[string]any{
+								"enrichment_params": map[string]any{
+									"repo":   "testorg/testrepo",
+									"branch": "main",
+								},
+							},
+						},
+						{
+							ConnectorId:  "github",
+							Id:           "github:branch:testorg/testrepo:feature/awesome-feature",
+							Title:        ptrString("feature/awesome-feature"),
+							Name:         "branch:feature/awesome-feature",
+							ParentId:     "github:repository:testorg/testrepo",
+							ResourceType: "branch",
+							Url:          ptrString("https://github.com/testorg/testrepo/tree/feature/awesome-feature"),
+							Metadata: map[string]any{
+								"enrichment_params": map[string]any{
+									"repo":   "testorg/testrepo",
+									"branch": "feature/awesome-feature",
+								},
+							},
+						},
+						{
+							ConnectorId:  "github",
+							Id:           "github:commit:testorg/testrepo:b3e292a848f0547955e4f2d9b4803acb70faefd1",
+							Title:        ptrString("Commit b3e292a"),
+							Name:         "commit:b3e292a",
+							ParentId:     "github:repository:testorg/testrepo",
+							ResourceType: "commit",
+							Url:          ptrString("https://github.com/testorg/testrepo/commit/b3e292a848f0547955e4f2d9b4803acb70faefd1"),
+							Metadata: map[string]any{
+								"enrichment_params": map[string]any{
+									"repo": "testorg/testrepo",
+									"sha":  "b3e292a848f0547955e4f2d9b4803acb70faefd1",
+								},
+							},
+						},
+						{
+							ConnectorId:  "github",
+							Id:           "github:review_thread:testorg/testrepo:52580:2532700510",
+							Title:        ptrString("Review thread on path/to/file.rb"),
+							Name:         "Thread r2532700510",
+							Description:  nil,
+							ParentId:     "github:pull_request:testorg/testrepo:52580",
+							ResourceType: "review_thread",
+							Url:          nil,
+							Metadata: map[string]any{
+								"enrichment_params": map[string]any{
+									"repo":       "testorg/testrepo",
+									"pr_number":  "52580",
+									"comment_id": "2532700510",
+								},
+							},
+						},
 					},
 				},
 			},
@@ -514,8 +628,7 @@ func TestFetchActivities(t *testing.T) {
 				response := loadJSONTestData(t, "../../testdata/events/pull_request.json")
 
 				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
-				mockHTTP.EXPECT().FetchActivities("username", 1).Return([]map[string]any{response}, nil).Times(1)
-				mockHTTP.EXPECT().FetchActivities("username", 2).Return([]map[string]any{}, nil).Times(1)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-12")).Return([]map[string]any{response}, nil).Times(1)
 				return mockHTTP
 			},
 			cfg: map[string]any{
@@ -528,13 +641,16 @@ func TestFetchActivities(t *testing.T) {
 					ActivityType: "pull_request",
 					Source:       "github",
 					Id:           "github:1234567890",
-					Title:        "PR #10286 merged in testorg/testrepo",
+					Title:        "Merged PR #10286 in testorg/testrepo",
 					Description:  "Pull request #10286 was merged",
 					Url:          ptrString("https://github.com/testorg/testrepo/pull/10286"),
 					Timestamp:    time.Date(2025, 11, 12, 1, 6, 1, 0, time.UTC),
 					Metadata: map[string]any{
 						"pr_number":   10286,
+						"pr_title":    nil,
 						"action":      "merged",
+						"merged":      true,
+						"draft":       nil,
 						"base_branch": "main",
 						"head_branch": "feature/my-awesome-feature",
 						"base_sha":    "4d0ac009a8e1f363fb6fea838abc52b2351d184e",
@@ -585,6 +701,51 @@ func TestFetchActivities(t *testing.T) {
 								},
 							},
 						},
+						{
+							ConnectorId:  "github",
+							Id:           "github:branch:testorg/testrepo:main",
+							Title:        ptrString("main"),
+							Name:         "branch:main",
+							ParentId:     "github:repository:testorg/testrepo",
+							ResourceType: "branch",
+							Url:          ptrString("https://github.com/testorg/testrepo/tree/main"),
+							Metadata: map[string]any{
+								"enrichment_params": map[string]any{
+									"repo":   "testorg/testrepo",
+									"branch": "main",
+								},
+							},
+						},
+						{
+							ConnectorId:  "github",
+							Id:           "github:branch:testorg/testrepo:feature/my-awesome-feature",
+							Title:        ptrString("feature/my-awesome-feature"),
+							Name:         "branch:feature/my-awesome-feature",
+							ParentId:     "github:repository:testorg/testrepo",
+							ResourceType: "branch",
+							Url:          ptrString("https://github.com/testorg/testrepo/tree/feature/my-awesome-feature"),
+							Metadata: map[string]any{
+								"enrichment_params": map[string]any{
+									"repo":   "testorg/testrepo",
+									"branch": "feature/my-awesome-feature",
+								},
+							},
+						},
+						{
+							ConnectorId:  "github",
+							Id:           "github:commit:testorg/testrepo:556eadf823c287022e62c8d76b77fe24371080f6",
+							Title:        ptrString("Commit 556eadf"),
+							Name:         "commit:556eadf",
+							ParentId:     "github:repository:testorg/testrepo",
+							ResourceType: "commit",
+							Url:          ptrString("https://github.com/testorg/testrepo/commit/556eadf823c287022e62c8d76b77fe24371080f6"),
+							Metadata: map[string]any{
+								"enrichment_params": map[string]any{
+									"repo": "testorg/testrepo",
+									"sha":  "556eadf823c287022e62c8d76b77fe24371080f6",
+								},
+							},
+						},
 					},
 				},
 			},
@@ -596,8 +757,7 @@ func TestFetchActivities(t *testing.T) {
 				response := loadJSONTestData(t, "../../testdata/events/push.json")
 
 				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
-				mockHTTP.EXPECT().FetchActivities("username", 1).Return([]map[string]any{response}, nil).Times(1)
-				mockHTTP.EXPECT().FetchActivities("username", 2).Return([]map[string]any{}, nil).Times(1)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-12")).Return([]map[string]any{response}, nil).Times(1)
 				return mockHTTP
 			},
 			cfg: map[string]any{
@@ -647,11 +807,103 @@ func TestFetchActivities(t *testing.T) {
 								},
 							},
 						},
+						{
+							ConnectorId:  "github",
+							Id:           "github:branch:ymtdzzz/otel-tui:feature/refactor_components",
+							Title:        ptrString("feature/refactor_components"),
+							Name:         "branch:feature/refactor_components",
+							ParentId:     "github:repository:ymtdzzz/otel-tui",
+							ResourceType: "branch",
+							Url:          ptrString("https://github.com/ymtdzzz/otel-tui/tree/feature/refactor_components"),
+							Metadata: map[string]any{
+								"enrichment_params": map[string]any{
+									"repo":   "ymtdzzz/otel-tui",
+									"branch": "feature/refactor_components",
+								},
+							},
+						},
+						{
+							ConnectorId:  "github",
+							Id:           "github:commit:ymtdzzz/otel-tui:4fb5eb96ecc5141ff2383d720508bd0ccaa1b820",
+							Title:        ptrString("Commit 4fb5eb9"),
+							Name:         "commit:4fb5eb9",
+							ParentId:     "github:repository:ymtdzzz/otel-tui",
+							ResourceType: "commit",
+							Url:          ptrString("https://github.com/ymtdzzz/otel-tui/commit/4fb5eb96ecc5141ff2383d720508bd0ccaa1b820"),
+							Metadata: map[string]any{
+								"enrichment_params": map[string]any{
+									"repo": "ymtdzzz/otel-tui",
+									"sha":  "4fb5eb96ecc5141ff2383d720508bd0ccaa1b820",
+								},
+							},
+						},
 					},
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "bot actors and excluded activity types are dropped",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				botEvent := loadJSONTestData(t, "../../testdata/events/delete.json")
+				botEvent["actor"].(map[string]any)["login"] = "github-actions[bot]"
+				ignoredActorEvent := loadJSONTestData(t, "../../testdata/events/delete.json")
+				ignoredActorEvent["actor"].(map[string]any)["login"] = "Release-Robot"
+				deleteEvent := loadJSONTestData(t, "../../testdata/events/delete.json")
+
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-18")).Return([]map[string]any{botEvent, ignoredActorEvent, deleteEvent}, nil).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"active_auth_method":      "token",
+				"username":                "username",
+				"exclude_bot_actors":      true,
+				"excluded_actors":         []any{"release-robot"},
+				"excluded_activity_types": []any{"delete"},
+			},
+			targetDate: "2025-11-18",
+			want:       []*Activity{},
+			wantErr:    false,
+		},
+		{
+			name: "repository filter excludes events",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/events/delete.json")
+
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-18")).Return([]map[string]any{response}, nil).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"active_auth_method":  "token",
+				"username":            "username",
+				"repository_patterns": []any{"ymtdzzz/*", "!ymtdzzz/otel-*"},
+			},
+			targetDate: "2025-11-18",
+			want:       []*Activity{},
+			wantErr:    false,
+		},
+		{
+			name: "repository filter excludes forks by attributes",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/events/delete.json")
+
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-18")).Return([]map[string]any{response, response}, nil).Times(1)
+				mockHTTP.EXPECT().FetchRepository("ymtdzzz/otel-tui").Return(map[string]any{"fork": true}, nil).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"active_auth_method":              "token",
+				"username":                        "username",
+				"repository_patterns":             []any{"YMTDZZZ/* fork:false"},
+				"repository_patterns_ignore_case": true,
+			},
+			targetDate: "2025-11-18",
+			want:       []*Activity{},
+			wantErr:    false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -676,6 +928,80 @@ func TestFetchActivities(t *testing.T) {
 	}
 }
 
+func TestFetchActivities_AuthenticatedMode(t *testing.T) {
+	deleteEvent := func(id, repo, actor, createdAt string) map[string]any {
+		event := loadJSONTestData(t, "../../testdata/events/delete.json")
+		event["id"] = id
+		event["created_at"] = createdAt
+		event["repo"].(map[string]any)["name"] = repo
+		event["actor"].(map[string]any)["login"] = actor
+		return event
+	}
+
+	tests := []struct {
+		name        string
+		getMockHTTP func(*gomock.Controller) HTTPClient
+		wantIDs     []string
+	}{
+		{
+			name: "merges organization feeds of matched organizations",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-18")).Return([]map[string]any{
+					deleteEvent("1", "username/dotfiles", "username", "2025-11-18T09:00:00Z"),
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchAuthenticatedUser().Return("UserName", nil).Times(1)
+				mockHTTP.EXPECT().FetchUserOrganizations().Return([]string{"acme", "unrelated"}, nil).Times(1)
+				mockHTTP.EXPECT().FetchOrgActivities("username", "acme", eventOptions("2025-11-18")).Return([]map[string]any{
+					deleteEvent("3", "acme/api", "username", "2025-11-18T12:00:00Z"),
+					deleteEvent("2", "acme/api", "colleague", "2025-11-18T11:00:00Z"),
+					deleteEvent("1", "username/dotfiles", "username", "2025-11-18T09:00:00Z"),
+				}, nil).Times(1)
+				return mockHTTP
+			},
+			wantIDs: []string{"github:3", "github:1"},
+		},
+		{
+			name: "token of another user falls back to the user feed",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-18")).Return([]map[string]any{
+					deleteEvent("1", "username/dotfiles", "username", "2025-11-18T09:00:00Z"),
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchAuthenticatedUser().Return("someone-else", nil).Times(1)
+				return mockHTTP
+			},
+			wantIDs: []string{"github:1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			cfg := map[string]any{
+				"username":            "username",
+				"fetch_mode":          "authenticated",
+				"repository_patterns": []any{"username/*", "acme/*"},
+			}
+			fetcher, err := NewActivityFetcher(tt.getMockHTTP(ctrl), cfg, "2025-11-18", core.NewNoopLogger())
+			if err != nil {
+				t.Fatalf("Failed to create ActivityFetcher: %v", err)
+			}
+
+			got, err := fetcher.FetchActivities()
+			assert.NoError(t, err)
+
+			ids := make([]string, 0, len(got))
+			for _, activity := range got {
+				ids = append(ids, activity.Id)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
 func ptrString(s string) *string {
 	return &s
 }
@@ -1,11 +1,16 @@
 package fetch
 
-import "time"
-
-// filterEventsByDate filters events by the target date range
-func filterEventsByDate(events []map[string]any, startTime, endTime time.Time) ([]map[string]any, bool) {
+import (
+	"fmt"
+	"github-connector/internal/core"
+	"sort"
+	"strings"
+	"time"
+)
+
+// filterEventsByDate filters events, listed newest first, by the target date range
+func filterEventsByDate(events []map[string]any, startTime, endTime time.Time) []map[string]any {
 	filtered := []map[string]any{}
-	shouldStop := false
 
 	for _, event := range events {
 		createdAtStr, ok := event["created_at"].(string)
@@ -23,17 +28,17 @@ func filterEventsByDate(events []map[string]any, startTime, endTime time.Time) (
 		}
 
 		if createdAt.Before(startTime) {
-			shouldStop = true
 			break
 		}
 	}
 
-	return filtered, shouldStop
+	return filtered
 }
 
-// filterEventsByRepository filters events by repository patterns
-func filterEventsByRepository(events []map[string]any, patterns []string) []map[string]any {
-	if len(patterns) == 0 {
+// filterEventsByRepository filters events by the repository filter. lookup returns the
+// attributes of a repository, or nil if they are unknown.
+func filterEventsByRepository(events []map[string]any, filter *core.RepositoryFilter, lookup func(repo string) *core.RepositoryAttributes) []map[string]any {
+	if filter.IsEmpty() {
 		return events
 	}
 
@@ -49,7 +54,7 @@ func filterEventsByRepository(events []map[string]any, patterns []string) []map[
 			continue
 		}
 
-		if matchesAnyPattern(repoName, patterns) {
+		if repositoryAllowed(filter, repoName, lookup) {
 			filtered = append(filtered, event)
 		}
 	}
@@ -57,123 +62,106 @@ func filterEventsByRepository(events []map[string]any, patterns []string) []map[
 	return filtered
 }
 
-// matchesAnyPattern checks if a repository name matches any of the patterns
-func matchesAnyPattern(repoName string, patterns []string) bool {
-	for _, pattern := range patterns {
-		if matchPattern(repoName, pattern) {
-			return true
-		}
-	}
-	return false
-}
-
-// matchPattern checks if a string matches a pattern with * wildcards
-// Supports patterns like:
-// - "owner/*" (matches all repos in owner)
-// - "owner/repo" (exact match)
-// - "owner/prefix-*" (matches repos starting with prefix-)
-// - "*/*" (matches all repos)
-func matchPattern(str, pattern string) bool {
-	// Convert pattern to regex-like matching
-	// Split both by '/' to handle owner/repo structure
-	strParts := splitRepo(str)
-	patternParts := splitRepo(pattern)
-
-	// Must have same structure (owner/repo)
-	if len(strParts) != len(patternParts) {
+// repositoryAllowed reports whether repoName passes the repository filter. Unknown attributes
+// (lookup returning nil) do not exclude the repository.
+func repositoryAllowed(filter *core.RepositoryFilter, repoName string, lookup func(repo string) *core.RepositoryAttributes) bool {
+	// Rules without attributes are at least as permissive, so only repositories passing
+	// this check need their attributes looked up
+	if !filter.Allows(repoName, nil) {
 		return false
 	}
-
-	// Check each part
-	for i := range strParts {
-		if !matchWildcard(strParts[i], patternParts[i]) {
+	if filter.NeedsAttributes() {
+		if attrs := lookup(repoName); attrs != nil && !filter.Allows(repoName, attrs) {
 			return false
 		}
 	}
-
 	return true
 }
 
-// splitRepo splits repository name by '/'
-func splitRepo(repo string) []string {
-	parts := []string{}
-	current := ""
-	for _, ch := range repo {
-		if ch == '/' {
-			if current != "" {
-				parts = append(parts, current)
-				current = ""
-			}
-		} else {
-			current += string(ch)
-		}
-	}
-	if current != "" {
-		parts = append(parts, current)
+// filterEventsByActor drops events performed by bot accounts (when excludeBots is set) or by
+// the given actor logins. Logins are compared case-insensitively, and an excluded login also
+// matches its bot account (e.g., "github-actions" matches "github-actions[bot]").
+func filterEventsByActor(events []map[string]any, excludeBots bool, excludedActors map[string]bool) []map[string]any {
+	if !excludeBots && len(excludedActors) == 0 {
+		return events
 	}
-	return parts
-}
 
-// matchWildcard checks if a string matches a pattern with * wildcard
-// * matches one or more characters (not empty string)
-func matchWildcard(str, pattern string) bool {
-	// Exact match
-	if pattern == str {
-		return true
-	}
+	filtered := []map[string]any{}
+	for _, event := range events {
+		actor, _ := event["actor"].(map[string]any)
+		login, _ := actor["login"].(string)
+		login = strings.ToLower(login)
 
-	// No wildcard
-	if !containsWildcard(pattern) {
-		return false
-	}
+		if excludeBots && isBotLogin(login) {
+			continue
+		}
+		if excludedActors[login] || excludedActors[strings.TrimSuffix(login, "[bot]")] {
+			continue
+		}
 
-	// Handle wildcard matching
-	if pattern == "*" {
-		return len(str) > 0
+		filtered = append(filtered, event)
 	}
 
-	// Pattern with wildcard at the end (e.g., "prefix-*")
-	if len(pattern) > 0 && pattern[len(pattern)-1] == '*' {
-		prefix := pattern[:len(pattern)-1]
-		return len(str) >= len(prefix) && str[:len(prefix)] == prefix
-	}
+	return filtered
+}
 
-	// Pattern with wildcard at the start (e.g., "*-suffix")
-	if len(pattern) > 0 && pattern[0] == '*' {
-		suffix := pattern[1:]
-		return len(str) >= len(suffix) && str[len(str)-len(suffix):] == suffix
+// isBotLogin reports whether a login belongs to a GitHub App bot account
+// (e.g., github-actions[bot], dependabot[bot]).
+func isBotLogin(login string) bool {
+	return strings.HasSuffix(login, "[bot]")
+}
+
+// filterActivitiesByType drops activities whose type is in excludedTypes
+func filterActivitiesByType(activities []*Activity, excludedTypes map[string]bool) []*Activity {
+	if len(excludedTypes) == 0 {
+		return activities
 	}
 
-	// Pattern with wildcard in the middle (e.g., "pre*fix")
-	// Find the position of '*'
-	starPos := -1
-	for i, ch := range pattern {
-		if ch == '*' {
-			starPos = i
-			break
+	filtered := []*Activity{}
+	for _, activity := range activities {
+		if !excludedTypes[activity.ActivityType] {
+			filtered = append(filtered, activity)
 		}
 	}
 
-	if starPos >= 0 {
-		prefix := pattern[:starPos]
-		suffix := pattern[starPos+1:]
+	return filtered
+}
 
-		if len(str) < len(prefix)+len(suffix) {
-			return false
+// filterEventsByActorLogin keeps only the events performed by login
+func filterEventsByActorLogin(events []map[string]any, login string) []map[string]any {
+	filtered := []map[string]any{}
+	for _, event := range events {
+		actor, _ := event["actor"].(map[string]any)
+		if actorLogin, _ := actor["login"].(string); strings.EqualFold(actorLogin, login) {
+			filtered = append(filtered, event)
 		}
-
-		return str[:len(prefix)] == prefix && str[len(str)-len(suffix):] == suffix
 	}
-
-	return false
+	return filtered
 }
 
-// containsWildcard checks if a string contains '*'
-func containsWildcard(s string) bool {
-	for _, ch := range s {
-		if ch == '*' {
-			return true
+// mergeEventFeeds removes events that appear in more than one feed and orders the result
+// newest first, as a single feed would be.
+func mergeEventFeeds(events []map[string]any) []map[string]any {
+	merged := []map[string]any{}
+	seen := map[string]bool{}
+	for _, event := range events {
+		id := fmt.Sprintf("%v", event["id"])
+		if seen[id] {
+			continue
 		}
+		seen[id] = true
+		merged = append(merged, event)
 	}
-	return false
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return eventTime(merged[i]).After(eventTime(merged[j]))
+	})
+
+	return merged
+}
+
+func eventTime(event map[string]any) time.Time {
+	createdAtStr, _ := event["created_at"].(string)
+	createdAt, _ := time.Parse(time.RFC3339, createdAtStr)
+	return createdAt
 }
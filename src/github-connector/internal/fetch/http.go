@@ -1,6 +1,26 @@
 package fetch
 
+import "github-connector/internal/paginate"
+
 // HTTPClient is the interface for fetching GitHub events.
+// List methods follow the Link header within the given pagination options.
 type HTTPClient interface {
-	FetchActivities(username string, page int) ([]map[string]any, error)
+	FetchActivities(username string, opts paginate.Options) ([]map[string]any, error)
+	// FetchRepository is used only when repository_patterns test repository attributes
+	FetchRepository(repo string) (map[string]any, error)
+	// The methods below are used only by the authenticated fetch mode
+	FetchAuthenticatedUser() (string, error)
+	FetchUserOrganizations() ([]string, error)
+	FetchOrgActivities(username, org string, opts paginate.Options) ([]map[string]any, error)
+	// The methods below are used only when GitHub Actions activities are enabled.
+	// created is a GitHub search qualifier value (e.g., "2025-11-11..2025-11-18"); actor may be empty.
+	FetchWorkflowRuns(repo, actor, created string, opts paginate.Options) ([]map[string]any, error)
+	FetchRunApprovals(repo string, runID int64) ([]map[string]any, error)
+	// FetchProjectTimeline returns the GraphQL issue or pull request with its project (v2)
+	// timeline events since the given RFC 3339 time. It is used only when project changes are
+	// enabled.
+	FetchProjectTimeline(repo string, number int, since string) (map[string]any, error)
+	// FetchDiscussionComments returns the GraphQL discussion comments authored by the user,
+	// newest first, with their discussion. It is used only when discussions are enabled.
+	FetchDiscussionComments(username string, opts paginate.Options) ([]map[string]any, error)
 }
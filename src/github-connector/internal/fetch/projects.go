@@ -0,0 +1,211 @@
+package fetch
+
+import (
+	"fmt"
+	"github-connector/internal/core"
+	"strings"
+	"time"
+)
+
+// maxProjectTimelineChecks limits how many issues and pull requests are checked for project
+// changes, since each check is a separate request
+const maxProjectTimelineChecks = 20
+
+// issueReference identifies an issue or pull request
+type issueReference struct {
+	repo   string
+	number int
+}
+
+// fetchProjectActivities fetches the project (v2) changes the user made to the issues and pull
+// requests of the given events. GitHub only exposes adding an item to a project, removing it and
+// changing its status, through the issue timeline; changes of other fields are not available.
+func (f *ActivityFetcher) fetchProjectActivities(events []map[string]any) []*Activity {
+	activities := []*Activity{}
+	since := f.config.startTime.Format(time.RFC3339)
+
+	targets := projectTimelineTargets(events)
+	if len(targets) > maxProjectTimelineChecks {
+		f.logger.Debug(fmt.Sprintf("Checking project changes of %d of %d issues and pull requests", maxProjectTimelineChecks, len(targets)))
+		targets = targets[:maxProjectTimelineChecks]
+	}
+
+	for _, target := range targets {
+		item, err := f.httpClient.FetchProjectTimeline(target.repo, target.number, since)
+		if err != nil {
+			f.logger.Warn(fmt.Sprintf("Skipping project changes of %s #%d: %s", target.repo, target.number, err.Error()))
+			continue
+		}
+
+		timeline, _ := item["timelineItems"].(map[string]any)
+		nodes, _ := timeline["nodes"].([]any)
+		for _, n := range nodes {
+			node, ok := n.(map[string]any)
+			if !ok {
+				continue
+			}
+			if !f.inDateRange(node, "createdAt") || !strings.EqualFold(getString(getMap(node, "actor"), "login"), f.config.username) {
+				continue
+			}
+			if automated, _ := node["wasAutomated"].(bool); automated {
+				continue
+			}
+			activity, err := transformProjectEvent(target, item, node)
+			if err != nil {
+				f.logger.Debug(fmt.Sprintf("Skipping project event: %s", err.Error()))
+				continue
+			}
+			activities = append(activities, activity)
+		}
+	}
+
+	f.logger.Info(fmt.Sprintf("Fetched %d project activities from %d issues and pull requests", len(activities), len(targets)))
+	return activities
+}
+
+// projectTimelineTargets returns the issues and pull requests the events refer to, in the order
+// they first appear
+func projectTimelineTargets(events []map[string]any) []issueReference {
+	targets := []issueReference{}
+	seen := map[issueReference]bool{}
+
+	for _, raw := range events {
+		e, err := decodeEvent(raw)
+		if err != nil {
+			continue
+		}
+		switch e.Type {
+		case "PullRequestEvent", "IssuesEvent", "IssueCommentEvent", "PullRequestReviewEvent", "PullRequestReviewCommentEvent":
+		default:
+			continue
+		}
+
+		var payload struct {
+			Number      int          `json:"number"`
+			Issue       *issue       `json:"issue"`
+			PullRequest *pullRequest `json:"pull_request"`
+		}
+		if err := e.decodePayload(&payload); err != nil {
+			continue
+		}
+		number := payload.Number
+		if payload.Issue != nil {
+			number = payload.Issue.Number
+		} else if number == 0 && payload.PullRequest != nil {
+			number = payload.PullRequest.Number
+		}
+		if number <= 0 {
+			continue
+		}
+
+		ref := issueReference{repo: e.Repo.Name, number: number}
+		if !seen[ref] {
+			seen[ref] = true
+			targets = append(targets, ref)
+		}
+	}
+
+	return targets
+}
+
+// transformProjectEvent transforms a project timeline event of an issue or pull request to an
+// Activity. item is the issue or pull request holding the timeline.
+func transformProjectEvent(target issueReference, item, node map[string]any) (*Activity, error) {
+	id := getString(node, "id")
+	if id == "" {
+		return nil, fmt.Errorf("missing id in project event")
+	}
+	timestamp, err := time.Parse(time.RFC3339, getString(node, "createdAt"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp format: %w", err)
+	}
+	timestamp = timestamp.UTC()
+
+	project := getMap(node, "project")
+	projectNumber := getInt(project, "number")
+	owner := getMap(project, "owner")
+	ownerLogin := getString(owner, "login")
+	if projectNumber <= 0 || ownerLogin == "" {
+		return nil, fmt.Errorf("invalid project in %s", getString(node, "__typename"))
+	}
+	ownerType := core.ProjectOwnerOrganization
+	if getString(owner, "__typename") == "User" {
+		ownerType = core.ProjectOwnerUser
+	}
+	projectTitle := getString(project, "title")
+
+	isPR := getString(item, "__typename") == "PullRequest"
+	itemRef := fmt.Sprintf("Issue #%d", target.number)
+	url := fmt.Sprintf("https://github.com/%s/issues/%d", target.repo, target.number)
+	if isPR {
+		itemRef = fmt.Sprintf("PR #%d", target.number)
+		url = fmt.Sprintf("https://github.com/%s/pull/%d", target.repo, target.number)
+	}
+
+	metadata := map[string]any{
+		"project_owner":  ownerLogin,
+		"project_number": projectNumber,
+		"project_title":  projectTitle,
+		"item_number":    target.number,
+		"item_type":      core.ResourceTypeIssue,
+	}
+	if isPR {
+		metadata["item_type"] = core.ResourceTypePullRequest
+	}
+
+	var activityType, title string
+	switch getString(node, "__typename") {
+	case "AddedToProjectV2Event":
+		activityType = "project_item_added"
+		title = fmt.Sprintf("Added %s to project %s", itemRef, projectTitle)
+	case "RemovedFromProjectV2Event":
+		activityType = "project_item_removed"
+		title = fmt.Sprintf("Removed %s from project %s", itemRef, projectTitle)
+	case "ProjectV2ItemStatusChangedEvent":
+		activityType = "project_status_changed"
+		status, previous := getString(node, "status"), getString(node, "previousStatus")
+		if previous == "" {
+			title = fmt.Sprintf("Set status of %s to %s in project %s", itemRef, status, projectTitle)
+		} else {
+			title = fmt.Sprintf("Moved %s from %s to %s in project %s", itemRef, previous, status, projectTitle)
+		}
+		metadata["status"] = status
+		metadata["previous_status"] = previous
+	default:
+		return nil, fmt.Errorf("unsupported project event type: %s", getString(node, "__typename"))
+	}
+	description := getString(item, "title")
+
+	gen := core.NewContextGenerator()
+	contexts := []*core.Context{
+		gen.CreateSourceContext(),
+		gen.CreateRepositoryContext(target.repo),
+	}
+	if isPR {
+		contexts = append(contexts, pullRequestContext(gen, target.repo, target.number, description))
+	} else {
+		contexts = append(contexts, gen.CreateIssueContext(target.repo, target.number))
+	}
+	projectContext := gen.CreateProjectContext(ownerType, ownerLogin, projectNumber)
+	if projectTitle != "" {
+		projectContext.Title = &projectTitle
+	}
+	contexts = append(contexts, projectContext)
+
+	return &Activity{
+		Id:           core.MakeActivityID("project_event:" + id),
+		Timestamp:    timestamp,
+		Title:        title,
+		Description:  description,
+		Source:       core.ConnectorID,
+		ActivityType: activityType,
+		Url:          &url,
+		Metadata:     metadata,
+		Contexts:     contexts,
+	}, nil
+}
+
+func getMap(m map[string]any, key string) map[string]any {
+	value, _ := m[key].(map[string]any)
+	return value
+}
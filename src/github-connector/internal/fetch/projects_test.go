@@ -0,0 +1,99 @@
+package fetch
+
+import (
+	"errors"
+	"github-connector/internal/core"
+	mock_fetch "github-connector/mock/fetch"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTransformProjectEvent(t *testing.T) {
+	item := loadJSONTestData(t, "../../testdata/projects/timeline.json")
+	nodes := item["timelineItems"].(map[string]any)["nodes"].([]any)
+	target := issueReference{repo: "testorg/testrepo", number: 340}
+
+	got, err := transformProjectEvent(target, item, nodes[1].(map[string]any))
+	assert.NoError(t, err)
+	assert.Equal(t, "github:project_event:PVTISC_lADOAbc", got.Id)
+	assert.Equal(t, "project_status_changed", got.ActivityType)
+	assert.Equal(t, "Moved Issue #340 from Todo to In Progress in project Platform Roadmap", got.Title)
+	assert.Equal(t, "Retry failed uploads", got.Description)
+	assert.Equal(t, "https://github.com/testorg/testrepo/issues/340", *got.Url)
+	assert.Equal(t, map[string]any{
+		"project_owner":   "testorg",
+		"project_number":  5,
+		"project_title":   "Platform Roadmap",
+		"item_number":     340,
+		"item_type":       "issue",
+		"status":          "In Progress",
+		"previous_status": "Todo",
+	}, got.Metadata)
+
+	ids := make([]string, 0, len(got.Contexts))
+	for _, c := range got.Contexts {
+		ids = append(ids, c.Id)
+	}
+	assert.Equal(t, []string{
+		"github:source",
+		"github:repository:testorg/testrepo",
+		"github:issue:testorg/testrepo:340",
+		"github:project:testorg:5",
+	}, ids)
+	assert.Equal(t, "Platform Roadmap", *got.Contexts[3].Title)
+
+	item["__typename"] = "PullRequest"
+	got, err = transformProjectEvent(target, item, nodes[0].(map[string]any))
+	assert.NoError(t, err)
+	assert.Equal(t, "project_item_added", got.ActivityType)
+	assert.Equal(t, "Added PR #340 to project Platform Roadmap", got.Title)
+	assert.Equal(t, "https://github.com/testorg/testrepo/pull/340", *got.Url)
+	assert.Equal(t, "github:pull_request:testorg/testrepo:340", got.Contexts[2].Id)
+
+	_, err = transformProjectEvent(target, item, map[string]any{"__typename": "AddedToProjectV2Event", "id": "x", "createdAt": "2025-11-18T09:30:00Z"})
+	assert.ErrorContains(t, err, "invalid project")
+}
+
+func TestFetchActivities_Projects(t *testing.T) {
+	issueEvent := loadJSONTestData(t, "../../testdata/events/issues.json")
+	issueEvent["created_at"] = "2025-11-18T09:00:00Z"
+	issueEvent["repo"].(map[string]any)["name"] = "testorg/testrepo"
+	issueEvent["payload"].(map[string]any)["issue"].(map[string]any)["number"] = float64(340)
+	commentEvent := loadJSONTestData(t, "../../testdata/events/issue_comment.json")
+	commentEvent["created_at"] = "2025-11-18T08:00:00Z"
+	commentEvent["repo"].(map[string]any)["name"] = "testorg/testrepo"
+	commentEvent["payload"].(map[string]any)["issue"].(map[string]any)["number"] = float64(340)
+	otherEvent := loadJSONTestData(t, "../../testdata/events/pull_request.json")
+	otherEvent["created_at"] = "2025-11-18T07:00:00Z"
+	otherEvent["repo"].(map[string]any)["name"] = "testorg/other"
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+	mockHTTP.EXPECT().FetchActivities("username", eventOptions("2025-11-18")).Return([]map[string]any{issueEvent, commentEvent, otherEvent}, nil).Times(1)
+	mockHTTP.EXPECT().FetchProjectTimeline("testorg/testrepo", 340, "2025-11-18T00:00:00Z").Return(loadJSONTestData(t, "../../testdata/projects/timeline.json"), nil).Times(1)
+	mockHTTP.EXPECT().FetchProjectTimeline("testorg/other", gomock.Any(), "2025-11-18T00:00:00Z").Return(nil, errors.New("Could not resolve to a Repository")).Times(1)
+
+	cfg := map[string]any{
+		"username":                "username",
+		"include_project_changes": true,
+		"excluded_activity_types": []any{"issues", "issue_comment", "pull_request"},
+	}
+	fetcher, err := NewActivityFetcher(mockHTTP, cfg, "2025-11-18", core.NewNoopLogger())
+	if err != nil {
+		t.Fatalf("Failed to create ActivityFetcher: %v", err)
+	}
+
+	got, err := fetcher.FetchActivities()
+	assert.NoError(t, err)
+
+	ids := make([]string, 0, len(got))
+	for _, activity := range got {
+		ids = append(ids, activity.Id)
+	}
+	// Automated changes and changes by other users are skipped
+	assert.Equal(t, []string{"github:project_event:PVTISC_lADOAbc", "github:project_event:PVTAE_lADOAbc"}, ids)
+}
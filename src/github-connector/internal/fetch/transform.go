@@ -3,6 +3,7 @@ package fetch
 import (
 	"fmt"
 	"github-connector/internal/core"
+	"strings"
 	"time"
 )
 
@@ -18,64 +19,57 @@ type Activity struct {
 	Url          *string
 }
 
-// transformEvent transforms a GitHub event to an Activity
-func transformEvent(event map[string]any) (*Activity, error) {
-	eventType, ok := event["type"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing event type")
+// transformEvent transforms a GitHub event to an Activity. Malformed events are reported as
+// errors so that the caller can skip them.
+func transformEvent(raw map[string]any) (*Activity, error) {
+	e, err := decodeEvent(raw)
+	if err != nil {
+		return nil, err
 	}
 
-	switch eventType {
+	switch e.Type {
 	case "PushEvent":
-		return transformPushEvent(event)
+		return transformPushEvent(e)
 	case "PullRequestEvent":
-		return transformPullRequestEvent(event)
+		return transformPullRequestEvent(e)
 	case "IssuesEvent":
-		return transformIssuesEvent(event)
+		return transformIssuesEvent(e)
 	case "IssueCommentEvent":
-		return transformIssueCommentEvent(event)
+		return transformIssueCommentEvent(e)
 	case "DeleteEvent":
-		return transformDeleteEvent(event)
+		return transformDeleteEvent(e)
 	case "PullRequestReviewCommentEvent":
-		return transformPRReviewCommentEvent(event)
+		return transformPRReviewCommentEvent(e)
 	case "PullRequestReviewEvent":
-		return transformPRReviewEvent(event)
+		return transformPRReviewEvent(e)
+	case "ReleaseEvent":
+		return transformReleaseEvent(e)
 	default:
-		return nil, fmt.Errorf("unsupported event type: %s", eventType)
+		return nil, fmt.Errorf("unsupported event type: %s", e.Type)
 	}
 }
 
 // transformPushEvent transforms a PushEvent to an Activity
-func transformPushEvent(event map[string]any) (*Activity, error) {
-	payload, ok := event["payload"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid payload in PushEvent")
+func transformPushEvent(e *event) (*Activity, error) {
+	var payload pushPayload
+	if err := e.decodePayload(&payload); err != nil {
+		return nil, err
 	}
 
-	repo, ok := event["repo"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid repo in PushEvent")
+	timestamp, err := e.timestamp()
+	if err != nil {
+		return nil, err
 	}
 
-	id := core.MakeActivityID(fmt.Sprintf("%v", event["id"]))
-	timestampStr, _ := event["created_at"].(string)
-	repoName, _ := repo["name"].(string)
-	ref, _ := payload["ref"].(string)
-	head, _ := payload["head"].(string)
-
+	repoName := e.Repo.Name
 	title := fmt.Sprintf("Push to %s", repoName)
-	description := fmt.Sprintf("Pushed to %s in %s", ref, repoName)
-	url := fmt.Sprintf("https://github.com/%s/commit/%s", repoName, head)
-	timestamp, err := time.Parse(time.RFC3339, timestampStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid timestamp format: %w", err)
-	}
-	timestamp = timestamp.UTC()
+	description := fmt.Sprintf("Pushed to %s in %s", payload.Ref, repoName)
+	url := fmt.Sprintf("https://github.com/%s/commit/%s", repoName, payload.Head)
 
 	// Metadata
 	metadata := map[string]any{
-		"branch":        ref,
-		"before_commit": payload["before"],
+		"branch":        payload.Ref,
+		"before_commit": payload.Before,
 	}
 
 	// Use ContextGenerator to create hierarchical contexts
@@ -84,9 +78,16 @@ func transformPushEvent(event map[string]any) (*Activity, error) {
 		gen.CreateSourceContext(),
 		gen.CreateRepositoryContext(repoName),
 	}
+	// Tag pushes have no branch
+	if branch, ok := strings.CutPrefix(payload.Ref, "refs/heads/"); ok {
+		contexts = append(contexts, gen.CreateBranchContext(repoName, branch))
+	}
+	if payload.Head != "" {
+		contexts = append(contexts, gen.CreateCommitContext(repoName, payload.Head))
+	}
 
 	return &Activity{
-		Id:           id,
+		Id:           core.MakeActivityID(e.ID),
 		Timestamp:    timestamp,
 		Title:        title,
 		Description:  description,
@@ -99,62 +100,74 @@ func transformPushEvent(event map[string]any) (*Activity, error) {
 }
 
 // transformPullRequestEvent transforms a PullRequestEvent to an Activity
-func transformPullRequestEvent(event map[string]any) (*Activity, error) {
-	payload, ok := event["payload"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid payload in PullRequestEvent")
+func transformPullRequestEvent(e *event) (*Activity, error) {
+	var payload pullRequestPayload
+	if err := e.decodePayload(&payload); err != nil {
+		return nil, err
 	}
-
-	repo, ok := event["repo"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid repo in PullRequestEvent")
+	pr := payload.PullRequest
+	if pr == nil {
+		return nil, fmt.Errorf("invalid pull_request in PullRequestEvent")
+	}
+	if payload.Number <= 0 {
+		return nil, fmt.Errorf("invalid number in PullRequestEvent")
 	}
 
-	pr, ok := payload["pull_request"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid pull_request in PullRequestEvent")
+	timestamp, err := e.timestamp()
+	if err != nil {
+		return nil, err
 	}
 
-	id := core.MakeActivityID(fmt.Sprintf("%v", event["id"]))
-	timestampStr, _ := event["created_at"].(string)
-	repoName, _ := repo["name"].(string)
-	prNumber := int(payload["number"].(float64))
-	action, _ := payload["action"].(string)
+	repoName := e.Repo.Name
+	prNumber := payload.Number
+	prTitle := pr.title()
+	action := payload.Action
+	// Older payloads report merges as closed with merged set
+	if action == "closed" && pr.Merged {
+		action = "merged"
+	}
 
-	title := fmt.Sprintf("PR #%d %s in %s", prNumber, action, repoName)
-	description := fmt.Sprintf("Pull request #%d was %s", prNumber, action)
+	activityType, title, description := describePullRequestAction(action, &payload, prNumber, prTitle, repoName)
 	url := fmt.Sprintf("https://github.com/%s/pull/%d", repoName, prNumber)
-	timestamp, err := time.Parse(time.RFC3339, timestampStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid timestamp format: %w", err)
-	}
-	timestamp = timestamp.UTC()
 
-	base, _ := pr["base"].(map[string]any)
-	head, _ := pr["head"].(map[string]any)
 	metadata := map[string]any{
 		"pr_number":   prNumber,
+		"pr_title":    valueOrNil(pr.Title),
 		"action":      action,
-		"base_branch": base["ref"],
-		"head_branch": head["ref"],
-		"base_sha":    base["sha"],
-		"head_sha":    head["sha"],
+		"merged":      action == "merged",
+		"draft":       valueOrNil(pr.Draft),
+		"base_branch": pr.Base.Ref,
+		"head_branch": pr.Head.Ref,
+		"base_sha":    pr.Base.SHA,
+		"head_sha":    pr.Head.SHA,
+	}
+	switch action {
+	case "review_requested":
+		metadata["requested_reviewer"] = payload.requestedReviewer()
+	case "assigned":
+		metadata["assignee"] = payload.assignee()
+	case "labeled":
+		metadata["label"] = payload.labelName()
 	}
 
 	gen := core.NewContextGenerator()
 	contexts := []*core.Context{
 		gen.CreateSourceContext(),
 		gen.CreateRepositoryContext(repoName),
-		gen.CreatePRContext(repoName, prNumber),
+		pullRequestContext(gen, repoName, prNumber, prTitle),
+	}
+	contexts = append(contexts, branchContexts(gen, repoName, pr)...)
+	if pr.Head.SHA != "" {
+		contexts = append(contexts, gen.CreateCommitContext(repoName, pr.Head.SHA))
 	}
 
 	return &Activity{
-		Id:           id,
+		Id:           core.MakeActivityID(e.ID),
 		Timestamp:    timestamp,
 		Title:        title,
 		Description:  description,
 		Source:       core.ConnectorID,
-		ActivityType: "pull_request",
+		ActivityType: activityType,
 		Url:          &url,
 		Metadata:     metadata,
 		Contexts:     contexts,
@@ -162,46 +175,35 @@ func transformPullRequestEvent(event map[string]any) (*Activity, error) {
 }
 
 // transformIssuesEvent transforms an IssuesEvent to an Activity
-func transformIssuesEvent(event map[string]any) (*Activity, error) {
-	payload, ok := event["payload"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid payload in IssuesEvent")
+func transformIssuesEvent(e *event) (*Activity, error) {
+	var payload issuesPayload
+	if err := e.decodePayload(&payload); err != nil {
+		return nil, err
 	}
-
-	repo, ok := event["repo"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid repo in IssuesEvent")
+	issue := payload.Issue
+	if issue == nil || issue.Number <= 0 {
+		return nil, fmt.Errorf("invalid issue in IssuesEvent")
 	}
 
-	issue, ok := payload["issue"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid issue in IssuesEvent")
+	timestamp, err := e.timestamp()
+	if err != nil {
+		return nil, err
 	}
 
-	id := core.MakeActivityID(fmt.Sprintf("%v", event["id"]))
-	timestampStr, _ := event["created_at"].(string)
-	repoName, _ := repo["name"].(string)
-	issueNumber := int(issue["number"].(float64))
-	action, _ := payload["action"].(string)
+	repoName := e.Repo.Name
+	issueNumber := issue.Number
+	action := payload.Action
 
 	title := fmt.Sprintf("Issue #%d %s in %s", issueNumber, action, repoName)
 	description := fmt.Sprintf("Issue #%d was %s", issueNumber, action)
-	url, _ := issue["html_url"].(string)
-	timestamp, err := time.Parse(time.RFC3339, timestampStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid timestamp format: %w", err)
-	}
-	timestamp = timestamp.UTC()
-
-	user, _ := issue["user"].(map[string]any)
-	labels := extractLabels(issue["labels"])
+	url := issue.HTMLURL
 
 	metadata := map[string]any{
 		"issue_number": issueNumber,
 		"action":       action,
-		"state":        issue["state"],
-		"author":       user["login"],
-		"labels":       labels,
+		"state":        issue.State,
+		"author":       issue.User.Login,
+		"labels":       labelNames(issue.Labels),
 	}
 
 	gen := core.NewContextGenerator()
@@ -212,7 +214,7 @@ func transformIssuesEvent(event map[string]any) (*Activity, error) {
 	}
 
 	return &Activity{
-		Id:           id,
+		Id:           core.MakeActivityID(e.ID),
 		Timestamp:    timestamp,
 		Title:        title,
 		Description:  description,
@@ -226,64 +228,57 @@ func transformIssuesEvent(event map[string]any) (*Activity, error) {
 
 // transformIssueCommentEvent transforms an IssueCommentEvent to an Activity
 // Distinguishes between PR comments and issue comments
-func transformIssueCommentEvent(event map[string]any) (*Activity, error) {
-	payload, ok := event["payload"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid payload in IssueCommentEvent")
+func transformIssueCommentEvent(e *event) (*Activity, error) {
+	var payload issueCommentPayload
+	if err := e.decodePayload(&payload); err != nil {
+		return nil, err
 	}
-
-	issue, ok := payload["issue"].(map[string]any)
-	if !ok {
+	if payload.Issue == nil || payload.Issue.Number <= 0 {
 		return nil, fmt.Errorf("invalid issue in IssueCommentEvent")
 	}
+	if payload.Comment == nil {
+		return nil, fmt.Errorf("invalid comment in IssueCommentEvent")
+	}
 
 	// Check if this is a PR comment or issue comment
-	_, isPR := issue["pull_request"]
-
-	if isPR {
-		return transformPRCommentEvent(event)
+	if payload.Issue.isPullRequest() {
+		return transformPRCommentEvent(e, &payload)
 	}
-	return transformIssueCommentOnlyEvent(event)
+	return transformIssueCommentOnlyEvent(e, &payload)
 }
 
 // transformPRCommentEvent transforms a PR comment (IssueCommentEvent on PR)
-func transformPRCommentEvent(event map[string]any) (*Activity, error) {
-	payload, _ := event["payload"].(map[string]any)
-	repo, _ := event["repo"].(map[string]any)
-	issue, _ := payload["issue"].(map[string]any)
-	comment, _ := payload["comment"].(map[string]any)
-
-	id := core.MakeActivityID(fmt.Sprintf("%v", event["id"]))
-	timestampStr, _ := event["created_at"].(string)
-	repoName, _ := repo["name"].(string)
-	prNumber := int(issue["number"].(float64))
+func transformPRCommentEvent(e *event, payload *issueCommentPayload) (*Activity, error) {
+	issue, comment := payload.Issue, payload.Comment
 
-	title := fmt.Sprintf("Commented on PR #%d", prNumber)
-	description, _ := comment["body"].(string)
-	url, _ := comment["html_url"].(string)
-	timestamp, err := time.Parse(time.RFC3339, timestampStr)
+	timestamp, err := e.timestamp()
 	if err != nil {
-		return nil, fmt.Errorf("invalid timestamp format: %w", err)
+		return nil, err
 	}
-	timestamp = timestamp.UTC()
 
-	commentUser, _ := comment["user"].(map[string]any)
+	repoName := e.Repo.Name
+	prNumber := issue.Number
+
+	title := fmt.Sprintf("Commented on PR #%d", prNumber)
+	description := comment.Body
+	url := comment.HTMLURL
+
 	metadata := map[string]any{
-		"comment_id":         comment["id"],
+		"comment_id":         comment.ID,
 		"issue_number":       prNumber,
-		"comment_author":     commentUser["login"],
-		"comment_created_at": comment["created_at"],
+		"comment_author":     comment.User.Login,
+		"comment_created_at": comment.CreatedAt,
 	}
 
 	gen := core.NewContextGenerator()
 	contexts := []*core.Context{
 		gen.CreateSourceContext(),
 		gen.CreateRepositoryContext(repoName),
-		gen.CreatePRContext(repoName, prNumber),
+		pullRequestContext(gen, repoName, prNumber, issue.Title),
 	}
 
 	return &Activity{
-		Id:           id,
+		Id:           core.MakeActivityID(e.ID),
 		Timestamp:    timestamp,
 		Title:        title,
 		Description:  description,
@@ -296,32 +291,26 @@ func transformPRCommentEvent(event map[string]any) (*Activity, error) {
 }
 
 // transformIssueCommentOnlyEvent transforms an issue comment (IssueCommentEvent on Issue)
-func transformIssueCommentOnlyEvent(event map[string]any) (*Activity, error) {
-	payload, _ := event["payload"].(map[string]any)
-	repo, _ := event["repo"].(map[string]any)
-	issue, _ := payload["issue"].(map[string]any)
-	comment, _ := payload["comment"].(map[string]any)
-
-	id := core.MakeActivityID(fmt.Sprintf("%v", event["id"]))
-	timestampStr, _ := event["created_at"].(string)
-	repoName, _ := repo["name"].(string)
-	issueNumber := int(issue["number"].(float64))
+func transformIssueCommentOnlyEvent(e *event, payload *issueCommentPayload) (*Activity, error) {
+	issue, comment := payload.Issue, payload.Comment
 
-	title := fmt.Sprintf("Commented on Issue #%d", issueNumber)
-	description, _ := comment["body"].(string)
-	url, _ := comment["html_url"].(string)
-	timestamp, err := time.Parse(time.RFC3339, timestampStr)
+	timestamp, err := e.timestamp()
 	if err != nil {
-		return nil, fmt.Errorf("invalid timestamp format: %w", err)
+		return nil, err
 	}
-	timestamp = timestamp.UTC()
 
-	commentUser, _ := comment["user"].(map[string]any)
+	repoName := e.Repo.Name
+	issueNumber := issue.Number
+
+	title := fmt.Sprintf("Commented on Issue #%d", issueNumber)
+	description := comment.Body
+	url := comment.HTMLURL
+
 	metadata := map[string]any{
-		"comment_id":         comment["id"],
+		"comment_id":         comment.ID,
 		"issue_number":       issueNumber,
-		"comment_author":     commentUser["login"],
-		"comment_created_at": comment["created_at"],
+		"comment_author":     comment.User.Login,
+		"comment_created_at": comment.CreatedAt,
 	}
 
 	gen := core.NewContextGenerator()
@@ -332,7 +321,7 @@ func transformIssueCommentOnlyEvent(event map[string]any) (*Activity, error) {
 	}
 
 	return &Activity{
-		Id:           id,
+		Id:           core.MakeActivityID(e.ID),
 		Timestamp:    timestamp,
 		Title:        title,
 		Description:  description,
@@ -345,39 +334,29 @@ func transformIssueCommentOnlyEvent(event map[string]any) (*Activity, error) {
 }
 
 // transformDeleteEvent transforms a DeleteEvent to an Activity
-func transformDeleteEvent(event map[string]any) (*Activity, error) {
-	payload, ok := event["payload"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid payload in DeleteEvent")
+func transformDeleteEvent(e *event) (*Activity, error) {
+	var payload deletePayload
+	if err := e.decodePayload(&payload); err != nil {
+		return nil, err
 	}
 
-	repo, ok := event["repo"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid repo in DeleteEvent")
+	timestamp, err := e.timestamp()
+	if err != nil {
+		return nil, err
 	}
 
-	actor, _ := event["actor"].(map[string]any)
-
-	id := core.MakeActivityID(fmt.Sprintf("%v", event["id"]))
-	timestampStr, _ := event["created_at"].(string)
-	repoName, _ := repo["name"].(string)
-	refType, _ := payload["ref_type"].(string)
-	ref, _ := payload["ref"].(string)
+	repoName := e.Repo.Name
+	refType, ref := payload.RefType, payload.Ref
 
 	title := fmt.Sprintf("Deleted %s %s in %s", refType, ref, repoName)
 	description := fmt.Sprintf("%s %s was deleted", refType, ref)
 	url := fmt.Sprintf("https://github.com/%s", repoName)
-	timestamp, err := time.Parse(time.RFC3339, timestampStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid timestamp format: %w", err)
-	}
-	timestamp = timestamp.UTC()
 
 	metadata := map[string]any{
 		"ref_type":    refType,
 		"ref":         ref,
-		"deleted_by":  actor["login"],
-		"pusher_type": payload["pusher_type"],
+		"deleted_by":  e.Actor.Login,
+		"pusher_type": payload.PusherType,
 	}
 
 	gen := core.NewContextGenerator()
@@ -385,9 +364,12 @@ func transformDeleteEvent(event map[string]any) (*Activity, error) {
 		gen.CreateSourceContext(),
 		gen.CreateRepositoryContext(repoName),
 	}
+	if refType == "branch" && ref != "" {
+		contexts = append(contexts, gen.CreateBranchContext(repoName, ref))
+	}
 
 	return &Activity{
-		Id:           id,
+		Id:           core.MakeActivityID(e.ID),
 		Timestamp:    timestamp,
 		Title:        title,
 		Description:  description,
@@ -399,65 +381,129 @@ func transformDeleteEvent(event map[string]any) (*Activity, error) {
 	}, nil
 }
 
-// transformPRReviewCommentEvent transforms a PullRequestReviewCommentEvent to an Activity
-func transformPRReviewCommentEvent(event map[string]any) (*Activity, error) {
-	payload, ok := event["payload"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid payload in PullRequestReviewCommentEvent")
+// transformReleaseEvent transforms a ReleaseEvent to an Activity
+func transformReleaseEvent(e *event) (*Activity, error) {
+	var payload releasePayload
+	if err := e.decodePayload(&payload); err != nil {
+		return nil, err
+	}
+	rel := payload.Release
+	if rel == nil || rel.TagName == "" {
+		return nil, fmt.Errorf("invalid release in ReleaseEvent")
 	}
 
-	repo, ok := event["repo"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid repo in PullRequestReviewCommentEvent")
+	timestamp, err := e.timestamp()
+	if err != nil {
+		return nil, err
 	}
 
-	pr, ok := payload["pull_request"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid pull_request in PullRequestReviewCommentEvent")
+	repoName := e.Repo.Name
+	name := rel.Name
+	if name == "" {
+		name = rel.TagName
 	}
 
-	comment, ok := payload["comment"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid comment in PullRequestReviewCommentEvent")
+	title := fmt.Sprintf("Release %s %s in %s", name, payload.Action, repoName)
+	description := rel.Body
+	url := rel.HTMLURL
+
+	metadata := map[string]any{
+		"release_id":   rel.ID,
+		"action":       payload.Action,
+		"tag_name":     rel.TagName,
+		"release_name": rel.Name,
+		"draft":        rel.Draft,
+		"prerelease":   rel.Prerelease,
 	}
 
-	id := core.MakeActivityID(fmt.Sprintf("%v", event["id"]))
-	timestampStr, _ := event["created_at"].(string)
-	repoName, _ := repo["name"].(string)
-	prNumber := int(pr["number"].(float64))
+	gen := core.NewContextGenerator()
+	releaseContext := gen.CreateReleaseContext(repoName, rel.TagName)
+	releaseContext.Title = &name
+	contexts := []*core.Context{
+		gen.CreateSourceContext(),
+		gen.CreateRepositoryContext(repoName),
+		releaseContext,
+	}
 
-	title := fmt.Sprintf("Commented on PR #%d in %s", prNumber, repoName)
-	description, _ := comment["body"].(string)
-	url, _ := comment["html_url"].(string)
-	timestamp, err := time.Parse(time.RFC3339, timestampStr)
+	return &Activity{
+		Id:           core.MakeActivityID(e.ID),
+		Timestamp:    timestamp,
+		Title:        title,
+		Description:  description,
+		Source:       core.ConnectorID,
+		ActivityType: "release",
+		Url:          &url,
+		Metadata:     metadata,
+		Contexts:     contexts,
+	}, nil
+}
+
+// transformPRReviewCommentEvent transforms a PullRequestReviewCommentEvent to an Activity
+func transformPRReviewCommentEvent(e *event) (*Activity, error) {
+	var payload prReviewCommentPayload
+	if err := e.decodePayload(&payload); err != nil {
+		return nil, err
+	}
+	pr, comment := payload.PullRequest, payload.Comment
+	if pr == nil || pr.Number <= 0 {
+		return nil, fmt.Errorf("invalid pull_request in PullRequestReviewCommentEvent")
+	}
+	if comment == nil {
+		return nil, fmt.Errorf("invalid comment in PullRequestReviewCommentEvent")
+	}
+
+	timestamp, err := e.timestamp()
 	if err != nil {
-		return nil, fmt.Errorf("invalid timestamp format: %w", err)
+		return nil, err
 	}
-	timestamp = timestamp.UTC()
 
-	commentUser, _ := comment["user"].(map[string]any)
-	prBase, _ := pr["base"].(map[string]any)
-	prHead, _ := pr["head"].(map[string]any)
+	repoName := e.Repo.Name
+	prNumber := pr.Number
+
+	title := fmt.Sprintf("Commented on PR #%d in %s", prNumber, repoName)
+	description := comment.Body
+	url := comment.HTMLURL
+
+	// Replies point at the thread's root comment; a root comment starts its own thread
+	threadID := comment.ID
+	if comment.InReplyToID != nil {
+		threadID = *comment.InReplyToID
+	}
 
 	metadata := map[string]any{
-		"comment_id":     comment["id"],
+		"comment_id":     comment.ID,
+		"review_id":      valueOrNil(comment.PullRequestReviewID),
+		"thread_id":      threadID,
+		"in_reply_to_id": valueOrNil(comment.InReplyToID),
 		"pr_number":      prNumber,
-		"comment_author": commentUser["login"],
-		"file_path":      comment["path"],
-		"commit_id":      comment["commit_id"],
-		"base_branch":    prBase["ref"],
-		"head_branch":    prHead["ref"],
+		"comment_author": comment.User.Login,
+		"file_path":      comment.Path,
+		"commit_id":      comment.CommitID,
+		"base_branch":    pr.Base.Ref,
+		"head_branch":    pr.Head.Ref,
 	}
 
 	gen := core.NewContextGenerator()
 	contexts := []*core.Context{
 		gen.CreateSourceContext(),
 		gen.CreateRepositoryContext(repoName),
-		gen.CreatePRContext(repoName, prNumber),
+		pullRequestContext(gen, repoName, prNumber, pr.title()),
+	}
+	contexts = append(contexts, branchContexts(gen, repoName, pr)...)
+	if comment.CommitID != "" {
+		contexts = append(contexts, gen.CreateCommitContext(repoName, comment.CommitID))
+	}
+	if threadID != 0 {
+		thread := gen.CreateReviewThreadContext(repoName, prNumber, threadID)
+		if comment.Path != "" {
+			threadTitle := fmt.Sprintf("Review thread on %s", comment.Path)
+			thread.Title = &threadTitle
+		}
+		contexts = append(contexts, thread)
 	}
 
 	return &Activity{
-		Id:           id,
+		Id:           core.MakeActivityID(e.ID),
 		Timestamp:    timestamp,
 		Title:        title,
 		Description:  description,
@@ -470,63 +516,51 @@ func transformPRReviewCommentEvent(event map[string]any) (*Activity, error) {
 }
 
 // transformPRReviewEvent transforms a PullRequestReviewEvent to an Activity
-func transformPRReviewEvent(event map[string]any) (*Activity, error) {
-	payload, ok := event["payload"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid payload in PullRequestReviewEvent")
-	}
-
-	repo, ok := event["repo"].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid repo in PullRequestReviewEvent")
+func transformPRReviewEvent(e *event) (*Activity, error) {
+	var payload prReviewPayload
+	if err := e.decodePayload(&payload); err != nil {
+		return nil, err
 	}
-
-	pr, ok := payload["pull_request"].(map[string]any)
-	if !ok {
+	pr, review := payload.PullRequest, payload.Review
+	if pr == nil || pr.Number <= 0 {
 		return nil, fmt.Errorf("invalid pull_request in PullRequestReviewEvent")
 	}
-
-	review, ok := payload["review"].(map[string]any)
-	if !ok {
+	if review == nil {
 		return nil, fmt.Errorf("invalid review in PullRequestReviewEvent")
 	}
 
-	id := core.MakeActivityID(fmt.Sprintf("%v", event["id"]))
-	timestampStr, _ := event["created_at"].(string)
-	repoName, _ := repo["name"].(string)
-	prNumber := int(pr["number"].(float64))
-
-	title := fmt.Sprintf("Reviewed PR #%d in %s", prNumber, repoName)
-	description, _ := review["body"].(string)
-	url, _ := review["html_url"].(string)
-	timestamp, err := time.Parse(time.RFC3339, timestampStr)
+	timestamp, err := e.timestamp()
 	if err != nil {
-		return nil, fmt.Errorf("invalid timestamp format: %w", err)
+		return nil, err
 	}
-	timestamp = timestamp.UTC()
 
-	reviewer, _ := review["user"].(map[string]any)
-	prBase, _ := pr["base"].(map[string]any)
-	prHead, _ := pr["head"].(map[string]any)
+	repoName := e.Repo.Name
+	prNumber := pr.Number
+	prTitle := pr.title()
+
+	title := describeReviewState(review.State, formatPRReference(prNumber, prTitle), repoName)
+	description := review.Body
+	url := review.HTMLURL
 
 	metadata := map[string]any{
 		"pr_number":    prNumber,
-		"review_state": review["state"],
-		"reviewer":     reviewer["login"],
-		"submitted_at": review["submitted_at"],
-		"base_branch":  prBase["ref"],
-		"head_branch":  prHead["ref"],
+		"review_state": review.State,
+		"reviewer":     review.User.Login,
+		"submitted_at": review.SubmittedAt,
+		"base_branch":  pr.Base.Ref,
+		"head_branch":  pr.Head.Ref,
 	}
 
 	gen := core.NewContextGenerator()
 	contexts := []*core.Context{
 		gen.CreateSourceContext(),
 		gen.CreateRepositoryContext(repoName),
-		gen.CreatePRContext(repoName, prNumber),
+		pullRequestContext(gen, repoName, prNumber, prTitle),
 	}
+	contexts = append(contexts, branchContexts(gen, repoName, pr)...)
 
 	return &Activity{
-		Id:           id,
+		Id:           core.MakeActivityID(e.ID),
 		Timestamp:    timestamp,
 		Title:        title,
 		Description:  description,
@@ -538,17 +572,132 @@ func transformPRReviewEvent(event map[string]any) (*Activity, error) {
 	}, nil
 }
 
-// extractLabels extracts label names from issue labels array
-func extractLabels(labelsInterface any) []string {
-	labels := []string{}
-	if labelsList, ok := labelsInterface.([]any); ok {
-		for _, labelItem := range labelsList {
-			if label, ok := labelItem.(map[string]any); ok {
-				if name, ok := label["name"].(string); ok {
-					labels = append(labels, name)
-				}
-			}
+// describePullRequestAction returns the activity type, title and description of a
+// PullRequestEvent action. Actions without a dedicated type are reported as "pull_request".
+func describePullRequestAction(action string, payload *pullRequestPayload, prNumber int, prTitle, repoName string) (string, string, string) {
+	prRef := formatPRReference(prNumber, prTitle)
+	switch action {
+	case "opened":
+		if draft := payload.PullRequest.Draft; draft != nil && *draft {
+			return "pull_request", fmt.Sprintf("Opened draft %s in %s", prRef, repoName), fmt.Sprintf("Pull request #%d was opened as a draft", prNumber)
+		}
+		return "pull_request", fmt.Sprintf("Opened %s in %s", prRef, repoName), fmt.Sprintf("Pull request #%d was opened", prNumber)
+	case "merged":
+		return "pull_request", fmt.Sprintf("Merged %s in %s", prRef, repoName), fmt.Sprintf("Pull request #%d was merged", prNumber)
+	case "closed":
+		return "pull_request", fmt.Sprintf("Closed %s in %s", prRef, repoName), fmt.Sprintf("Pull request #%d was closed without merging", prNumber)
+	case "reopened":
+		return "pull_request", fmt.Sprintf("Reopened %s in %s", prRef, repoName), fmt.Sprintf("Pull request #%d was reopened", prNumber)
+	case "converted_to_draft":
+		return "pr_converted_to_draft", fmt.Sprintf("Converted %s to draft in %s", prRef, repoName), fmt.Sprintf("Pull request #%d was converted to draft", prNumber)
+	case "ready_for_review":
+		return "pr_ready_for_review", fmt.Sprintf("Marked %s ready for review in %s", prRef, repoName), fmt.Sprintf("Pull request #%d was marked ready for review", prNumber)
+	case "review_requested":
+		reviewer := payload.requestedReviewer()
+		return "pr_review_requested", fmt.Sprintf("Requested review from %s on %s in %s", reviewer, prRef, repoName), fmt.Sprintf("Review of pull request #%d was requested from %s", prNumber, reviewer)
+	case "assigned":
+		assignee := payload.assignee()
+		return "pr_assigned", fmt.Sprintf("Assigned %s to %s in %s", assignee, prRef, repoName), fmt.Sprintf("Pull request #%d was assigned to %s", prNumber, assignee)
+	case "labeled":
+		name := payload.labelName()
+		return "pr_labeled", fmt.Sprintf("Labeled %s with %s in %s", prRef, name, repoName), fmt.Sprintf("Pull request #%d was labeled %s", prNumber, name)
+	default:
+		return "pull_request", fmt.Sprintf("%s %s in %s", prRef, action, repoName), fmt.Sprintf("Pull request #%d was %s", prNumber, action)
+	}
+}
+
+// describeReviewState returns the activity title of a pull request review in the given state
+func describeReviewState(state, prRef, repoName string) string {
+	switch strings.ToLower(state) {
+	case "approved":
+		return fmt.Sprintf("Approved %s in %s", prRef, repoName)
+	case "changes_requested":
+		return fmt.Sprintf("Requested changes on %s in %s", prRef, repoName)
+	case "commented":
+		return fmt.Sprintf("Reviewed %s with comments in %s", prRef, repoName)
+	default:
+		return fmt.Sprintf("Reviewed %s in %s", prRef, repoName)
+	}
+}
+
+// formatPRReference formats a pull request as "PR #N: title", or "PR #N" when the payload
+// does not include the title
+func formatPRReference(prNumber int, prTitle string) string {
+	if prTitle == "" {
+		return fmt.Sprintf("PR #%d", prNumber)
+	}
+	return fmt.Sprintf("PR #%d: %s", prNumber, prTitle)
+}
+
+// branchContexts returns the contexts of the base and head branches of a pull request. The head
+// branch of a pull request from a fork belongs to the fork.
+func branchContexts(gen *core.ContextGenerator, repoName string, pr *pullRequest) []*core.Context {
+	contexts := []*core.Context{}
+	if pr.Base.Ref != "" {
+		contexts = append(contexts, gen.CreateBranchContext(repoName, pr.Base.Ref))
+	}
+	headRepo := repoName
+	if name := pr.Head.Repo.fullName(); name != "" {
+		headRepo = name
+	}
+	if pr.Head.Ref != "" && (pr.Head.Ref != pr.Base.Ref || headRepo != repoName) {
+		contexts = append(contexts, gen.CreateBranchContext(headRepo, pr.Head.Ref))
+	}
+	return contexts
+}
+
+// pullRequestContext creates a PR context titled with the PR title when it is known
+func pullRequestContext(gen *core.ContextGenerator, repoName string, prNumber int, prTitle string) *core.Context {
+	ctx := gen.CreatePRContext(repoName, prNumber)
+	if prTitle != "" {
+		ctx.Title = &prTitle
+	}
+	return ctx
+}
+
+// title returns the pull request title, or "" when the payload does not include it
+func (pr *pullRequest) title() string {
+	if pr.Title == nil {
+		return ""
+	}
+	return *pr.Title
+}
+
+// requestedReviewer returns the login of the requested reviewer, or the team name when a team
+// was requested
+func (p *pullRequestPayload) requestedReviewer() string {
+	if p.RequestedReviewer != nil && p.RequestedReviewer.Login != "" {
+		return p.RequestedReviewer.Login
+	}
+	if p.RequestedTeam != nil {
+		return p.RequestedTeam.Name
+	}
+	return ""
+}
+
+// assignee returns the login of the assigned user
+func (p *pullRequestPayload) assignee() string {
+	if p.Assignee == nil {
+		return ""
+	}
+	return p.Assignee.Login
+}
+
+// labelName returns the name of the added label
+func (p *pullRequestPayload) labelName() string {
+	if p.Label == nil {
+		return ""
+	}
+	return p.Label.Name
+}
+
+// labelNames extracts label names from issue labels
+func labelNames(labels []label) []string {
+	names := []string{}
+	for _, l := range labels {
+		if l.Name != "" {
+			names = append(names, l.Name)
 		}
 	}
-	return labels
+	return names
 }
@@ -0,0 +1,358 @@
+package fetch
+
+import (
+	"encoding/json"
+	"github-connector/internal/core"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformPullRequestEvent_Actions(t *testing.T) {
+	tests := []struct {
+		name             string
+		action           string
+		pr               map[string]any
+		payload          map[string]any
+		wantType         string
+		wantTitle        string
+		wantMetadataKeys map[string]any
+	}{
+		{
+			name:      "merged",
+			action:    "merged",
+			wantType:  "pull_request",
+			wantTitle: "Merged PR #10286: Add retry to uploads in testorg/testrepo",
+		},
+		{
+			name:             "closed with merged flag",
+			action:           "closed",
+			pr:               map[string]any{"merged": true},
+			wantType:         "pull_request",
+			wantTitle:        "Merged PR #10286: Add retry to uploads in testorg/testrepo",
+			wantMetadataKeys: map[string]any{"action": "merged", "merged": true},
+		},
+		{
+			name:             "closed without merging",
+			action:           "closed",
+			pr:               map[string]any{"merged": false},
+			wantType:         "pull_request",
+			wantTitle:        "Closed PR #10286: Add retry to uploads in testorg/testrepo",
+			wantMetadataKeys: map[string]any{"merged": false},
+		},
+		{
+			name:      "opened as draft",
+			action:    "opened",
+			pr:        map[string]any{"draft": true},
+			wantType:  "pull_request",
+			wantTitle: "Opened draft PR #10286: Add retry to uploads in testorg/testrepo",
+		},
+		{
+			name:      "converted to draft",
+			action:    "converted_to_draft",
+			wantType:  "pr_converted_to_draft",
+			wantTitle: "Converted PR #10286: Add retry to uploads to draft in testorg/testrepo",
+		},
+		{
+			name:      "ready for review",
+			action:    "ready_for_review",
+			wantType:  "pr_ready_for_review",
+			wantTitle: "Marked PR #10286: Add retry to uploads ready for review in testorg/testrepo",
+		},
+		{
+			name:             "review requested from user",
+			action:           "review_requested",
+			payload:          map[string]any{"requested_reviewer": map[string]any{"login": "octocat"}},
+			wantType:         "pr_review_requested",
+			wantTitle:        "Requested review from octocat on PR #10286: Add retry to uploads in testorg/testrepo",
+			wantMetadataKeys: map[string]any{"requested_reviewer": "octocat"},
+		},
+		{
+			name:      "review requested from team",
+			action:    "review_requested",
+			payload:   map[string]any{"requested_team": map[string]any{"name": "platform"}},
+			wantType:  "pr_review_requested",
+			wantTitle: "Requested review from platform on PR #10286: Add retry to uploads in testorg/testrepo",
+		},
+		{
+			name:             "assigned",
+			action:           "assigned",
+			payload:          map[string]any{"assignee": map[string]any{"login": "octocat"}},
+			wantType:         "pr_assigned",
+			wantTitle:        "Assigned octocat to PR #10286: Add retry to uploads in testorg/testrepo",
+			wantMetadataKeys: map[string]any{"assignee": "octocat"},
+		},
+		{
+			name:             "labeled",
+			action:           "labeled",
+			payload:          map[string]any{"label": map[string]any{"name": "bug"}},
+			wantType:         "pr_labeled",
+			wantTitle:        "Labeled PR #10286: Add retry to uploads with bug in testorg/testrepo",
+			wantMetadataKeys: map[string]any{"label": "bug"},
+		},
+		{
+			name:      "other actions keep the raw action",
+			action:    "synchronize",
+			wantType:  "pull_request",
+			wantTitle: "PR #10286: Add retry to uploads synchronize in testorg/testrepo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := loadJSONTestData(t, "../../testdata/events/pull_request.json")
+			payload := event["payload"].(map[string]any)
+			payload["action"] = tt.action
+			for k, v := range tt.payload {
+				payload[k] = v
+			}
+			pr := payload["pull_request"].(map[string]any)
+			pr["title"] = "Add retry to uploads"
+			for k, v := range tt.pr {
+				pr[k] = v
+			}
+
+			got, err := transformEvent(event)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantType, got.ActivityType)
+			assert.Equal(t, tt.wantTitle, got.Title)
+			assert.Equal(t, "Add retry to uploads", *got.Contexts[2].Title)
+
+			metadata := got.Metadata.(map[string]any)
+			assert.Equal(t, "Add retry to uploads", metadata["pr_title"])
+			for k, v := range tt.wantMetadataKeys {
+				assert.Equal(t, v, metadata[k], k)
+			}
+		})
+	}
+}
+
+func TestTransformPRReviewEvent_States(t *testing.T) {
+	tests := []struct {
+		state     string
+		wantTitle string
+	}{
+		{state: "approved", wantTitle: "Approved PR #52742: Fix flaky test in testorg/testrepo"},
+		{state: "APPROVED", wantTitle: "Approved PR #52742: Fix flaky test in testorg/testrepo"},
+		{state: "changes_requested", wantTitle: "Requested changes on PR #52742: Fix flaky test in testorg/testrepo"},
+		{state: "commented", wantTitle: "Reviewed PR #52742: Fix flaky test with comments in testorg/testrepo"},
+		{state: "dismissed", wantTitle: "Reviewed PR #52742: Fix flaky test in testorg/testrepo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			event := loadJSONTestData(t, "../../testdata/events/pr_review.json")
+			payload := event["payload"].(map[string]any)
+			payload["review"].(map[string]any)["state"] = tt.state
+			payload["pull_request"].(map[string]any)["title"] = "Fix flaky test"
+
+			got, err := transformEvent(event)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTitle, got.Title)
+		})
+	}
+}
+
+func TestTransformReleaseEvent(t *testing.T) {
+	got, err := transformEvent(loadJSONTestData(t, "../../testdata/events/release.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, "release", got.ActivityType)
+	assert.Equal(t, "Release v0.6.0 - Trace timeline navigation published in ymtdzzz/otel-tui", got.Title)
+	assert.Equal(t, "https://github.com/ymtdzzz/otel-tui/releases/tag/v0.6.0", *got.Url)
+	assert.Equal(t, map[string]any{
+		"release_id":   int64(261532907),
+		"action":       "published",
+		"tag_name":     "v0.6.0",
+		"release_name": "v0.6.0 - Trace timeline navigation",
+		"draft":        false,
+		"prerelease":   false,
+	}, got.Metadata)
+	if assert.Len(t, got.Contexts, 3) {
+		assert.Equal(t, "github:release:ymtdzzz/otel-tui:v0.6.0", got.Contexts[2].Id)
+		assert.Equal(t, "v0.6.0 - Trace timeline navigation", *got.Contexts[2].Title)
+	}
+}
+
+func TestBranchContexts(t *testing.T) {
+	tests := []struct {
+		name    string
+		pr      *pullRequest
+		wantIDs []string
+	}{
+		{
+			name: "same repository",
+			pr: &pullRequest{
+				Base: branchRef{Ref: "main"},
+				Head: branchRef{Ref: "feature/login", Repo: &refRepo{URL: "https://api.github.com/repos/testorg/testrepo"}},
+			},
+			wantIDs: []string{"github:branch:testorg/testrepo:main", "github:branch:testorg/testrepo:feature/login"},
+		},
+		{
+			name: "fork",
+			pr: &pullRequest{
+				Base: branchRef{Ref: "main"},
+				Head: branchRef{Ref: "main", Repo: &refRepo{FullName: "contributor/testrepo"}},
+			},
+			wantIDs: []string{"github:branch:testorg/testrepo:main", "github:branch:contributor/testrepo:main"},
+		},
+		{
+			name: "head repository unknown",
+			pr: &pullRequest{
+				Base: branchRef{Ref: "main"},
+				Head: branchRef{Ref: "fix"},
+			},
+			wantIDs: []string{"github:branch:testorg/testrepo:main", "github:branch:testorg/testrepo:fix"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := []string{}
+			for _, c := range branchContexts(core.NewContextGenerator(), "testorg/testrepo", tt.pr) {
+				ids = append(ids, c.Id)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestTransformEvent_Malformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		modify  func(event map[string]any)
+		wantErr string
+	}{
+		{
+			name:    "missing type",
+			file:    "push.json",
+			modify:  func(event map[string]any) { delete(event, "type") },
+			wantErr: "missing event type",
+		},
+		{
+			name:    "numeric id",
+			file:    "push.json",
+			modify:  func(event map[string]any) { event["id"] = 123 },
+			wantErr: "invalid event",
+		},
+		{
+			name:    "missing repo",
+			file:    "push.json",
+			modify:  func(event map[string]any) { delete(event, "repo") },
+			wantErr: "invalid repo in PushEvent",
+		},
+		{
+			name:    "missing payload",
+			file:    "delete.json",
+			modify:  func(event map[string]any) { delete(event, "payload") },
+			wantErr: "invalid payload in DeleteEvent",
+		},
+		{
+			name: "pull request of wrong type",
+			file: "pull_request.json",
+			modify: func(event map[string]any) {
+				event["payload"].(map[string]any)["pull_request"] = "oops"
+			},
+			wantErr: "invalid payload in PullRequestEvent",
+		},
+		{
+			name: "missing pull request number",
+			file: "pull_request.json",
+			modify: func(event map[string]any) {
+				delete(event["payload"].(map[string]any), "number")
+			},
+			wantErr: "invalid number in PullRequestEvent",
+		},
+		{
+			name: "missing issue number",
+			file: "issues.json",
+			modify: func(event map[string]any) {
+				delete(event["payload"].(map[string]any)["issue"].(map[string]any), "number")
+			},
+			wantErr: "invalid issue in IssuesEvent",
+		},
+		{
+			name: "missing comment",
+			file: "issue_comment.json",
+			modify: func(event map[string]any) {
+				delete(event["payload"].(map[string]any), "comment")
+			},
+			wantErr: "invalid comment in IssueCommentEvent",
+		},
+		{
+			name: "missing review",
+			file: "pr_review.json",
+			modify: func(event map[string]any) {
+				delete(event["payload"].(map[string]any), "review")
+			},
+			wantErr: "invalid review in PullRequestReviewEvent",
+		},
+		{
+			name: "missing review comment pull request",
+			file: "pr_review_comment.json",
+			modify: func(event map[string]any) {
+				delete(event["payload"].(map[string]any), "pull_request")
+			},
+			wantErr: "invalid pull_request in PullRequestReviewCommentEvent",
+		},
+		{
+			name: "missing release tag",
+			file: "release.json",
+			modify: func(event map[string]any) {
+				delete(event["payload"].(map[string]any)["release"].(map[string]any), "tag_name")
+			},
+			wantErr: "invalid release in ReleaseEvent",
+		},
+		{
+			name:    "invalid timestamp",
+			file:    "push.json",
+			modify:  func(event map[string]any) { event["created_at"] = "yesterday" },
+			wantErr: "invalid timestamp format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := loadJSONTestData(t, "../../testdata/events/"+tt.file)
+			tt.modify(event)
+
+			got, err := transformEvent(event)
+			assert.Nil(t, got)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+// FuzzTransformEvent checks that arbitrary events never panic, neither in transformEvent nor in
+// the aggregation of the activities it returns
+func FuzzTransformEvent(f *testing.F) {
+	files, err := filepath.Glob("../../testdata/events/*.json")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, file := range files {
+		b, err := os.ReadFile(file) // nolint:gosec
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var event map[string]any
+		if err := json.Unmarshal(data, &event); err != nil {
+			return
+		}
+
+		activity, err := transformEvent(event)
+		if err != nil {
+			return
+		}
+		if activity == nil {
+			t.Fatal("transformEvent returned neither an activity nor an error")
+		}
+		aggregateActivities([]*Activity{activity, activity})
+	})
+}
@@ -0,0 +1,224 @@
+package httpcache
+
+import (
+	"encoding/json"
+	"github-connector/internal/auth"
+	"strings"
+)
+
+const (
+	// keyPrefix namespaces cache entries in the store
+	keyPrefix = "httpcache:"
+	// indexKey stores the index of the cached entries
+	indexKey = "httpcache-index"
+	// maxTotalBytes is the budget of all stored entries. Extism limits the total size of plugin
+	// vars (1 MiB by default), so the least recently used entries are evicted to stay below it.
+	maxTotalBytes = 640 * 1024
+	// maxEntryBytes is the largest stored entry. Larger responses are always fetched
+	// unconditionally, so that one listing cannot evict the whole cache.
+	maxEntryBytes = 128 * 1024
+)
+
+// Store persists cache entries between plugin calls.
+type Store interface {
+	// Load returns the value stored for key, or nil if there is none.
+	Load(key string) []byte
+	// Save stores value for key.
+	Save(key string, value []byte)
+	// Delete removes the value stored for key.
+	Delete(key string)
+}
+
+// indexEntry records the size of a stored entry. The index lists entries from least to most
+// recently used.
+type indexEntry struct {
+	Key  string `json:"key"`
+	Size int    `json:"size"`
+}
+
+// entry is a cached response for a single URL.
+type entry struct {
+	ETag    string            `json:"etag"`
+	Body    []byte            `json:"body"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Client wraps an auth.Client with an ETag cache keyed by URL.
+// Requests for cached URLs are sent with If-None-Match; on 304 Not Modified the cached
+// body is served with status 200, so GitHub does not count the request against the rate limit.
+type Client struct {
+	client auth.Client
+	store  Store
+	hits   int
+	misses int
+
+	// index is loaded from the store on first use
+	index []indexEntry
+}
+
+// NewClient creates a caching Client on top of client, persisting entries in store.
+func NewClient(client auth.Client, store Store) *Client {
+	return &Client{
+		client: client,
+		store:  store,
+	}
+}
+
+// Get sends a conditional GET request and returns the response body and status code.
+func (c *Client) Get(url string) ([]byte, int, error) {
+	body, status, _, err := c.GetWithHeaders(url, nil)
+	return body, status, err
+}
+
+// GetWithHeaders sends a conditional GET request with additional request headers.
+func (c *Client) GetWithHeaders(url string, headers map[string]string) ([]byte, int, map[string]string, error) {
+	cached := c.load(url)
+
+	reqHeaders := make(map[string]string, len(headers)+1)
+	for k, v := range headers {
+		reqHeaders[k] = v
+	}
+	if cached != nil {
+		reqHeaders["If-None-Match"] = cached.ETag
+	}
+
+	body, status, respHeaders, err := c.client.GetWithHeaders(url, reqHeaders)
+	if err != nil {
+		return nil, status, nil, err
+	}
+
+	if status == 304 && cached != nil {
+		c.hits++
+		return cached.Body, 200, cached.Headers, nil
+	}
+
+	c.misses++
+	if status == 200 {
+		if etag := HeaderValue(respHeaders, "ETag"); etag != "" {
+			c.save(url, &entry{ETag: etag, Body: body, Headers: respHeaders})
+		}
+	}
+
+	return body, status, respHeaders, nil
+}
+
+// Post sends a POST request without caching; POST responses are never conditional.
+func (c *Client) Post(url string, body []byte) ([]byte, int, error) {
+	return c.client.Post(url, body)
+}
+
+// Stats returns the number of cache hits and misses since the client was created.
+func (c *Client) Stats() (hits, misses int) {
+	return c.hits, c.misses
+}
+
+func (c *Client) load(url string) *entry {
+	key := keyPrefix + url
+	if c.indexOf(key) < 0 {
+		// Not in the index: nothing is cached, or the entry was left by an older version
+		c.store.Delete(key)
+		return nil
+	}
+
+	raw := c.store.Load(key)
+	var e entry
+	if err := json.Unmarshal(raw, &e); err != nil || e.ETag == "" {
+		c.remove(key)
+		c.saveIndex()
+		return nil
+	}
+
+	c.touch(key, len(raw))
+	c.saveIndex()
+	return &e
+}
+
+// save stores an entry, evicting the least recently used entries to stay within maxTotalBytes.
+// An entry larger than maxEntryBytes replaces nothing and is dropped.
+func (c *Client) save(url string, e *entry) {
+	key := keyPrefix + url
+	raw, err := json.Marshal(e)
+	if err != nil || len(raw) > maxEntryBytes {
+		if c.indexOf(key) >= 0 {
+			c.remove(key)
+			c.saveIndex()
+		}
+		return
+	}
+
+	c.remove(key)
+	for len(c.index) > 0 && c.totalBytes()+len(raw) > maxTotalBytes {
+		c.remove(c.index[0].Key)
+	}
+	c.store.Save(key, raw)
+	c.touch(key, len(raw))
+	c.saveIndex()
+}
+
+// loadIndex reads the index once per client. A missing or unreadable index empties the cache.
+func (c *Client) loadIndex() {
+	if c.index != nil {
+		return
+	}
+	c.index = []indexEntry{}
+	if raw := c.store.Load(indexKey); len(raw) > 0 {
+		if err := json.Unmarshal(raw, &c.index); err != nil {
+			c.index = []indexEntry{}
+		}
+	}
+}
+
+func (c *Client) saveIndex() {
+	raw, err := json.Marshal(c.index)
+	if err != nil {
+		return
+	}
+	c.store.Save(indexKey, raw)
+}
+
+func (c *Client) indexOf(key string) int {
+	c.loadIndex()
+	for i, e := range c.index {
+		if e.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// touch marks key as the most recently used entry
+func (c *Client) touch(key string, size int) {
+	if i := c.indexOf(key); i >= 0 {
+		c.index = append(c.index[:i], c.index[i+1:]...)
+	}
+	c.index = append(c.index, indexEntry{Key: key, Size: size})
+}
+
+// remove deletes an entry from the store and the index
+func (c *Client) remove(key string) {
+	if i := c.indexOf(key); i >= 0 {
+		c.index = append(c.index[:i], c.index[i+1:]...)
+		c.store.Delete(key)
+	}
+}
+
+func (c *Client) totalBytes() int {
+	total := 0
+	for _, e := range c.index {
+		total += e.Size
+	}
+	return total
+}
+
+// HeaderValue returns the value of an HTTP header using a case-insensitive name lookup.
+func HeaderValue(headers map[string]string, name string) string {
+	if v, ok := headers[name]; ok {
+		return v
+	}
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,200 @@
+package httpcache
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type response struct {
+	body    []byte
+	status  int
+	headers map[string]string
+}
+
+// fakeClient returns queued responses and records the request headers it received.
+type fakeClient struct {
+	responses []response
+	requests  []map[string]string
+}
+
+func (c *fakeClient) Get(url string) ([]byte, int, error) {
+	body, status, _, err := c.GetWithHeaders(url, nil)
+	return body, status, err
+}
+
+func (c *fakeClient) GetWithHeaders(_ string, headers map[string]string) ([]byte, int, map[string]string, error) {
+	c.requests = append(c.requests, headers)
+	res := c.responses[0]
+	c.responses = c.responses[1:]
+	return res.body, res.status, res.headers, nil
+}
+
+func (c *fakeClient) Post(_ string, _ []byte) ([]byte, int, error) {
+	res := c.responses[0]
+	c.responses = c.responses[1:]
+	return res.body, res.status, nil
+}
+
+type memoryStore map[string][]byte
+
+func (s memoryStore) Load(key string) []byte { return s[key] }
+
+func (s memoryStore) Save(key string, value []byte) { s[key] = value }
+
+func (s memoryStore) Delete(key string) { delete(s, key) }
+
+func (s memoryStore) total() int {
+	total := 0
+	for _, v := range s {
+		total += len(v)
+	}
+	return total
+}
+
+func TestClient_ServesCachedBodyOnNotModified(t *testing.T) {
+	fake := &fakeClient{responses: []response{
+		{body: []byte(`{"id":1}`), status: 200, headers: map[string]string{"etag": `W/"abc"`, "link": `<next>; rel="next"`}},
+		{body: nil, status: 304, headers: map[string]string{"etag": `W/"abc"`}},
+	}}
+	store := memoryStore{}
+	client := NewClient(fake, store)
+
+	body, status, err := client.Get("https://api.github.com/repos/o/r")
+	assert.NoError(t, err)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, `{"id":1}`, string(body))
+	assert.NotContains(t, fake.requests[0], "If-None-Match")
+
+	body, status, headers, err := client.GetWithHeaders("https://api.github.com/repos/o/r", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, `{"id":1}`, string(body))
+	assert.Equal(t, `<next>; rel="next"`, HeaderValue(headers, "Link"))
+	assert.Equal(t, `W/"abc"`, fake.requests[1]["If-None-Match"])
+
+	hits, misses := client.Stats()
+	assert.Equal(t, 1, hits)
+	assert.Equal(t, 1, misses)
+}
+
+func TestClient_PersistsAcrossClients(t *testing.T) {
+	store := memoryStore{}
+	first := NewClient(&fakeClient{responses: []response{
+		{body: []byte(`[]`), status: 200, headers: map[string]string{"ETag": `"v1"`}},
+	}}, store)
+	_, _, err := first.Get("https://api.github.com/users/u/events?page=1")
+	assert.NoError(t, err)
+
+	fake := &fakeClient{responses: []response{{status: 304}}}
+	second := NewClient(fake, store)
+	body, status, err := second.Get("https://api.github.com/users/u/events?page=1")
+	assert.NoError(t, err)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, `[]`, string(body))
+	assert.Equal(t, `"v1"`, fake.requests[0]["If-None-Match"])
+}
+
+func TestClient_ReplacesEntryOnChange(t *testing.T) {
+	store := memoryStore{}
+	fake := &fakeClient{responses: []response{
+		{body: []byte(`"old"`), status: 200, headers: map[string]string{"ETag": `"v1"`}},
+		{body: []byte(`"new"`), status: 200, headers: map[string]string{"ETag": `"v2"`}},
+		{status: 304},
+	}}
+	client := NewClient(fake, store)
+
+	for _, want := range []string{`"old"`, `"new"`, `"new"`} {
+		body, _, err := client.Get("https://api.github.com/x")
+		assert.NoError(t, err)
+		assert.Equal(t, want, string(body))
+	}
+	assert.Equal(t, `"v2"`, fake.requests[2]["If-None-Match"])
+
+	hits, misses := client.Stats()
+	assert.Equal(t, 1, hits)
+	assert.Equal(t, 2, misses)
+}
+
+func TestClient_DoesNotCacheErrorsOrMissingETag(t *testing.T) {
+	store := memoryStore{}
+	fake := &fakeClient{responses: []response{
+		{body: []byte(`{"message":"Not Found"}`), status: 404, headers: map[string]string{"ETag": `"e"`}},
+		{body: []byte(`{}`), status: 200},
+	}}
+	client := NewClient(fake, store)
+
+	_, status, err := client.Get("https://api.github.com/a")
+	assert.NoError(t, err)
+	assert.Equal(t, 404, status)
+	_, _, err = client.Get("https://api.github.com/b")
+	assert.NoError(t, err)
+
+	assert.Empty(t, store)
+}
+
+func TestClient_EvictsLeastRecentlyUsedEntries(t *testing.T) {
+	store := memoryStore{}
+	body := bytes.Repeat([]byte("a"), 60*1024)
+	url := func(i int) string { return fmt.Sprintf("https://api.github.com/x?page=%d", i) }
+
+	fake := &fakeClient{}
+	client := NewClient(fake, store)
+	get := func(i int) {
+		_, _, err := client.Get(url(i))
+		assert.NoError(t, err)
+	}
+
+	for i := 0; i < 5; i++ {
+		fake.responses = append(fake.responses, response{body: body, status: 200, headers: map[string]string{"ETag": fmt.Sprintf(`"v%d"`, i)}})
+		get(i)
+	}
+	// page 0 becomes the most recently used entry
+	fake.responses = append(fake.responses, response{status: 304})
+	get(0)
+	for i := 5; i < 10; i++ {
+		fake.responses = append(fake.responses, response{body: body, status: 200, headers: map[string]string{"ETag": fmt.Sprintf(`"v%d"`, i)}})
+		get(i)
+	}
+
+	assert.LessOrEqual(t, store.total()-len(store[indexKey]), maxTotalBytes)
+	assert.Contains(t, store, keyPrefix+url(0))
+	assert.NotContains(t, store, keyPrefix+url(1))
+	assert.Contains(t, store, keyPrefix+url(9))
+
+	// a new client sees the same entries
+	fake = &fakeClient{responses: []response{{status: 304}, {body: body, status: 200}}}
+	client = NewClient(fake, store)
+	get(9)
+	get(1)
+	assert.Equal(t, `"v9"`, fake.requests[0]["If-None-Match"])
+	assert.NotContains(t, fake.requests[1], "If-None-Match")
+}
+
+func TestClient_DoesNotCacheLargeBodies(t *testing.T) {
+	store := memoryStore{}
+	fake := &fakeClient{responses: []response{
+		{body: []byte(`"small"`), status: 200, headers: map[string]string{"ETag": `"v1"`}},
+		{body: bytes.Repeat([]byte("a"), maxEntryBytes), status: 200, headers: map[string]string{"ETag": `"v2"`}},
+		{body: []byte(`"small"`), status: 200},
+	}}
+	client := NewClient(fake, store)
+
+	for i := 0; i < 3; i++ {
+		_, _, err := client.Get("https://api.github.com/x")
+		assert.NoError(t, err)
+	}
+	// the stale entry is dropped once the body outgrows the cache
+	assert.NotContains(t, store, keyPrefix+"https://api.github.com/x")
+	assert.NotContains(t, fake.requests[2], "If-None-Match")
+}
+
+func TestHeaderValue(t *testing.T) {
+	headers := map[string]string{"etag": "x", "Link": "y"}
+	assert.Equal(t, "x", HeaderValue(headers, "ETag"))
+	assert.Equal(t, "y", HeaderValue(headers, "link"))
+	assert.Equal(t, "", HeaderValue(headers, "missing"))
+	assert.Equal(t, "", HeaderValue(nil, "ETag"))
+}
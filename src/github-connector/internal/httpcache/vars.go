@@ -0,0 +1,26 @@
+//go:build wasip1
+
+package httpcache
+
+import "github.com/extism/go-pdk"
+
+// varStore persists cache entries in Extism plugin vars, which live as long as the
+// plugin instance on the host.
+type varStore struct{}
+
+// NewVarStore creates a Store backed by Extism plugin vars.
+func NewVarStore() Store {
+	return &varStore{}
+}
+
+func (s *varStore) Load(key string) []byte {
+	return pdk.GetVar(key)
+}
+
+func (s *varStore) Save(key string, value []byte) {
+	pdk.SetVar(key, value)
+}
+
+func (s *varStore) Delete(key string) {
+	pdk.RemoveVar(key)
+}
@@ -2,54 +2,277 @@ package match
 
 import (
 	"github-connector/internal/core"
+	"net/url"
 	"regexp"
+	"strconv"
+	"strings"
 )
 
 var (
-	rePullRequest = regexp.MustCompile(core.ContextPatternPullRequest)
-	reIssue       = regexp.MustCompile(core.ContextPatternIssue)
-	reRepository  = regexp.MustCompile(core.ContextPatternRepository)
-	reExcludeRepo = regexp.MustCompile(core.ContextExcludePatternRepository)
+	reURL            = regexp.MustCompile(core.ContextPatternURL)
+	reFullSHA        = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+	reAbbreviatedSHA = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+	reVersionTag     = regexp.MustCompile(`^[vV]?\d+(?:\.\d+)+`)
+
+	reservedSegments = toSet(core.ReservedPathSegments)
 )
 
-// MatchURL returns the context hierarchy for a single URL, or an empty slice if no pattern matches.
+// rule maps a URL pattern to the context hierarchy it represents.
+type rule struct {
+	re    *regexp.Regexp
+	build func(gen *core.ContextGenerator, m map[string]string) []*core.Context
+}
+
+// rules are evaluated in order; more specific patterns must come before the ones they overlap with.
+var rules = []rule{
+	// Gists and organization/user projects do not belong to a repository. They are recognised
+	// here so that they never fall through to the repository pattern.
+	{re: regexp.MustCompile(core.ContextPatternGist), build: gistContexts},
+	{re: regexp.MustCompile(core.ContextPatternOwnerProject), build: projectContexts},
+
+	{re: regexp.MustCompile(core.ContextPatternPullRequestCommit), build: pullRequestContexts},
+	{re: regexp.MustCompile(core.ContextPatternPullRequest), build: pullRequestContexts},
+	{re: regexp.MustCompile(core.ContextPatternIssue), build: issueContexts},
+	{re: regexp.MustCompile(core.ContextPatternCommit), build: commitContexts},
+	{re: regexp.MustCompile(core.ContextPatternCompare), build: compareContexts},
+	{re: regexp.MustCompile(core.ContextPatternTree), build: treeContexts},
+	{re: regexp.MustCompile(core.ContextPatternRelease), build: releaseContexts},
+	{re: regexp.MustCompile(core.ContextPatternReleases), build: repositoryContexts},
+	{re: regexp.MustCompile(core.ContextPatternDiscussion), build: discussionContexts},
+	{re: regexp.MustCompile(core.ContextPatternWorkflowRun), build: workflowRunContexts},
+	{re: regexp.MustCompile(core.ContextPatternWorkflow), build: workflowContexts},
+	{re: regexp.MustCompile(core.ContextPatternRepositoryProject), build: repositoryContexts},
+	{re: regexp.MustCompile(core.ContextPatternRepository), build: repositoryContexts},
+}
+
+// MatchURL returns the context hierarchies for every GitHub URL found in text, or an empty slice
+// if nothing matches. Contexts shared between URLs (e.g., the source) appear only once. URLs of
+// repositories excluded by filter are skipped; a nil filter allows every repository.
 // No external API calls are made; context hierarchy is constructed from URL captures alone.
-func MatchURL(gen *core.ContextGenerator, url string) []*core.Context {
-	// Pull Request pattern (checked before Repository to avoid partial match)
-	if m := namedCaptures(rePullRequest, url); m != nil {
-		repoName := m["owner"] + "/" + m["repo"]
-		prNum := parseInt(m["number"])
-		return []*core.Context{
-			gen.CreateSourceContext(),
-			gen.CreateRepositoryContext(repoName),
-			gen.CreatePRContext(repoName, prNum),
+func MatchURL(gen *core.ContextGenerator, filter *core.RepositoryFilter, text string) []*core.Context {
+	contexts := []*core.Context{}
+	seen := map[string]bool{}
+
+	for _, candidate := range reURL.FindAllString(text, -1) {
+		for _, c := range matchSingleURL(gen, filter, candidate) {
+			if seen[c.Id] {
+				continue
+			}
+			seen[c.Id] = true
+			contexts = append(contexts, c)
 		}
 	}
 
-	// Issue pattern (checked before Repository to avoid partial match)
-	if m := namedCaptures(reIssue, url); m != nil {
-		repoName := m["owner"] + "/" + m["repo"]
-		issueNum := parseInt(m["number"])
-		return []*core.Context{
-			gen.CreateSourceContext(),
-			gen.CreateRepositoryContext(repoName),
-			gen.CreateIssueContext(repoName, issueNum),
+	return contexts
+}
+
+// matchSingleURL returns the context hierarchy for a single URL extracted from text.
+func matchSingleURL(gen *core.ContextGenerator, filter *core.RepositoryFilter, rawURL string) []*core.Context {
+	url := normalizeURL(rawURL)
+
+	for _, r := range rules {
+		m := namedCaptures(r.re, url)
+		if m == nil {
+			continue
+		}
+		if isReserved(m["owner"]) {
+			return nil
+		}
+		if m["repo"] != "" && !filter.Allows(m["owner"]+"/"+m["repo"], nil) {
+			return nil
+		}
+		// Resources owned by an account (e.g., projects, gists) pass when the owner may have repositories
+		// passing the filter
+		if m["repo"] == "" && !filter.MatchesOwner(m["owner"]) {
+			return nil
 		}
+		m["item_id"] = queryParam(rawURL, "itemId")
+		return r.build(gen, m)
 	}
 
-	// Repository pattern (with exclusion check)
-	if reExcludeRepo.MatchString(url) {
-		return []*core.Context{}
+	return nil
+}
+
+// normalizeURL strips the query string, fragment, trailing punctuation picked up from
+// surrounding prose, trailing slashes and a ".git" suffix.
+func normalizeURL(url string) string {
+	url = strings.TrimRight(url, ".,;:!?")
+	if i := strings.IndexAny(url, "?#"); i >= 0 {
+		url = url[:i]
 	}
-	if m := namedCaptures(reRepository, url); m != nil {
-		repoName := m["owner"] + "/" + m["repo"]
-		return []*core.Context{
-			gen.CreateSourceContext(),
-			gen.CreateRepositoryContext(repoName),
-		}
+	url = strings.TrimRight(url, "/")
+	return strings.TrimSuffix(url, ".git")
+}
+
+// queryParam returns the value of a query parameter of a URL, or "" if it is absent.
+func queryParam(rawURL, name string) string {
+	start := strings.Index(rawURL, "?")
+	if start < 0 {
+		return ""
+	}
+	query := rawURL[start+1:]
+	if end := strings.Index(query, "#"); end >= 0 {
+		query = query[:end]
+	}
+	values, err := url.ParseQuery(strings.TrimRight(query, ".,;:!?"))
+	if err != nil {
+		return ""
+	}
+	return values.Get(name)
+}
+
+func repositoryContexts(gen *core.ContextGenerator, m map[string]string) []*core.Context {
+	repoName := m["owner"] + "/" + m["repo"]
+	return []*core.Context{
+		gen.CreateSourceContext(),
+		gen.CreateRepositoryContext(repoName),
+	}
+}
+
+// pullRequestContexts builds the pull request hierarchy. A link to a commit of the pull request
+// adds a commit context when it carries the full SHA, like commitContexts. Review threads are
+// identified by their root comment, and an anchor to a review comment (e.g.,
+// "#discussion_r1234567") may link to a reply, so anchors do not add a review thread context.
+func pullRequestContexts(gen *core.ContextGenerator, m map[string]string) []*core.Context {
+	repoName := m["owner"] + "/" + m["repo"]
+	contexts := append(repositoryContexts(gen, m), gen.CreatePRContext(repoName, parseInt(m["number"])))
+	if !reFullSHA.MatchString(m["sha"]) {
+		return contexts
+	}
+	return append(contexts, gen.CreateCommitContext(repoName, strings.ToLower(m["sha"])))
+}
+
+func issueContexts(gen *core.ContextGenerator, m map[string]string) []*core.Context {
+	repoName := m["owner"] + "/" + m["repo"]
+	return append(repositoryContexts(gen, m), gen.CreateIssueContext(repoName, parseInt(m["number"])))
+}
+
+func workflowContexts(gen *core.ContextGenerator, m map[string]string) []*core.Context {
+	repoName := m["owner"] + "/" + m["repo"]
+	return append(repositoryContexts(gen, m), gen.CreateWorkflowContext(repoName, m["workflow_file"]))
+}
+
+func workflowRunContexts(gen *core.ContextGenerator, m map[string]string) []*core.Context {
+	repoName := m["owner"] + "/" + m["repo"]
+	runID, err := strconv.ParseInt(m["run_id"], 10, 64)
+	if err != nil {
+		return repositoryContexts(gen, m)
+	}
+	return append(repositoryContexts(gen, m), gen.CreateWorkflowRunContext(repoName, runID))
+}
+
+// projectContexts builds the hierarchy of an organization or user project (v2). The itemId query
+// parameter, set when an item is opened in the side panel, adds a project item context.
+func projectContexts(gen *core.ContextGenerator, m map[string]string) []*core.Context {
+	ownerType := core.ProjectOwnerOrganization
+	if m["owner_type"] == "users" {
+		ownerType = core.ProjectOwnerUser
+	}
+	projectNumber := parseInt(m["number"])
+	contexts := []*core.Context{
+		gen.CreateSourceContext(),
+		gen.CreateProjectContext(ownerType, m["owner"], projectNumber),
+	}
+
+	itemID, err := strconv.ParseInt(m["item_id"], 10, 64)
+	if err != nil || itemID <= 0 {
+		return contexts
+	}
+	return append(contexts, gen.CreateProjectItemContext(ownerType, m["owner"], projectNumber, itemID))
+}
+
+// commitContexts builds the commit hierarchy. Commit contexts are identified by the full SHA, so
+// an abbreviated SHA, which cannot be resolved without an API call, only yields the repository.
+func commitContexts(gen *core.ContextGenerator, m map[string]string) []*core.Context {
+	repoName := m["owner"] + "/" + m["repo"]
+	if !reFullSHA.MatchString(m["sha"]) {
+		return repositoryContexts(gen, m)
+	}
+	return append(repositoryContexts(gen, m), gen.CreateCommitContext(repoName, strings.ToLower(m["sha"])))
+}
+
+// treeContexts builds the hierarchy of a link to the root of a branch ("/tree/<branch>"). The ref
+// of a link to a file or directory cannot be told apart from its path when the branch name
+// contains a slash, so such links only yield the repository.
+func treeContexts(gen *core.ContextGenerator, m map[string]string) []*core.Context {
+	repoName := m["owner"] + "/" + m["repo"]
+	if m["kind"] != "tree" || m["path"] != "" {
+		return repositoryContexts(gen, m)
+	}
+	ref, err := url.PathUnescape(m["ref"])
+	if err != nil {
+		return repositoryContexts(gen, m)
+	}
+	if reFullSHA.MatchString(ref) {
+		return append(repositoryContexts(gen, m), gen.CreateCommitContext(repoName, strings.ToLower(ref)))
+	}
+	return append(repositoryContexts(gen, m), gen.CreateBranchContext(repoName, ref))
+}
+
+// compareContexts builds the hierarchy of a comparison ("/compare/<base>...<head>"), adding the
+// head branch. A head of another repository ("owner:branch" or "owner:repo:branch", e.g. a fork)
+// only yields the base repository, since the head repository has not been checked against
+// repository_patterns. Heads that look like commits or version tags only yield the repository.
+func compareContexts(gen *core.ContextGenerator, m map[string]string) []*core.Context {
+	repoName := m["owner"] + "/" + m["repo"]
+	contexts := repositoryContexts(gen, m)
+
+	spec, err := url.PathUnescape(m["spec"])
+	if err != nil {
+		return contexts
 	}
+	head := spec
+	if i := strings.LastIndex(spec, ".."); i >= 0 {
+		head = spec[i+2:]
+	}
+
+	headRepo := repoName
+	parts := strings.Split(head, ":")
+	switch len(parts) {
+	case 2:
+		headRepo, head = parts[0]+"/"+m["repo"], parts[1]
+	case 3:
+		headRepo, head = parts[0]+"/"+parts[1], parts[2]
+	}
+
+	if !strings.EqualFold(headRepo, repoName) || head == "" || reAbbreviatedSHA.MatchString(head) || reVersionTag.MatchString(head) {
+		return contexts
+	}
+	return append(contexts, gen.CreateBranchContext(repoName, head))
+}
+
+func releaseContexts(gen *core.ContextGenerator, m map[string]string) []*core.Context {
+	repoName := m["owner"] + "/" + m["repo"]
+	tag, err := url.PathUnescape(m["tag"])
+	if err != nil {
+		tag = m["tag"]
+	}
+	return append(repositoryContexts(gen, m), gen.CreateReleaseContext(repoName, tag))
+}
 
-	return []*core.Context{}
+func discussionContexts(gen *core.ContextGenerator, m map[string]string) []*core.Context {
+	repoName := m["owner"] + "/" + m["repo"]
+	return append(repositoryContexts(gen, m), gen.CreateDiscussionContext(repoName, parseInt(m["number"])))
+}
+
+func gistContexts(gen *core.ContextGenerator, m map[string]string) []*core.Context {
+	return []*core.Context{
+		gen.CreateSourceContext(),
+		gen.CreateGistContext(m["owner"], m["gist_id"]),
+	}
+}
+
+// isReserved reports whether a path segment is a GitHub feature path rather than an owner.
+func isReserved(segment string) bool {
+	return reservedSegments[strings.ToLower(segment)]
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
 }
 
 // namedCaptures returns a map of named capture groups for the first match, or nil if no match.
@@ -14,7 +14,7 @@ func gen() *core.ContextGenerator { return core.NewContextGenerator() }
 // --- Pull Request ---
 
 func TestMatchURL_PullRequest_Basic(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World/pull/42")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World/pull/42")
 	assert.Equal(t, []*core.Context{
 		{
 			Id:           "github:source",
@@ -49,19 +49,19 @@ func TestMatchURL_PullRequest_Basic(t *testing.T) {
 }
 
 func TestMatchURL_PullRequest_TrailingSlash(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World/pull/42/")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World/pull/42/")
 	assert.Len(t, got, 3)
 	assert.Equal(t, "github:pull_request:octocat/Hello-World:42", got[2].Id)
 }
 
 func TestMatchURL_PullRequest_SlackMrkdwn(t *testing.T) {
-	got := MatchURL(gen(), "<https://github.com/octocat/Hello-World/pull/42|PR #42>")
+	got := MatchURL(gen(), nil, "<https://github.com/octocat/Hello-World/pull/42|PR #42>")
 	assert.Len(t, got, 3)
 	assert.Equal(t, "github:pull_request:octocat/Hello-World:42", got[2].Id)
 }
 
 func TestMatchURL_PullRequest_NotMatchIssueURL(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World/issues/42")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World/issues/42")
 	// Issue URL should not produce a pull_request context
 	if assert.Len(t, got, 3) {
 		assert.Equal(t, "issue", got[2].ResourceType)
@@ -71,7 +71,7 @@ func TestMatchURL_PullRequest_NotMatchIssueURL(t *testing.T) {
 // --- Issue ---
 
 func TestMatchURL_Issue_Basic(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World/issues/42")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World/issues/42")
 	assert.Equal(t, []*core.Context{
 		{
 			Id:           "github:source",
@@ -106,19 +106,19 @@ func TestMatchURL_Issue_Basic(t *testing.T) {
 }
 
 func TestMatchURL_Issue_TrailingSlash(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World/issues/42/")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World/issues/42/")
 	assert.Len(t, got, 3)
 	assert.Equal(t, "github:issue:octocat/Hello-World:42", got[2].Id)
 }
 
 func TestMatchURL_Issue_SlackMrkdwn(t *testing.T) {
-	got := MatchURL(gen(), "<https://github.com/octocat/Hello-World/issues/42|Issue #42>")
+	got := MatchURL(gen(), nil, "<https://github.com/octocat/Hello-World/issues/42|Issue #42>")
 	assert.Len(t, got, 3)
 	assert.Equal(t, "github:issue:octocat/Hello-World:42", got[2].Id)
 }
 
 func TestMatchURL_Issue_NotMatchPullRequestURL(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World/pull/42")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World/pull/42")
 	if assert.Len(t, got, 3) {
 		assert.Equal(t, "pull_request", got[2].ResourceType)
 	}
@@ -127,7 +127,7 @@ func TestMatchURL_Issue_NotMatchPullRequestURL(t *testing.T) {
 // --- Repository ---
 
 func TestMatchURL_Repository_Basic(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World")
 	assert.Equal(t, []*core.Context{
 		{
 			Id:           "github:source",
@@ -153,43 +153,43 @@ func TestMatchURL_Repository_Basic(t *testing.T) {
 }
 
 func TestMatchURL_Repository_TrailingSlash(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World/")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World/")
 	assert.Len(t, got, 2)
 	assert.Equal(t, "github:repository:octocat/Hello-World", got[1].Id)
 }
 
 func TestMatchURL_Repository_SlackMrkdwn(t *testing.T) {
-	got := MatchURL(gen(), "<https://github.com/octocat/Hello-World|My Repository>")
+	got := MatchURL(gen(), nil, "<https://github.com/octocat/Hello-World|My Repository>")
 	assert.Len(t, got, 2)
 	assert.Equal(t, "github:repository:octocat/Hello-World", got[1].Id)
 }
 
 func TestMatchURL_Repository_EndWithCloseParen(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World)")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World)")
 	assert.Len(t, got, 2)
 	assert.Equal(t, "github:repository:octocat/Hello-World", got[1].Id)
 }
 
 func TestMatchURL_Repository_EndWithCloseBracket(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World]")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World]")
 	assert.Len(t, got, 2)
 	assert.Equal(t, "github:repository:octocat/Hello-World", got[1].Id)
 }
 
 func TestMatchURL_Repository_EndWithDoubleQuote(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World\"")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World\"")
 	assert.Len(t, got, 2)
 	assert.Equal(t, "github:repository:octocat/Hello-World", got[1].Id)
 }
 
 func TestMatchURL_Repository_EndWithSingleQuote(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World'")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World'")
 	assert.Len(t, got, 2)
 	assert.Equal(t, "github:repository:octocat/Hello-World", got[1].Id)
 }
 
 func TestMatchURL_Repository_EndWithQueryParam(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World?param=value")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World?param=value")
 	assert.Len(t, got, 2)
 	assert.Equal(t, "github:repository:octocat/Hello-World", got[1].Id)
 }
@@ -197,25 +197,373 @@ func TestMatchURL_Repository_EndWithQueryParam(t *testing.T) {
 // --- Exclude pattern ---
 
 func TestMatchURL_ExcludePattern_UserAttachmentsAsset(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/user-attachments/assets/a548f44f-5f9d-4ad0-8c2b-e7211b7bc08b")
+	got := MatchURL(gen(), nil, "https://github.com/user-attachments/assets/a548f44f-5f9d-4ad0-8c2b-e7211b7bc08b")
 	assert.Empty(t, got)
 }
 
 func TestMatchURL_ExcludePattern_UserAttachmentsNoPath(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/user-attachments/")
+	got := MatchURL(gen(), nil, "https://github.com/user-attachments/")
 	assert.Empty(t, got)
 }
 
 func TestMatchURL_ExcludePattern_RegularRepoNotExcluded(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World")
 	assert.NotEmpty(t, got)
 }
 
 func TestMatchURL_ExcludePattern_PullRequestNotExcluded(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World/pull/42")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World/pull/42")
 	assert.NotEmpty(t, got)
 }
 
+func TestMatchURL_ExcludePattern_ReservedPaths(t *testing.T) {
+	urls := []string{
+		"https://github.com/orgs/foo/projects",
+		"https://github.com/settings/tokens",
+		"https://github.com/features/actions",
+		"https://github.com/marketplace/actions/setup-go",
+		"https://github.com/topics/go",
+		"https://github.com/sponsors/octocat",
+		"https://github.com/notifications",
+		"https://github.com/Settings/profile",
+	}
+	for _, url := range urls {
+		got := MatchURL(gen(), nil, url)
+		assert.Empty(t, got, "should not match: %s", url)
+	}
+}
+
+// --- Repository sub-resources ---
+
+func TestMatchURL_RepositorySubResources(t *testing.T) {
+	urls := []string{
+		"https://github.com/octocat/Hello-World/compare/v1.0.0..v1.1.0",
+		"https://github.com/octocat/Hello-World/compare/main...7fd1a60",
+		"https://github.com/octocat/Hello-World/blob/main/cmd/main.go#L10-L20",
+		"https://github.com/octocat/Hello-World/blob/main",
+		"https://github.com/octocat/Hello-World/tree/main/internal",
+		"https://github.com/octocat/Hello-World/releases",
+		"https://github.com/octocat/Hello-World/tags",
+		"https://github.com/octocat/Hello-World/projects/3",
+		"https://github.com/octocat/Hello-World.git",
+		"https://github.com/octocat/Hello-World#readme",
+	}
+	for _, url := range urls {
+		got := MatchURL(gen(), nil, url)
+		if assert.Len(t, got, 2, "url: %s", url) {
+			assert.Equal(t, "github:repository:octocat/Hello-World", got[1].Id, "url: %s", url)
+		}
+	}
+}
+
+func TestMatchURL_Commit(t *testing.T) {
+	tests := []struct {
+		url    string
+		wantID string
+	}{
+		{url: "https://github.com/octocat/Hello-World/commit/7fd1a60b01f91b314f59955a4e4d4e80d8edf11d", wantID: "github:commit:octocat/Hello-World:7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"},
+		{url: "https://github.com/octocat/Hello-World/commit/7FD1A60B01F91B314F59955A4E4D4E80D8EDF11D?diff=split", wantID: "github:commit:octocat/Hello-World:7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"},
+	}
+	for _, tt := range tests {
+		got := MatchURL(gen(), nil, tt.url)
+		if assert.Len(t, got, 3, "url: %s", tt.url) {
+			assert.Equal(t, "github:repository:octocat/Hello-World", got[1].Id, "url: %s", tt.url)
+			assert.Equal(t, tt.wantID, got[2].Id, "url: %s", tt.url)
+		}
+	}
+}
+
+func TestMatchURL_AbbreviatedCommit(t *testing.T) {
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World/commit/7fd1a60")
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "github:repository:octocat/Hello-World", got[1].Id)
+	}
+}
+
+func TestMatchURL_Branch(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantIDs []string
+	}{
+		{url: "https://github.com/octocat/Hello-World/tree/main", wantIDs: []string{"github:branch:octocat/Hello-World:main"}},
+		{url: "https://github.com/octocat/Hello-World/tree/release%2F1.x", wantIDs: []string{"github:branch:octocat/Hello-World:release/1.x"}},
+		{url: "https://github.com/octocat/Hello-World/tree/7fd1a60b01f91b314f59955a4e4d4e80d8edf11d", wantIDs: []string{"github:commit:octocat/Hello-World:7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"}},
+		{url: "https://github.com/octocat/Hello-World/compare/main...feature/login", wantIDs: []string{"github:branch:octocat/Hello-World:feature/login"}},
+		{url: "https://github.com/octocat/Hello-World/compare/feature/login", wantIDs: []string{"github:branch:octocat/Hello-World:feature/login"}},
+		{url: "https://github.com/octocat/Hello-World/compare/main...octocat:feature/login", wantIDs: []string{"github:branch:octocat/Hello-World:feature/login"}},
+		// Heads of other repositories are not checked against the filter, so no branch is added
+		{url: "https://github.com/octocat/Hello-World/compare/main...monalisa:fix-typo?expand=1", wantIDs: []string{}},
+		{url: "https://github.com/octocat/Hello-World/compare/main...monalisa:Hello-Fork:fix-typo", wantIDs: []string{}},
+	}
+	for _, tt := range tests {
+		got := MatchURL(gen(), nil, tt.url)
+		ids := []string{}
+		for _, c := range got {
+			ids = append(ids, c.Id)
+		}
+		want := append([]string{"github:source", "github:repository:octocat/Hello-World"}, tt.wantIDs...)
+		assert.Equal(t, want, ids, "url: %s", tt.url)
+		// Every context's parent is part of the hierarchy
+		for _, c := range got[1:] {
+			assert.Contains(t, ids, c.ParentId, "url: %s", tt.url)
+		}
+	}
+}
+
+func TestMatchURL_Release(t *testing.T) {
+	tests := []struct {
+		url    string
+		wantID string
+	}{
+		{url: "https://github.com/octocat/Hello-World/releases/tag/v1.2.3", wantID: "github:release:octocat/Hello-World:v1.2.3"},
+		{url: "https://github.com/octocat/Hello-World/releases/tag/cli%2Fv2.0.0", wantID: "github:release:octocat/Hello-World:cli/v2.0.0"},
+	}
+	for _, tt := range tests {
+		got := MatchURL(gen(), nil, tt.url)
+		if assert.Len(t, got, 3, "url: %s", tt.url) {
+			assert.Equal(t, tt.wantID, got[2].Id, "url: %s", tt.url)
+		}
+	}
+}
+
+func TestMatchURL_PullRequestSubResources(t *testing.T) {
+	urls := []string{
+		"https://github.com/octocat/Hello-World/pull/42/commits/7fd1a60",
+		"https://github.com/octocat/Hello-World/pull/42/files",
+		"https://github.com/octocat/Hello-World/pull/42#issuecomment-3506104349",
+		"https://github.com/octocat/Hello-World/pull/42/files#diff-abc123",
+	}
+	for _, url := range urls {
+		got := MatchURL(gen(), nil, url)
+		if assert.Len(t, got, 3, "url: %s", url) {
+			assert.Equal(t, "github:pull_request:octocat/Hello-World:42", got[2].Id, "url: %s", url)
+		}
+	}
+}
+
+func TestMatchURL_PullRequestCommit(t *testing.T) {
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World/pull/42/commits/7FD1A60B01F91B314F59955A4E4D4E80D8EDF11D")
+	ids := []string{}
+	for _, c := range got {
+		ids = append(ids, c.Id)
+	}
+	assert.Equal(t, []string{
+		"github:source",
+		"github:repository:octocat/Hello-World",
+		"github:pull_request:octocat/Hello-World:42",
+		"github:commit:octocat/Hello-World:7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+	}, ids)
+}
+
+func TestMatchURL_ReviewCommentAnchor(t *testing.T) {
+	// The anchored comment may be a reply, so no review thread context is derived from it
+	urls := []string{
+		"https://github.com/octocat/Hello-World/pull/42#discussion_r2532044372",
+		"https://github.com/octocat/Hello-World/pull/42/files#r2532044372",
+		"https://github.com/octocat/Hello-World/pull/42#discussion_r2532044372.",
+	}
+	for _, url := range urls {
+		got := MatchURL(gen(), nil, url)
+		if assert.Len(t, got, 3, "url: %s", url) {
+			assert.Equal(t, "github:pull_request:octocat/Hello-World:42", got[2].Id, "url: %s", url)
+		}
+	}
+}
+
+func TestMatchURL_Issue_CommentAnchor(t *testing.T) {
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World/issues/340#issuecomment-3506104349")
+	if assert.Len(t, got, 3) {
+		assert.Equal(t, "github:issue:octocat/Hello-World:340", got[2].Id)
+	}
+}
+
+// --- Actions ---
+
+func TestMatchURL_WorkflowRun(t *testing.T) {
+	urls := []string{
+		"https://github.com/octocat/Hello-World/actions/runs/123456789",
+		"https://github.com/octocat/Hello-World/actions/runs/123456789/job/987654321",
+		"https://github.com/octocat/Hello-World/actions/runs/123456789/attempts/2",
+	}
+	for _, url := range urls {
+		got := MatchURL(gen(), nil, url)
+		if assert.Len(t, got, 3, "url: %s", url) {
+			assert.Equal(t, &core.Context{
+				Id:           "github:workflow_run:octocat/Hello-World:123456789",
+				Name:         "Run #123456789",
+				ParentId:     "github:repository:octocat/Hello-World",
+				ConnectorId:  "github",
+				ResourceType: "workflow_run",
+				Title:        ptrString("Run #123456789"),
+				Metadata: map[string]any{
+					"enrichment_params": map[string]any{
+						"repo":   "octocat/Hello-World",
+						"run_id": "123456789",
+					},
+				},
+			}, got[2], "url: %s", url)
+		}
+	}
+}
+
+func TestMatchURL_Workflow(t *testing.T) {
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World/actions/workflows/ci.yml?query=branch%3Amain")
+	if assert.Len(t, got, 3) {
+		assert.Equal(t, "github:workflow:octocat/Hello-World:ci.yml", got[2].Id)
+	}
+}
+
+func TestMatchURL_Project(t *testing.T) {
+	got := MatchURL(gen(), nil, "https://github.com/orgs/acme/projects/5/views/2")
+	assert.Equal(t, []*core.Context{
+		{
+			Id:           "github:source",
+			Name:         "github:source",
+			ParentId:     "",
+			ConnectorId:  "github",
+			ResourceType: "source",
+			Title:        ptrString("GitHub"),
+			Description:  ptrString("Github is a code hosting platform for version control and collaboration."),
+			Url:          ptrString("https://github.com"),
+			Metadata:     map[string]any{"enrichment_params": map[string]any{}},
+		},
+		{
+			Id:           "github:project:acme:5",
+			Name:         "project:acme/5",
+			ParentId:     "github:source",
+			ConnectorId:  "github",
+			ResourceType: "project",
+			Title:        ptrString("Project #5"),
+			Url:          ptrString("https://github.com/orgs/acme/projects/5"),
+			Metadata: map[string]any{"enrichment_params": map[string]any{
+				"owner":          "acme",
+				"owner_type":     "organization",
+				"project_number": "5",
+			}},
+		},
+	}, got)
+}
+
+func TestMatchURL_ProjectItem(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantIDs []string
+	}{
+		{
+			url:     "https://github.com/orgs/acme/projects/5/views/2?pane=issue&itemId=98765",
+			wantIDs: []string{"github:source", "github:project:acme:5", "github:project_item:acme:5:98765"},
+		},
+		{
+			url:     "https://github.com/users/octocat/projects/1?itemId=42.",
+			wantIDs: []string{"github:source", "github:project:octocat:1", "github:project_item:octocat:1:42"},
+		},
+		{
+			url:     "https://github.com/orgs/acme/projects/5?itemId=PVTI_abc",
+			wantIDs: []string{"github:source", "github:project:acme:5"},
+		},
+	}
+
+	for _, tt := range tests {
+		got := MatchURL(gen(), nil, tt.url)
+		ids := make([]string, 0, len(got))
+		for _, c := range got {
+			ids = append(ids, c.Id)
+		}
+		assert.Equal(t, tt.wantIDs, ids, tt.url)
+	}
+
+	got := MatchURL(gen(), nil, "https://github.com/users/octocat/projects/1?itemId=42")
+	if assert.Len(t, got, 3) {
+		assert.Equal(t, map[string]any{"enrichment_params": map[string]any{
+			"owner":          "octocat",
+			"owner_type":     "user",
+			"project_number": "1",
+			"item_id":        "42",
+		}}, got[2].Metadata)
+	}
+}
+
+func TestMatchURL_Gist(t *testing.T) {
+	urls := []string{
+		"https://gist.github.com/octocat/6cad326836d38bd3a7ae",
+		"https://gist.github.com/octocat/6cad326836d38bd3a7ae#file-hello-go",
+		"https://gist.github.com/octocat/6cad326836d38bd3a7ae/revisions",
+	}
+	for _, url := range urls {
+		got := MatchURL(gen(), nil, url)
+		if assert.Len(t, got, 2, "url: %s", url) {
+			assert.Equal(t, "github:source", got[0].Id, "url: %s", url)
+			assert.Equal(t, "github:gist:6cad326836d38bd3a7ae", got[1].Id, "url: %s", url)
+			assert.Equal(t, "https://gist.github.com/octocat/6cad326836d38bd3a7ae", *got[1].Url, "url: %s", url)
+		}
+	}
+}
+
+func TestMatchURL_Discussion(t *testing.T) {
+	urls := []string{
+		"https://github.com/octocat/Hello-World/discussions/7",
+		"https://github.com/octocat/Hello-World/discussions/7#discussioncomment-1234567",
+	}
+	for _, url := range urls {
+		got := MatchURL(gen(), nil, url)
+		if assert.Len(t, got, 3, "url: %s", url) {
+			assert.Equal(t, "github:repository:octocat/Hello-World", got[1].Id, "url: %s", url)
+			assert.Equal(t, "github:discussion:octocat/Hello-World:7", got[2].Id, "url: %s", url)
+			assert.Equal(t, "github:repository:octocat/Hello-World", got[2].ParentId, "url: %s", url)
+		}
+	}
+}
+
+// --- Free text ---
+
+func TestMatchURL_FreeText_MultipleURLs(t *testing.T) {
+	text := "Fixed in https://github.com/octocat/Hello-World/pull/42, see also " +
+		"<https://github.com/octocat/Hello-World/issues/7|Issue #7> and [docs](https://github.com/octocat/docs)."
+	got := MatchURL(gen(), nil, text)
+	ids := make([]string, 0, len(got))
+	for _, c := range got {
+		ids = append(ids, c.Id)
+	}
+	assert.Equal(t, []string{
+		"github:source",
+		"github:repository:octocat/Hello-World",
+		"github:pull_request:octocat/Hello-World:42",
+		"github:issue:octocat/Hello-World:7",
+		"github:repository:octocat/docs",
+	}, ids)
+}
+
+func TestMatchURL_FreeText_SkipsReservedAmongValid(t *testing.T) {
+	text := "https://github.com/settings/tokens https://github.com/octocat/Hello-World/pull/1"
+	got := MatchURL(gen(), nil, text)
+	if assert.Len(t, got, 3) {
+		assert.Equal(t, "github:pull_request:octocat/Hello-World:1", got[2].Id)
+	}
+}
+
+// --- Repository filter ---
+
+func TestMatchURL_RepositoryFilter(t *testing.T) {
+	filter, err := core.ParseRepositoryFilter([]string{"octocat/*", "!octocat/secret-*", "!octocat/* archived:true"}, true)
+	assert.NoError(t, err)
+
+	text := "https://github.com/octocat/Hello-World/pull/42 https://github.com/Octocat/secret-plans/issues/1 " +
+		"https://github.com/other/repo https://gist.github.com/octocat/aa5a315d61ae9438b18d " +
+		"https://github.com/orgs/other/projects/1 https://github.com/users/octocat/projects/2"
+	got := MatchURL(gen(), filter, text)
+	ids := make([]string, 0, len(got))
+	for _, c := range got {
+		ids = append(ids, c.Id)
+	}
+	assert.Equal(t, []string{
+		"github:source",
+		"github:repository:octocat/Hello-World",
+		"github:pull_request:octocat/Hello-World:42",
+		"github:gist:aa5a315d61ae9438b18d",
+		"github:project:octocat:2",
+	}, ids)
+}
+
 // --- No match ---
 
 func TestMatchURL_NoMatch(t *testing.T) {
@@ -226,7 +574,7 @@ func TestMatchURL_NoMatch(t *testing.T) {
 		"https://github.com",
 	}
 	for _, url := range urls {
-		got := MatchURL(gen(), url)
+		got := MatchURL(gen(), nil, url)
 		assert.Empty(t, got, "should not match: %s", url)
 	}
 }
@@ -234,7 +582,7 @@ func TestMatchURL_NoMatch(t *testing.T) {
 // --- Context hierarchy integrity ---
 
 func TestMatchURL_ContextHierarchy_PR(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World/pull/42")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World/pull/42")
 	assert.Len(t, got, 3)
 	// source has no parent
 	assert.Equal(t, "", got[0].ParentId)
@@ -245,7 +593,7 @@ func TestMatchURL_ContextHierarchy_PR(t *testing.T) {
 }
 
 func TestMatchURL_ContextHierarchy_Issue(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World/issues/101")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World/issues/101")
 	assert.Len(t, got, 3)
 	assert.Equal(t, "", got[0].ParentId)
 	assert.Equal(t, got[0].Id, got[1].ParentId)
@@ -253,7 +601,7 @@ func TestMatchURL_ContextHierarchy_Issue(t *testing.T) {
 }
 
 func TestMatchURL_ContextHierarchy_Repository(t *testing.T) {
-	got := MatchURL(gen(), "https://github.com/octocat/Hello-World")
+	got := MatchURL(gen(), nil, "https://github.com/octocat/Hello-World")
 	assert.Len(t, got, 2)
 	assert.Equal(t, "", got[0].ParentId)
 	assert.Equal(t, got[0].Id, got[1].ParentId)
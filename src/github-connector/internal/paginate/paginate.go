@@ -0,0 +1,264 @@
+package paginate
+
+import (
+	"encoding/json"
+	"fmt"
+	"github-connector/internal/httpcache"
+	"strings"
+	"time"
+)
+
+// Client sends GET requests; it is satisfied by auth.Client and httpcache.Client.
+type Client interface {
+	GetWithHeaders(url string, headers map[string]string) ([]byte, int, map[string]string, error)
+}
+
+// Limits bounds how much of a list endpoint is read. Zero values fall back to DefaultLimits.
+type Limits struct {
+	// MaxPages is the maximum number of requests
+	MaxPages int
+	// MaxItems is the maximum number of items returned
+	MaxItems int
+}
+
+// DefaultLimits are the limits used when none are configured
+var DefaultLimits = Limits{MaxPages: 10, MaxItems: 1000}
+
+func (l Limits) withDefaults() Limits {
+	if l.MaxPages <= 0 {
+		l.MaxPages = DefaultLimits.MaxPages
+	}
+	if l.MaxItems <= 0 {
+		l.MaxItems = DefaultLimits.MaxItems
+	}
+	return l
+}
+
+// LimitsFromConfig reads the pagination_max_pages and pagination_max_items settings, falling
+// back to DefaultLimits when they are not set.
+func LimitsFromConfig(cfg map[string]any) (Limits, error) {
+	limits := DefaultLimits
+	for _, setting := range []struct {
+		key   string
+		limit *int
+	}{
+		{key: "pagination_max_pages", limit: &limits.MaxPages},
+		{key: "pagination_max_items", limit: &limits.MaxItems},
+	} {
+		value, ok := cfg[setting.key]
+		if !ok || value == nil {
+			continue
+		}
+		num, ok := value.(float64)
+		if !ok || num < 1 || num != float64(int(num)) {
+			return Limits{}, fmt.Errorf("invalid %s: %v", setting.key, value)
+		}
+		*setting.limit = int(num)
+	}
+	return limits, nil
+}
+
+// Options controls how a list endpoint is paginated.
+type Options struct {
+	Limits
+	// Watermark stops pagination after the first page that holds an item older than it.
+	// Items must be listed newest first. The zero value disables the check.
+	Watermark time.Time
+	// TimeField is the dot-separated path of the item timestamp compared with Watermark
+	// (e.g., "created_at" or "commit.author.date")
+	TimeField string
+}
+
+// Result is the outcome of paginating a list endpoint.
+type Result struct {
+	Items []map[string]any
+	Pages int
+	// Truncated reports that pagination stopped at a limit while more items were available
+	Truncated bool
+}
+
+// Decoder extracts the items of a page from a response body.
+type Decoder func(body []byte) ([]map[string]any, error)
+
+// DecodeArray decodes a response whose body is a JSON array of items.
+func DecodeArray(body []byte) ([]map[string]any, error) {
+	var items []map[string]any
+	if err := json.Unmarshal(body, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return items, nil
+}
+
+// DecodeField returns a Decoder for responses that wrap the items in an object field, such as
+// "items" for search results or "workflow_runs" for GitHub Actions.
+func DecodeField(field string) Decoder {
+	return func(body []byte) ([]map[string]any, error) {
+		var response map[string]json.RawMessage
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		raw, ok := response[field]
+		if !ok {
+			return []map[string]any{}, nil
+		}
+		return DecodeArray(raw)
+	}
+}
+
+// List reads a list endpoint starting at url, following the Link rel="next" header until the
+// last page, the watermark or a limit is reached.
+func List(client Client, url string, opts Options, decode Decoder) (*Result, error) {
+	limits := opts.Limits.withDefaults()
+	result := &Result{Items: []map[string]any{}}
+
+	for url != "" {
+		if result.Pages >= limits.MaxPages || len(result.Items) >= limits.MaxItems {
+			result.Truncated = true
+			break
+		}
+
+		body, status, headers, err := client.GetWithHeaders(url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to send request: %w", err)
+		}
+		if status != 200 {
+			return nil, fmt.Errorf("GitHub API error: HTTP %d", status)
+		}
+
+		items, err := decode(body)
+		if err != nil {
+			return nil, err
+		}
+		result.Pages++
+		if len(items) == 0 {
+			break
+		}
+
+		if remaining := limits.MaxItems - len(result.Items); len(items) > remaining {
+			result.Items = append(result.Items, items[:remaining]...)
+			result.Truncated = true
+			break
+		}
+		result.Items = append(result.Items, items...)
+
+		if opts.reachedWatermark(items) {
+			break
+		}
+		url = NextLink(httpcache.HeaderValue(headers, "Link"))
+	}
+
+	return result, nil
+}
+
+// PageFunc fetches the page of a cursor-paginated list (e.g., a GraphQL connection) that starts
+// after cursor, "" for the first page. next is the cursor of the following page, or "" on the
+// last page.
+type PageFunc func(cursor string) (items []map[string]any, next string, err error)
+
+// ListCursor reads a cursor-paginated list until the last page, the watermark or a limit is
+// reached, like List does for Link headers.
+func ListCursor(opts Options, page PageFunc) (*Result, error) {
+	limits := opts.Limits.withDefaults()
+	result := &Result{Items: []map[string]any{}}
+
+	cursor := ""
+	for {
+		if result.Pages >= limits.MaxPages || len(result.Items) >= limits.MaxItems {
+			result.Truncated = true
+			break
+		}
+
+		items, next, err := page(cursor)
+		if err != nil {
+			return nil, err
+		}
+		result.Pages++
+		if len(items) == 0 {
+			break
+		}
+
+		if remaining := limits.MaxItems - len(result.Items); len(items) > remaining {
+			result.Items = append(result.Items, items[:remaining]...)
+			result.Truncated = true
+			break
+		}
+		result.Items = append(result.Items, items...)
+
+		if opts.reachedWatermark(items) || next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	return result, nil
+}
+
+// ListCursorBackward reads a cursor-paginated list that is ordered oldest first (e.g., a GraphQL
+// connection without orderBy) from its end, so that the watermark applies as for lists ordered
+// newest first. page fetches the items before cursor, "" for the last page, and returns them
+// oldest first with the cursor of the preceding page. Items are returned newest first.
+func ListCursorBackward(opts Options, page PageFunc) (*Result, error) {
+	return ListCursor(opts, func(cursor string) ([]map[string]any, string, error) {
+		items, previous, err := page(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		reversed := make([]map[string]any, len(items))
+		for i, item := range items {
+			reversed[len(items)-1-i] = item
+		}
+		return reversed, previous, nil
+	})
+}
+
+// reachedWatermark reports whether any of the items is older than the watermark. Items whose
+// timestamp is missing or malformed are ignored.
+func (o Options) reachedWatermark(items []map[string]any) bool {
+	if o.Watermark.IsZero() || o.TimeField == "" {
+		return false
+	}
+	for _, item := range items {
+		str, _ := lookup(item, o.TimeField).(string)
+		t, err := time.Parse(time.RFC3339, str)
+		if err == nil && t.Before(o.Watermark) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup returns the value at a dot-separated path of nested objects
+func lookup(item map[string]any, path string) any {
+	var value any = item
+	for _, key := range strings.Split(path, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+	return value
+}
+
+// NextLink returns the URL of the rel="next" link in a Link header, or "" on the last page.
+func NextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+		target := strings.TrimSpace(parts[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		for _, param := range parts[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+				continue
+			}
+			for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+				if strings.EqualFold(rel, "next") {
+					return target[1 : len(target)-1]
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,280 @@
+package paginate
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type page struct {
+	body   string
+	status int
+	link   string
+}
+
+// fakeClient serves pages keyed by URL and records the requested URLs.
+type fakeClient struct {
+	pages    map[string]page
+	requests []string
+}
+
+func (c *fakeClient) GetWithHeaders(url string, _ map[string]string) ([]byte, int, map[string]string, error) {
+	c.requests = append(c.requests, url)
+	p, ok := c.pages[url]
+	if !ok {
+		return nil, 0, nil, errors.New("unexpected request: " + url)
+	}
+	headers := map[string]string{}
+	if p.link != "" {
+		headers["link"] = p.link
+	}
+	return []byte(p.body), p.status, headers, nil
+}
+
+func TestList(t *testing.T) {
+	threePages := map[string]page{
+		"p1": {body: `[{"id":1,"created_at":"2025-11-18T12:00:00Z"},{"id":2,"created_at":"2025-11-18T11:00:00Z"}]`, status: 200, link: `<p2>; rel="next", <p3>; rel="last"`},
+		"p2": {body: `[{"id":3,"created_at":"2025-11-18T01:00:00Z"},{"id":4,"created_at":"2025-11-17T23:00:00Z"}]`, status: 200, link: `<p1>; rel="prev", <p3>; rel="next", <p3>; rel="last"`},
+		"p3": {body: `[{"id":5,"created_at":"2025-11-17T20:00:00Z"}]`, status: 200, link: `<p1>; rel="first", <p2>; rel="prev"`},
+	}
+
+	tests := []struct {
+		name          string
+		pages         map[string]page
+		opts          Options
+		wantIDs       []float64
+		wantRequests  []string
+		wantTruncated bool
+		wantErr       string
+	}{
+		{
+			name:         "follows next links to the last page",
+			pages:        threePages,
+			wantIDs:      []float64{1, 2, 3, 4, 5},
+			wantRequests: []string{"p1", "p2", "p3"},
+		},
+		{
+			name:         "stops at the watermark",
+			pages:        threePages,
+			opts:         Options{Watermark: time.Date(2025, 11, 18, 0, 0, 0, 0, time.UTC), TimeField: "created_at"},
+			wantIDs:      []float64{1, 2, 3, 4},
+			wantRequests: []string{"p1", "p2"},
+		},
+		{
+			name:          "stops at max pages",
+			pages:         threePages,
+			opts:          Options{Limits: Limits{MaxPages: 1}},
+			wantIDs:       []float64{1, 2},
+			wantRequests:  []string{"p1"},
+			wantTruncated: true,
+		},
+		{
+			name:          "stops at max items",
+			pages:         threePages,
+			opts:          Options{Limits: Limits{MaxItems: 3}},
+			wantIDs:       []float64{1, 2, 3},
+			wantRequests:  []string{"p1", "p2"},
+			wantTruncated: true,
+		},
+		{
+			name:          "stops when max items is reached at a page boundary",
+			pages:         threePages,
+			opts:          Options{Limits: Limits{MaxItems: 2}},
+			wantIDs:       []float64{1, 2},
+			wantRequests:  []string{"p1"},
+			wantTruncated: true,
+		},
+		{
+			name: "stops on an empty page",
+			pages: map[string]page{
+				"p1": {body: `[{"id":1}]`, status: 200, link: `<p2>; rel="next"`},
+				"p2": {body: `[]`, status: 200, link: `<p3>; rel="next"`},
+			},
+			wantIDs:      []float64{1},
+			wantRequests: []string{"p1", "p2"},
+		},
+		{
+			name: "reports API errors",
+			pages: map[string]page{
+				"p1": {body: `{"message":"Not Found"}`, status: 404},
+			},
+			wantErr: "GitHub API error: HTTP 404",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeClient{pages: tt.pages}
+
+			got, err := List(client, "p1", tt.opts, DecodeArray)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			ids := []float64{}
+			for _, item := range got.Items {
+				ids = append(ids, item["id"].(float64))
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantRequests, client.requests)
+			assert.Equal(t, len(tt.wantRequests), got.Pages)
+			assert.Equal(t, tt.wantTruncated, got.Truncated)
+		})
+	}
+}
+
+func TestList_WatermarkOnNestedField(t *testing.T) {
+	client := &fakeClient{pages: map[string]page{
+		"p1": {body: `[{"sha":"a","commit":{"author":{"date":"2025-11-17T10:00:00Z"}}}]`, status: 200, link: `<p2>; rel="next"`},
+	}}
+	opts := Options{Watermark: time.Date(2025, 11, 18, 0, 0, 0, 0, time.UTC), TimeField: "commit.author.date"}
+
+	got, err := List(client, "p1", opts, DecodeArray)
+	assert.NoError(t, err)
+	assert.Len(t, got.Items, 1)
+	assert.Equal(t, []string{"p1"}, client.requests)
+}
+
+func TestListCursor(t *testing.T) {
+	pages := map[string]struct {
+		items []map[string]any
+		next  string
+	}{
+		"":   {items: []map[string]any{{"id": 1, "createdAt": "2025-11-18T12:00:00Z"}, {"id": 2, "createdAt": "2025-11-18T08:00:00Z"}}, next: "c1"},
+		"c1": {items: []map[string]any{{"id": 3, "createdAt": "2025-11-17T23:00:00Z"}}, next: "c2"},
+		"c2": {items: []map[string]any{{"id": 4, "createdAt": "2025-11-17T20:00:00Z"}}},
+	}
+
+	tests := []struct {
+		name          string
+		opts          Options
+		wantIDs       []int
+		wantCursors   []string
+		wantTruncated bool
+	}{
+		{
+			name:        "follows cursors to the last page",
+			wantIDs:     []int{1, 2, 3, 4},
+			wantCursors: []string{"", "c1", "c2"},
+		},
+		{
+			name:        "stops at the watermark",
+			opts:        Options{Watermark: time.Date(2025, 11, 18, 0, 0, 0, 0, time.UTC), TimeField: "createdAt"},
+			wantIDs:     []int{1, 2, 3},
+			wantCursors: []string{"", "c1"},
+		},
+		{
+			name:          "stops at max items",
+			opts:          Options{Limits: Limits{MaxItems: 1}},
+			wantIDs:       []int{1},
+			wantCursors:   []string{""},
+			wantTruncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursors := []string{}
+			got, err := ListCursor(tt.opts, func(cursor string) ([]map[string]any, string, error) {
+				cursors = append(cursors, cursor)
+				return pages[cursor].items, pages[cursor].next, nil
+			})
+			assert.NoError(t, err)
+
+			ids := []int{}
+			for _, item := range got.Items {
+				ids = append(ids, item["id"].(int))
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantCursors, cursors)
+			assert.Equal(t, tt.wantTruncated, got.Truncated)
+		})
+	}
+
+	_, err := ListCursor(Options{}, func(string) ([]map[string]any, string, error) {
+		return nil, "", errors.New("GitHub GraphQL error: rate limited")
+	})
+	assert.ErrorContains(t, err, "rate limited")
+}
+
+func TestListCursorBackward(t *testing.T) {
+	// A connection listed oldest first, read from its end two items at a time
+	ascending := []map[string]any{
+		{"id": 1, "createdAt": "2025-11-16T10:00:00Z"},
+		{"id": 2, "createdAt": "2025-11-17T09:00:00Z"},
+		{"id": 3, "createdAt": "2025-11-17T23:00:00Z"},
+		{"id": 4, "createdAt": "2025-11-18T08:00:00Z"},
+		{"id": 5, "createdAt": "2025-11-18T12:00:00Z"},
+	}
+	cursors := []string{}
+	got, err := ListCursorBackward(Options{Watermark: time.Date(2025, 11, 18, 0, 0, 0, 0, time.UTC), TimeField: "createdAt"}, func(cursor string) ([]map[string]any, string, error) {
+		cursors = append(cursors, cursor)
+		end := len(ascending)
+		if cursor != "" {
+			end, _ = strconv.Atoi(cursor)
+		}
+		start := max(end-2, 0)
+		previous := ""
+		if start > 0 {
+			previous = strconv.Itoa(start)
+		}
+		return ascending[start:end], previous, nil
+	})
+	assert.NoError(t, err)
+
+	ids := []int{}
+	for _, item := range got.Items {
+		ids = append(ids, item["id"].(int))
+	}
+	assert.Equal(t, []int{5, 4, 3, 2}, ids)
+	assert.Equal(t, []string{"", "3"}, cursors)
+	assert.False(t, got.Truncated)
+}
+
+func TestLimitsFromConfig(t *testing.T) {
+	limits, err := LimitsFromConfig(map[string]any{"pagination_max_pages": float64(3)})
+	assert.NoError(t, err)
+	assert.Equal(t, Limits{MaxPages: 3, MaxItems: DefaultLimits.MaxItems}, limits)
+
+	// The first invalid setting is reported
+	for i := 0; i < 10; i++ {
+		_, err = LimitsFromConfig(map[string]any{"pagination_max_pages": "many", "pagination_max_items": float64(0)})
+		assert.EqualError(t, err, "invalid pagination_max_pages: many")
+	}
+}
+
+func TestDecodeField(t *testing.T) {
+	items, err := DecodeField("workflow_runs")([]byte(`{"total_count":1,"workflow_runs":[{"id":7}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]any{{"id": float64(7)}}, items)
+
+	items, err = DecodeField("items")([]byte(`{"total_count":0}`))
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+
+	_, err = DecodeField("items")([]byte(`[]`))
+	assert.Error(t, err)
+}
+
+func TestNextLink(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: `<https://api.github.com/user/1/events?page=2>; rel="next", <https://api.github.com/user/1/events?page=3>; rel="last"`, want: "https://api.github.com/user/1/events?page=2"},
+		{header: `<https://api.github.com/x?page=1>; rel="prev",<https://api.github.com/x?page=3>; rel="next"`, want: "https://api.github.com/x?page=3"},
+		{header: `<https://api.github.com/x?page=1>; rel="first", <https://api.github.com/x?page=2>; rel="prev"`, want: ""},
+		{header: `<https://api.github.com/x?page=2>; rel="next last"`, want: "https://api.github.com/x?page=2"},
+		{header: ``, want: ""},
+		{header: `garbage; rel="next"`, want: ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, NextLink(tt.header), tt.header)
+	}
+}
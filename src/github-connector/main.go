@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"github-connector/internal/auth"
+	"github-connector/internal/connection"
 	"github-connector/internal/core"
+	"github-connector/internal/paginate"
 
 	"github.com/extism/go-pdk"
 )
@@ -46,7 +48,95 @@ func GetConfigSchema() (ConfigSchema, error) {
 					"type": "string",
 				},
 				"title":       "Repository Patterns",
-				"description": "Repository patterns to include (e.g., 'myorg/*', 'user/repo'). Leave empty for all repositories. Use * for wildcards.",
+				"description": "Repository patterns to include (e.g., 'myorg/*', 'user/repo'). Leave empty for all repositories. Use * and ? for wildcards, ** for any owner and repository, and [a-z] for character classes. Prefix a pattern with ! to exclude repositories; later patterns override earlier ones. Append fork:, archived: or visibility: qualifiers to match repository attributes (e.g., '!myorg/* archived:true').",
+			},
+			"repository_patterns_ignore_case": map[string]any{
+				"type":        "boolean",
+				"title":       "Ignore Case in Repository Patterns",
+				"description": "Match repository patterns case-insensitively, as GitHub does for owner and repository names.",
+				"default":     false,
+			},
+			"fetch_mode": map[string]any{
+				"type":        "string",
+				"title":       "Fetch Mode",
+				"enum":        []string{"public", "authenticated"},
+				"default":     "public",
+				"description": "'public' reads the user's events feed. 'authenticated' also merges the user's events from the organization feeds of the organizations matched by the repository patterns; it requires a token that belongs to the configured user.",
+			},
+			"exclude_bot_actors": map[string]any{
+				"type":        "boolean",
+				"title":       "Exclude Bot Actors",
+				"description": "Skip events performed by bot accounts such as github-actions[bot] or dependabot[bot].",
+				"default":     false,
+			},
+			"excluded_actors": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "string",
+				},
+				"title":       "Excluded Actors",
+				"description": "Logins whose events are skipped (e.g., 'github-actions', 'release-robot').",
+			},
+			"jira_project_keys": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "string",
+				},
+				"title":       "Jira Project Keys",
+				"description": "Jira projects whose issue keys (e.g., 'PROJ-123') are linked from pull request titles and branches. When empty, any key is linked except common tokens such as 'UTF-8' or 'SHA-256'.",
+			},
+			"excluded_activity_types": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "string",
+					"enum": []string{"push", "pull_request", "issues", "issue_comment", "pr_comment", "pr_review", "pr_review_comment", "pr_converted_to_draft", "pr_ready_for_review", "pr_review_requested", "pr_assigned", "pr_labeled", "delete", "release", "workflow_run", "deployment_review", "project_item_added", "project_item_removed", "project_status_changed", "discussion_comment", "discussion_answer"},
+				},
+				"title":       "Excluded Activity Types",
+				"description": "Activity types that are not imported.",
+			},
+			"aggregate_activities": map[string]any{
+				"type":        "boolean",
+				"title":       "Aggregate Activities",
+				"description": "Merge consecutive pushes to the same branch and review comments of the same review into a single activity.",
+				"default":     false,
+			},
+			"include_workflow_runs": map[string]any{
+				"type":        "boolean",
+				"title":       "Include Workflow Runs",
+				"description": "Import GitHub Actions workflow runs you triggered or re-ran in the repositories you were active in and the repositories named exactly in repository_patterns. Private repositories require a token with the actions:read scope.",
+				"default":     false,
+			},
+			"include_deployment_reviews": map[string]any{
+				"type":        "boolean",
+				"title":       "Include Deployment Reviews",
+				"description": "Import the deployment approvals and rejections you made on GitHub Actions workflow runs in the same repositories. Requires one extra request per recent run.",
+				"default":     false,
+			},
+			"include_project_changes": map[string]any{
+				"type":        "boolean",
+				"title":       "Include Project Changes",
+				"description": "Import the issues and pull requests you added to or removed from GitHub Projects, and the status changes you made to them, on the issues and pull requests you were active in. GitHub does not expose changes of other project fields. Requires one extra request per issue or pull request and a token with the read:project scope.",
+				"default":     false,
+			},
+			"include_discussions": map[string]any{
+				"type":        "boolean",
+				"title":       "Include Discussions",
+				"description": "Import the discussion comments and answers you wrote. The Events API does not report discussions, so they are read through the GraphQL API.",
+				"default":     false,
+			},
+			"pagination_max_pages": map[string]any{
+				"type":        "integer",
+				"title":       "Maximum Pages per List",
+				"description": "Maximum number of pages requested from a single list endpoint (events, workflow runs, ...). Listing stops earlier once the target date has been covered.",
+				"minimum":     1,
+				"default":     10,
+			},
+			"pagination_max_items": map[string]any{
+				"type":        "integer",
+				"title":       "Maximum Items per List",
+				"description": "Maximum number of items read from a single list endpoint.",
+				"minimum":     1,
+				"default":     1000,
 			},
 		},
 		Required:    &[]string{"username"},
@@ -76,43 +166,41 @@ func TestConnection(input TestConnectionRequest) error {
 		return err
 	}
 
-	url := fmt.Sprintf("%s/user", core.GithubAPIBaseURL)
-
-	pdk.Log(pdk.LogInfo, fmt.Sprintf("Testing connection to: %s", url))
-
-	body, statusCode, err := authClient.Get(url)
+	checker, err := connection.NewChecker(&connectionHTTPClient{authClient: authClient}, config, logger)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return err
 	}
 
-	pdk.Log(pdk.LogInfo, fmt.Sprintf("Response status: %d", statusCode))
-
-	if statusCode == 200 {
-		pdk.Log(pdk.LogInfo, "Connection test successful")
-		return nil
+	report, err := checker.Check()
+	if err != nil {
+		pdk.Log(pdk.LogError, err.Error())
+		return err
 	}
 
-	var errorMsg string
-	if statusCode == 401 {
-		errorMsg = "Authentication failed: Invalid or expired token"
-	} else if statusCode == 403 {
-		errorMsg = "Access forbidden: Check token permissions"
-	} else if statusCode >= 500 {
-		errorMsg = fmt.Sprintf("GitHub API server error (status %d)", statusCode)
-	} else {
-		errorMsg = fmt.Sprintf("Connection failed with status %d", statusCode)
+	if !report.OK() {
+		pdk.Log(pdk.LogError, report.String())
+		return fmt.Errorf("%s", report.String())
 	}
 
-	var githubError struct {
-		Message string `json:"message"`
-	}
-	if err := json.Unmarshal(body, &githubError); err == nil && githubError.Message != "" {
-		errorMsg = fmt.Sprintf("%s: %s", errorMsg, githubError.Message)
-	}
+	pdk.Log(pdk.LogInfo, report.String())
+	pdk.Log(pdk.LogInfo, "Connection test successful")
+	return nil
+}
 
-	pdk.Log(pdk.LogError, errorMsg)
+type connectionHTTPClient struct {
+	authClient auth.Client
+}
 
-	return fmt.Errorf("%s", errorMsg)
+func (c *connectionHTTPClient) Get(path string) ([]byte, int, map[string]string, error) {
+	url := core.GithubAPIBaseURL + path
+	pdk.Log(pdk.LogDebug, fmt.Sprintf("Testing connection to: %s", url))
+	return c.authClient.GetWithHeaders(url, nil)
+}
+
+func (c *connectionHTTPClient) List(path string, opts paginate.Options, decode paginate.Decoder) (*paginate.Result, error) {
+	url := core.GithubAPIBaseURL + path
+	pdk.Log(pdk.LogDebug, fmt.Sprintf("Testing connection to: %s", url))
+	return paginate.List(c.authClient, url, opts, decode)
 }
 
 // validateConfig checks required fields and repository pattern formats
@@ -127,68 +215,8 @@ func validateConfig(config any) error {
 		return fmt.Errorf("username is required")
 	}
 
-	if patternsInterface, ok := configMap["repository_patterns"]; ok && patternsInterface != nil {
-		patterns, ok := patternsInterface.([]any)
-		if !ok {
-			return fmt.Errorf("repository_patterns must be an array")
-		}
-
-		for i, p := range patterns {
-			patternStr, ok := p.(string)
-			if !ok {
-				return fmt.Errorf("repository_patterns[%d] must be a string", i)
-			}
-
-			if err := validateRepositoryPattern(patternStr); err != nil {
-				return fmt.Errorf("repository pattern at line %d: %w", i+1, err)
-			}
-		}
-	}
-
-	return nil
-}
-
-// validateRepositoryPattern validates a repository pattern format
-func validateRepositoryPattern(pattern string) error {
-	if pattern == "" {
-		return fmt.Errorf("pattern cannot be empty")
-	}
-
-	// Check if pattern contains '/'
-	hasSlash := false
-	for _, ch := range pattern {
-		if ch == '/' {
-			hasSlash = true
-			break
-		}
-	}
-
-	if !hasSlash {
-		return fmt.Errorf("pattern must be in 'owner/repo' format (e.g., 'myorg/*', 'user/repo'). Got: '%s'", pattern)
-	}
-
-	// Split and validate parts
-	parts := []string{}
-	current := ""
-	for _, ch := range pattern {
-		if ch == '/' {
-			if current == "" {
-				return fmt.Errorf("invalid pattern: owner or repo part cannot be empty. Got: '%s'", pattern)
-			}
-			parts = append(parts, current)
-			current = ""
-		} else {
-			current += string(ch)
-		}
-	}
-	if current == "" {
-		return fmt.Errorf("invalid pattern: repo part cannot be empty. Got: '%s'", pattern)
-	}
-	parts = append(parts, current)
-
-	// Should have exactly 2 parts (owner/repo)
-	if len(parts) != 2 {
-		return fmt.Errorf("pattern must have exactly one '/' separator (e.g., 'owner/repo'). Got: '%s'", pattern)
+	if _, err := core.NewRepositoryFilterFromConfig(configMap); err != nil {
+		return err
 	}
 
 	return nil
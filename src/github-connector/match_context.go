@@ -1,18 +1,26 @@
 package main
 
 import (
+	"fmt"
 	"github-connector/internal/core"
 	"github-connector/internal/match"
 )
 
 // MatchContext matches the provided URLs against GitHub URL patterns and returns context nodes.
 // No external API calls are made; context hierarchy is constructed from URL captures alone.
+// URLs of repositories excluded by repository_patterns yield no contexts.
 func MatchContext(input MatchContextRequest) (MatchContextResponse, error) {
+	config, _ := input.Config.(map[string]any)
+	filter, err := core.NewRepositoryFilterFromConfig(config)
+	if err != nil {
+		return MatchContextResponse{}, fmt.Errorf("invalid repository patterns: %w", err)
+	}
+
 	gen := core.NewContextGenerator()
 	results := make([]MatchContextResult, 0, len(input.Urls))
 
 	for _, url := range input.Urls {
-		coreContexts := match.MatchURL(gen, url)
+		coreContexts := match.MatchURL(gen, filter, url)
 		contexts := make([]Context, 0, len(coreContexts))
 		for _, c := range coreContexts {
 			contexts = append(contexts, convertCoreContext(c))
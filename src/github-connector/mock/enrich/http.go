@@ -39,6 +39,81 @@ func (m *MockHTTPClient) EXPECT() *MockHTTPClientMockRecorder {
 	return m.recorder
 }
 
+// FetchBranch mocks base method.
+func (m *MockHTTPClient) FetchBranch(repo, branch string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBranch", repo, branch)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBranch indicates an expected call of FetchBranch.
+func (mr *MockHTTPClientMockRecorder) FetchBranch(repo, branch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBranch", reflect.TypeOf((*MockHTTPClient)(nil).FetchBranch), repo, branch)
+}
+
+// FetchCommit mocks base method.
+func (m *MockHTTPClient) FetchCommit(repo, sha string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCommit", repo, sha)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCommit indicates an expected call of FetchCommit.
+func (mr *MockHTTPClientMockRecorder) FetchCommit(repo, sha any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCommit", reflect.TypeOf((*MockHTTPClient)(nil).FetchCommit), repo, sha)
+}
+
+// FetchComparison mocks base method.
+func (m *MockHTTPClient) FetchComparison(repo, base, head string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchComparison", repo, base, head)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchComparison indicates an expected call of FetchComparison.
+func (mr *MockHTTPClientMockRecorder) FetchComparison(repo, base, head any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchComparison", reflect.TypeOf((*MockHTTPClient)(nil).FetchComparison), repo, base, head)
+}
+
+// FetchDiscussion mocks base method.
+func (m *MockHTTPClient) FetchDiscussion(repo, number string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDiscussion", repo, number)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDiscussion indicates an expected call of FetchDiscussion.
+func (mr *MockHTTPClientMockRecorder) FetchDiscussion(repo, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDiscussion", reflect.TypeOf((*MockHTTPClient)(nil).FetchDiscussion), repo, number)
+}
+
+// FetchGist mocks base method.
+func (m *MockHTTPClient) FetchGist(gistID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchGist", gistID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchGist indicates an expected call of FetchGist.
+func (mr *MockHTTPClientMockRecorder) FetchGist(gistID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchGist", reflect.TypeOf((*MockHTTPClient)(nil).FetchGist), gistID)
+}
+
 // FetchIssue mocks base method.
 func (m *MockHTTPClient) FetchIssue(repo, number string) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -54,6 +129,66 @@ func (mr *MockHTTPClientMockRecorder) FetchIssue(repo, number any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchIssue", reflect.TypeOf((*MockHTTPClient)(nil).FetchIssue), repo, number)
 }
 
+// FetchIssueProjectItems mocks base method.
+func (m *MockHTTPClient) FetchIssueProjectItems(repo, number string) ([]map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchIssueProjectItems", repo, number)
+	ret0, _ := ret[0].([]map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchIssueProjectItems indicates an expected call of FetchIssueProjectItems.
+func (mr *MockHTTPClientMockRecorder) FetchIssueProjectItems(repo, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchIssueProjectItems", reflect.TypeOf((*MockHTTPClient)(nil).FetchIssueProjectItems), repo, number)
+}
+
+// FetchIssueTimeline mocks base method.
+func (m *MockHTTPClient) FetchIssueTimeline(repo, number string) ([]map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchIssueTimeline", repo, number)
+	ret0, _ := ret[0].([]map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchIssueTimeline indicates an expected call of FetchIssueTimeline.
+func (mr *MockHTTPClientMockRecorder) FetchIssueTimeline(repo, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchIssueTimeline", reflect.TypeOf((*MockHTTPClient)(nil).FetchIssueTimeline), repo, number)
+}
+
+// FetchProject mocks base method.
+func (m *MockHTTPClient) FetchProject(ownerType, owner, projectNumber string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchProject", ownerType, owner, projectNumber)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchProject indicates an expected call of FetchProject.
+func (mr *MockHTTPClientMockRecorder) FetchProject(ownerType, owner, projectNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchProject", reflect.TypeOf((*MockHTTPClient)(nil).FetchProject), ownerType, owner, projectNumber)
+}
+
+// FetchProjectItem mocks base method.
+func (m *MockHTTPClient) FetchProjectItem(ownerType, owner, projectNumber, itemID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchProjectItem", ownerType, owner, projectNumber, itemID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchProjectItem indicates an expected call of FetchProjectItem.
+func (mr *MockHTTPClientMockRecorder) FetchProjectItem(ownerType, owner, projectNumber, itemID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchProjectItem", reflect.TypeOf((*MockHTTPClient)(nil).FetchProjectItem), ownerType, owner, projectNumber, itemID)
+}
+
 // FetchPullRequest mocks base method.
 func (m *MockHTTPClient) FetchPullRequest(repo, number string) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -69,6 +204,21 @@ func (mr *MockHTTPClientMockRecorder) FetchPullRequest(repo, number any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPullRequest", reflect.TypeOf((*MockHTTPClient)(nil).FetchPullRequest), repo, number)
 }
 
+// FetchRelease mocks base method.
+func (m *MockHTTPClient) FetchRelease(repo, tag string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRelease", repo, tag)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRelease indicates an expected call of FetchRelease.
+func (mr *MockHTTPClientMockRecorder) FetchRelease(repo, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRelease", reflect.TypeOf((*MockHTTPClient)(nil).FetchRelease), repo, tag)
+}
+
 // FetchRepository mocks base method.
 func (m *MockHTTPClient) FetchRepository(repo string) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -83,3 +233,48 @@ func (mr *MockHTTPClientMockRecorder) FetchRepository(repo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRepository", reflect.TypeOf((*MockHTTPClient)(nil).FetchRepository), repo)
 }
+
+// FetchReviewThread mocks base method.
+func (m *MockHTTPClient) FetchReviewThread(repo, prNumber, commentID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchReviewThread", repo, prNumber, commentID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchReviewThread indicates an expected call of FetchReviewThread.
+func (mr *MockHTTPClientMockRecorder) FetchReviewThread(repo, prNumber, commentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchReviewThread", reflect.TypeOf((*MockHTTPClient)(nil).FetchReviewThread), repo, prNumber, commentID)
+}
+
+// FetchWorkflow mocks base method.
+func (m *MockHTTPClient) FetchWorkflow(repo, workflowFile string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchWorkflow", repo, workflowFile)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchWorkflow indicates an expected call of FetchWorkflow.
+func (mr *MockHTTPClientMockRecorder) FetchWorkflow(repo, workflowFile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWorkflow", reflect.TypeOf((*MockHTTPClient)(nil).FetchWorkflow), repo, workflowFile)
+}
+
+// FetchWorkflowRun mocks base method.
+func (m *MockHTTPClient) FetchWorkflowRun(repo, runID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchWorkflowRun", repo, runID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchWorkflowRun indicates an expected call of FetchWorkflowRun.
+func (mr *MockHTTPClientMockRecorder) FetchWorkflowRun(repo, runID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWorkflowRun", reflect.TypeOf((*MockHTTPClient)(nil).FetchWorkflowRun), repo, runID)
+}
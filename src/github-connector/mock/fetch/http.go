@@ -10,6 +10,7 @@
 package mock_fetch
 
 import (
+	paginate "github-connector/internal/paginate"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -40,16 +41,136 @@ func (m *MockHTTPClient) EXPECT() *MockHTTPClientMockRecorder {
 }
 
 // FetchActivities mocks base method.
-func (m *MockHTTPClient) FetchActivities(username string, page int) ([]map[string]any, error) {
+func (m *MockHTTPClient) FetchActivities(username string, opts paginate.Options) ([]map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchActivities", username, page)
+	ret := m.ctrl.Call(m, "FetchActivities", username, opts)
 	ret0, _ := ret[0].([]map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchActivities indicates an expected call of FetchActivities.
-func (mr *MockHTTPClientMockRecorder) FetchActivities(username, page any) *gomock.Call {
+func (mr *MockHTTPClientMockRecorder) FetchActivities(username, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchActivities", reflect.TypeOf((*MockHTTPClient)(nil).FetchActivities), username, page)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchActivities", reflect.TypeOf((*MockHTTPClient)(nil).FetchActivities), username, opts)
+}
+
+// FetchAuthenticatedUser mocks base method.
+func (m *MockHTTPClient) FetchAuthenticatedUser() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchAuthenticatedUser")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAuthenticatedUser indicates an expected call of FetchAuthenticatedUser.
+func (mr *MockHTTPClientMockRecorder) FetchAuthenticatedUser() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAuthenticatedUser", reflect.TypeOf((*MockHTTPClient)(nil).FetchAuthenticatedUser))
+}
+
+// FetchDiscussionComments mocks base method.
+func (m *MockHTTPClient) FetchDiscussionComments(username string, opts paginate.Options) ([]map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDiscussionComments", username, opts)
+	ret0, _ := ret[0].([]map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDiscussionComments indicates an expected call of FetchDiscussionComments.
+func (mr *MockHTTPClientMockRecorder) FetchDiscussionComments(username, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDiscussionComments", reflect.TypeOf((*MockHTTPClient)(nil).FetchDiscussionComments), username, opts)
+}
+
+// FetchOrgActivities mocks base method.
+func (m *MockHTTPClient) FetchOrgActivities(username, org string, opts paginate.Options) ([]map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchOrgActivities", username, org, opts)
+	ret0, _ := ret[0].([]map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchOrgActivities indicates an expected call of FetchOrgActivities.
+func (mr *MockHTTPClientMockRecorder) FetchOrgActivities(username, org, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchOrgActivities", reflect.TypeOf((*MockHTTPClient)(nil).FetchOrgActivities), username, org, opts)
+}
+
+// FetchProjectTimeline mocks base method.
+func (m *MockHTTPClient) FetchProjectTimeline(repo string, number int, since string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchProjectTimeline", repo, number, since)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchProjectTimeline indicates an expected call of FetchProjectTimeline.
+func (mr *MockHTTPClientMockRecorder) FetchProjectTimeline(repo, number, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchProjectTimeline", reflect.TypeOf((*MockHTTPClient)(nil).FetchProjectTimeline), repo, number, since)
+}
+
+// FetchRepository mocks base method.
+func (m *MockHTTPClient) FetchRepository(repo string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRepository", repo)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRepository indicates an expected call of FetchRepository.
+func (mr *MockHTTPClientMockRecorder) FetchRepository(repo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRepository", reflect.TypeOf((*MockHTTPClient)(nil).FetchRepository), repo)
+}
+
+// FetchRunApprovals mocks base method.
+func (m *MockHTTPClient) FetchRunApprovals(repo string, runID int64) ([]map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRunApprovals", repo, runID)
+	ret0, _ := ret[0].([]map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRunApprovals indicates an expected call of FetchRunApprovals.
+func (mr *MockHTTPClientMockRecorder) FetchRunApprovals(repo, runID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRunApprovals", reflect.TypeOf((*MockHTTPClient)(nil).FetchRunApprovals), repo, runID)
+}
+
+// FetchUserOrganizations mocks base method.
+func (m *MockHTTPClient) FetchUserOrganizations() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchUserOrganizations")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchUserOrganizations indicates an expected call of FetchUserOrganizations.
+func (mr *MockHTTPClientMockRecorder) FetchUserOrganizations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUserOrganizations", reflect.TypeOf((*MockHTTPClient)(nil).FetchUserOrganizations))
+}
+
+// FetchWorkflowRuns mocks base method.
+func (m *MockHTTPClient) FetchWorkflowRuns(repo, actor, created string, opts paginate.Options) ([]map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchWorkflowRuns", repo, actor, created, opts)
+	ret0, _ := ret[0].([]map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchWorkflowRuns indicates an expected call of FetchWorkflowRuns.
+func (mr *MockHTTPClientMockRecorder) FetchWorkflowRuns(repo, actor, created, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWorkflowRuns", reflect.TypeOf((*MockHTTPClient)(nil).FetchWorkflowRuns), repo, actor, created, opts)
 }
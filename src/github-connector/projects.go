@@ -0,0 +1,275 @@
+package main
+
+import (
+	"fmt"
+	"github-connector/internal/core"
+	"strconv"
+	"strings"
+)
+
+// projectTimelineQuery lists the project (v2) events on the timeline of an issue or pull request.
+// These are the only project changes GitHub exposes per user. The issue and pull request timeline
+// connections are different types, so the same selection is declared for both.
+const projectTimelineQuery = `query($owner: String!, $name: String!, $number: Int!, $since: DateTime) {
+  repository(owner: $owner, name: $name) {
+    issueOrPullRequest(number: $number) {
+      __typename
+      ... on Issue {
+        title url
+        timelineItems(first: 100, since: $since, itemTypes: [ADDED_TO_PROJECT_V2_EVENT, REMOVED_FROM_PROJECT_V2_EVENT, PROJECT_V2_ITEM_STATUS_CHANGED_EVENT]) { ...issueProjectEvents }
+      }
+      ... on PullRequest {
+        title url
+        timelineItems(first: 100, since: $since, itemTypes: [ADDED_TO_PROJECT_V2_EVENT, REMOVED_FROM_PROJECT_V2_EVENT, PROJECT_V2_ITEM_STATUS_CHANGED_EVENT]) { ...pullRequestProjectEvents }
+      }
+    }
+  }
+}
+
+fragment issueProjectEvents on IssueTimelineItemsConnection {
+  nodes { __typename ...projectEvent }
+}
+
+fragment pullRequestProjectEvents on PullRequestTimelineItemsConnection {
+  nodes { __typename ...projectEvent }
+}
+
+fragment projectEvent on Node {
+  ... on AddedToProjectV2Event { id createdAt wasAutomated actor { login } project { ...projectSummary } }
+  ... on RemovedFromProjectV2Event { id createdAt wasAutomated actor { login } project { ...projectSummary } }
+  ... on ProjectV2ItemStatusChangedEvent { id createdAt wasAutomated actor { login } previousStatus status project { ...projectSummary } }
+}
+
+fragment projectSummary on ProjectV2 {
+  number title url
+  owner { __typename ... on Organization { login } ... on User { login } }
+}`
+
+func (c *fetchHTTPClient) FetchProjectTimeline(repo string, number int, since string) (map[string]any, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository name: %s", repo)
+	}
+
+	var data struct {
+		Repository struct {
+			IssueOrPullRequest map[string]any `json:"issueOrPullRequest"`
+		} `json:"repository"`
+	}
+	variables := map[string]any{"owner": owner, "name": name, "number": number, "since": since}
+	if err := graphQL(c.authClient, projectTimelineQuery, variables, &data); err != nil {
+		return nil, err
+	}
+	if data.Repository.IssueOrPullRequest == nil {
+		return nil, fmt.Errorf("%s #%d not found", repo, number)
+	}
+
+	return data.Repository.IssueOrPullRequest, nil
+}
+
+// projectQuery fetches a project (v2) with the names of its fields. %s is the owner field,
+// "organization" or "user".
+const projectQuery = `query($login: String!, $number: Int!) {
+  %s(login: $login) {
+    projectV2(number: $number) {
+      title shortDescription url closed public createdAt updatedAt
+      items { totalCount }
+      fields(first: 50) { nodes { ... on ProjectV2FieldCommon { name dataType } } }
+    }
+  }
+}`
+
+func (c *enrichHTTPClient) FetchProject(ownerType, owner, projectNumber string) (map[string]any, error) {
+	number, err := strconv.Atoi(projectNumber)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project number: %s", projectNumber)
+	}
+
+	var data map[string]struct {
+		ProjectV2 map[string]any `json:"projectV2"`
+	}
+	variables := map[string]any{"login": owner, "number": number}
+	if err := graphQL(c.authClient, fmt.Sprintf(projectQuery, projectOwnerField(ownerType)), variables, &data); err != nil {
+		return nil, err
+	}
+	project := data[projectOwnerField(ownerType)].ProjectV2
+	if project == nil {
+		return nil, fmt.Errorf("project %s #%s not found", owner, projectNumber)
+	}
+
+	return project, nil
+}
+
+// projectItemIDsQuery lists the node IDs of a project's items so that an item can be looked up by
+// the database ID found in its URL
+const projectItemIDsQuery = `query($login: String!, $number: Int!, $after: String) {
+  %s(login: $login) {
+    projectV2(number: $number) {
+      items(first: 100, after: $after) {
+        pageInfo { hasNextPage endCursor }
+        nodes { id databaseId }
+      }
+    }
+  }
+}`
+
+// projectItemQuery fetches a project item with its content and field values
+const projectItemQuery = `query($id: ID!) {
+  node(id: $id) {
+    ... on ProjectV2Item {
+      databaseId isArchived createdAt updatedAt
+      project { number title url }
+      content {
+        __typename
+        ... on Issue { number title body url state repository { nameWithOwner } }
+        ... on PullRequest { number title body url state repository { nameWithOwner } }
+        ... on DraftIssue { title body }
+      }
+      fieldValues(first: 50) { nodes { ...projectFieldValue } }
+    }
+  }
+}
+
+fragment projectFieldValue on ProjectV2ItemFieldValue {
+  __typename
+  ... on ProjectV2ItemFieldSingleSelectValue { name field { ... on ProjectV2FieldCommon { name } } }
+  ... on ProjectV2ItemFieldIterationValue { title startDate duration field { ... on ProjectV2FieldCommon { name } } }
+  ... on ProjectV2ItemFieldTextValue { text field { ... on ProjectV2FieldCommon { name } } }
+  ... on ProjectV2ItemFieldNumberValue { number field { ... on ProjectV2FieldCommon { name } } }
+  ... on ProjectV2ItemFieldDateValue { date field { ... on ProjectV2FieldCommon { name } } }
+}`
+
+// maxProjectItemPages bounds the pages of project items scanned for a single item
+const maxProjectItemPages = 10
+
+func (c *enrichHTTPClient) FetchProjectItem(ownerType, owner, projectNumber, itemID string) (map[string]any, error) {
+	number, err := strconv.Atoi(projectNumber)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project number: %s", projectNumber)
+	}
+	id, err := strconv.ParseInt(itemID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project item id: %s", itemID)
+	}
+
+	field := projectOwnerField(ownerType)
+	query := fmt.Sprintf(projectItemIDsQuery, field)
+	variables := map[string]any{"login": owner, "number": number}
+	for page := 0; page < maxProjectItemPages; page++ {
+		var data map[string]struct {
+			ProjectV2 *struct {
+				Items struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						ID         string `json:"id"`
+						DatabaseID int64  `json:"databaseId"`
+					} `json:"nodes"`
+				} `json:"items"`
+			} `json:"projectV2"`
+		}
+		if err := graphQL(c.authClient, query, variables, &data); err != nil {
+			return nil, err
+		}
+		project := data[field].ProjectV2
+		if project == nil {
+			return nil, fmt.Errorf("project %s #%s not found", owner, projectNumber)
+		}
+
+		for _, node := range project.Items.Nodes {
+			if node.DatabaseID == id {
+				return c.fetchProjectItemNode(node.ID)
+			}
+		}
+		if !project.Items.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = project.Items.PageInfo.EndCursor
+	}
+
+	return nil, fmt.Errorf("item %s not found in project %s #%s", itemID, owner, projectNumber)
+}
+
+func (c *enrichHTTPClient) fetchProjectItemNode(nodeID string) (map[string]any, error) {
+	var data struct {
+		Node map[string]any `json:"node"`
+	}
+	if err := graphQL(c.authClient, projectItemQuery, map[string]any{"id": nodeID}, &data); err != nil {
+		return nil, err
+	}
+	if data.Node == nil {
+		return nil, fmt.Errorf("project item %s not found", nodeID)
+	}
+	return data.Node, nil
+}
+
+// issueProjectItemsQuery lists the project items of an issue or pull request
+const issueProjectItemsQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    issueOrPullRequest(number: $number) {
+      ... on Issue { projectItems(first: 20) { ...projectItems } }
+      ... on PullRequest { projectItems(first: 20) { ...projectItems } }
+    }
+  }
+}
+
+fragment projectItems on ProjectV2ItemConnection {
+  nodes {
+    databaseId isArchived
+    project {
+      number title url
+      owner { __typename ... on Organization { login } ... on User { login } }
+    }
+    fieldValues(first: 50) { nodes { ...projectFieldValue } }
+  }
+}
+
+fragment projectFieldValue on ProjectV2ItemFieldValue {
+  __typename
+  ... on ProjectV2ItemFieldSingleSelectValue { name field { ... on ProjectV2FieldCommon { name } } }
+  ... on ProjectV2ItemFieldIterationValue { title startDate duration field { ... on ProjectV2FieldCommon { name } } }
+  ... on ProjectV2ItemFieldTextValue { text field { ... on ProjectV2FieldCommon { name } } }
+  ... on ProjectV2ItemFieldNumberValue { number field { ... on ProjectV2FieldCommon { name } } }
+  ... on ProjectV2ItemFieldDateValue { date field { ... on ProjectV2FieldCommon { name } } }
+}`
+
+func (c *enrichHTTPClient) FetchIssueProjectItems(repo, number string) ([]map[string]any, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository name: %s", repo)
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue number: %s", number)
+	}
+
+	var data struct {
+		Repository struct {
+			IssueOrPullRequest struct {
+				ProjectItems struct {
+					Nodes []map[string]any `json:"nodes"`
+				} `json:"projectItems"`
+			} `json:"issueOrPullRequest"`
+		} `json:"repository"`
+	}
+	variables := map[string]any{"owner": owner, "name": name, "number": n}
+	if err := graphQL(c.authClient, issueProjectItemsQuery, variables, &data); err != nil {
+		return nil, err
+	}
+
+	items := data.Repository.IssueOrPullRequest.ProjectItems.Nodes
+	if items == nil {
+		items = []map[string]any{}
+	}
+	return items, nil
+}
+
+// projectOwnerField returns the GraphQL root field of a project owner type
+func projectOwnerField(ownerType string) string {
+	if ownerType == core.ProjectOwnerUser {
+		return "user"
+	}
+	return "organization"
+}
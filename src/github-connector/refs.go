@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github-connector/internal/core"
+	neturl "net/url"
+)
+
+func (c *enrichHTTPClient) FetchCommit(repo, sha string) (map[string]any, error) {
+	url := fmt.Sprintf("%s/repos/%s/commits/%s", core.GithubAPIBaseURL, repo, sha)
+	body, status, err := c.authClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	if status != 200 {
+		return nil, fmt.Errorf("GitHub API error (status %d): %s", status, string(body))
+	}
+
+	var apiResp map[string]any
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse API response: %w", err)
+	}
+
+	return apiResp, nil
+}
+
+// FetchBranch returns nil without an error when the branch does not exist
+func (c *enrichHTTPClient) FetchBranch(repo, branch string) (map[string]any, error) {
+	url := fmt.Sprintf("%s/repos/%s/branches/%s", core.GithubAPIBaseURL, repo, neturl.PathEscape(branch))
+	body, status, err := c.authClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	if status == 404 {
+		return nil, nil
+	}
+	if status != 200 {
+		return nil, fmt.Errorf("GitHub API error (status %d): %s", status, string(body))
+	}
+
+	var apiResp map[string]any
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse API response: %w", err)
+	}
+
+	return apiResp, nil
+}
+
+func (c *enrichHTTPClient) FetchComparison(repo, base, head string) (map[string]any, error) {
+	// Only the counts are used, so the commit list is limited to a single entry
+	url := fmt.Sprintf("%s/repos/%s/compare/%s...%s?per_page=1", core.GithubAPIBaseURL, repo, neturl.PathEscape(base), neturl.PathEscape(head))
+	body, status, err := c.authClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	if status != 200 {
+		return nil, fmt.Errorf("GitHub API error (status %d): %s", status, string(body))
+	}
+
+	var apiResp map[string]any
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse API response: %w", err)
+	}
+
+	return apiResp, nil
+}
+
+func (c *enrichHTTPClient) FetchRelease(repo, tag string) (map[string]any, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases/tags/%s", core.GithubAPIBaseURL, repo, neturl.PathEscape(tag))
+	body, status, err := c.authClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	if status != 200 {
+		return nil, fmt.Errorf("GitHub API error (status %d): %s", status, string(body))
+	}
+
+	var apiResp map[string]any
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse API response: %w", err)
+	}
+
+	return apiResp, nil
+}
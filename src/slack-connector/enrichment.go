@@ -1,8 +1,8 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
+	"net/url"
 	"slack-connector/internal/core"
 	"slack-connector/internal/enrich"
 
@@ -27,6 +27,7 @@ func EnrichContext(input EnrichRequest) (EnrichResponse, error) {
 	if !ok {
 		return EnrichResponse{}, fmt.Errorf("invalid configuration format")
 	}
+	useAuthConfig(config)
 
 	enricher, err := enrich.NewContextEnricher(&enrichHTTPClient{}, contextType, config, enrichmentParams, logger)
 	if err != nil {
@@ -102,56 +103,34 @@ func extractEnrichmentParams(metadata any) (map[string]any, error) {
 type enrichHTTPClient struct{}
 
 func (c *enrichHTTPClient) FetchChannel(token, channelID string) (map[string]any, error) {
-	// Call Slack API: GET /conversations.info?channel={channel_id}
-	url := fmt.Sprintf("%s/conversations.info?channel=%s", core.SlackAPIBaseURL, channelID)
-	req := pdk.NewHTTPRequest(pdk.MethodGet, url)
-	req.SetHeader("Authorization", "Bearer "+token)
-	req.SetHeader("Content-Type", "application/json")
-
-	res := req.Send()
-	if res.Status() != 200 {
-		body := string(res.Body())
-		return nil, fmt.Errorf("Slack API error (status %d): %s", res.Status(), body)
-	}
-
-	var apiResp map[string]any
-	if err := json.Unmarshal(res.Body(), &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse API response: %w", err)
-	}
+	return fetchChannel(token, channelID)
+}
 
-	// Check if API call was successful
-	ok, _ := apiResp["ok"].(bool)
-	if !ok {
-		errorMsg := getStringValue(apiResp, "error")
-		return nil, fmt.Errorf("Slack API error: %s", errorMsg)
-	}
+func (c *enrichHTTPClient) FetchTeam(token, teamID string) (map[string]any, error) {
+	return fetchTeam(token, teamID)
+}
 
-	return apiResp, nil
+func (c *enrichHTTPClient) FetchAuth(token string) (map[string]any, error) {
+	return slackGet(token, "auth.test", nil)
 }
 
-func (c *enrichHTTPClient) FetchThread(token, channelID, threadTS string) (map[string]any, error) {
-	// Call Slack API: GET /conversations.replies?channel={channel_id}&ts={thread_ts}&limit=1
-	url := fmt.Sprintf("%s/conversations.replies?channel=%s&ts=%s&limit=1", core.SlackAPIBaseURL, channelID, threadTS)
-	req := pdk.NewHTTPRequest(pdk.MethodGet, url)
-	req.SetHeader("Authorization", "Bearer "+token)
-	req.SetHeader("Content-Type", "application/json")
-
-	res := req.Send()
-	if res.Status() != 200 {
-		body := string(res.Body())
-		return nil, fmt.Errorf("Slack API error (status %d): %s", res.Status(), body)
-	}
+func (c *enrichHTTPClient) FetchUser(token, userID string) (map[string]any, error) {
+	return fetchUser(token, userID)
+}
 
-	var apiResp map[string]any
-	if err := json.Unmarshal(res.Body(), &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse API response: %w", err)
-	}
+func (c *enrichHTTPClient) FetchUserGroups(token string) (map[string]any, error) {
+	return fetchUserGroups(token)
+}
 
-	ok, _ := apiResp["ok"].(bool)
-	if !ok {
-		errorMsg := getStringValue(apiResp, "error")
-		return nil, fmt.Errorf("Slack API error: %s", errorMsg)
-	}
+func (c *enrichHTTPClient) FetchMembers(token, channelID string) (map[string]any, error) {
+	return fetchMembers(token, channelID)
+}
 
-	return apiResp, nil
+func (c *enrichHTTPClient) FetchThread(token, channelID, threadTS, cursor string) (map[string]any, error) {
+	return slackGet(token, "conversations.replies", url.Values{
+		"channel": {channelID},
+		"ts":      {threadTS},
+		"limit":   {"200"},
+		"cursor":  {cursor},
+	})
 }
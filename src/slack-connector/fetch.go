@@ -1,12 +1,11 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/url"
 	"slack-connector/internal/core"
 	"slack-connector/internal/fetch"
-	"time"
+	"strconv"
 
 	"github.com/extism/go-pdk"
 )
@@ -20,6 +19,7 @@ func FetchActivities(input FetchRequest) (FetchResponse, error) {
 	if !ok {
 		return FetchResponse{}, fmt.Errorf("invalid configuration format")
 	}
+	useAuthConfig(config)
 
 	fetcher, err := fetch.NewActivityFetcher(&fetchHTTPClient{}, config, input.Params.TargetDate, logger)
 	if err != nil {
@@ -68,53 +68,92 @@ func convertActivity(activity *fetch.Activity) Activity {
 
 type fetchHTTPClient struct{}
 
-func (c *fetchHTTPClient) FetchMessages(token, userID, targetDate string, page int) (map[string]any, error) {
-	queryDate, err := formatDateForQuery(targetDate)
-	if err != nil {
-		return nil, fmt.Errorf("invalid target date: %w", err)
-	}
+func (c *fetchHTTPClient) FetchMessages(token, query, teamID string, page int) (map[string]any, error) {
+	return slackGet(token, "search.messages", url.Values{
+		"query":   {query},
+		"count":   {"100"},
+		"page":    {strconv.Itoa(page)},
+		"team_id": {teamID},
+	})
+}
 
-	query := fmt.Sprintf("from:@%s on:%s", userID, queryDate)
-	url := fmt.Sprintf("%s/search.messages?query=%s&count=100&page=%d", core.SlackAPIBaseURL, url.QueryEscape(query), page)
+func (c *fetchHTTPClient) FetchUserConversations(token, userID, teamID, cursor string) (map[string]any, error) {
+	return slackGet(token, "users.conversations", url.Values{
+		"user":             {userID},
+		"types":            {"public_channel,private_channel,mpim,im"},
+		"exclude_archived": {"true"},
+		"limit":            {"200"},
+		"cursor":           {cursor},
+		"team_id":          {teamID},
+	})
+}
 
-	logger.Debug(fmt.Sprintf("Fetching page %d: %s", page, url))
+func (c *fetchHTTPClient) FetchHistory(token, channelID, oldest, latest, cursor string) (map[string]any, error) {
+	return slackGet(token, "conversations.history", url.Values{
+		"channel":   {channelID},
+		"oldest":    {oldest},
+		"latest":    {latest},
+		"inclusive": {"true"},
+		"limit":     {"200"},
+		"cursor":    {cursor},
+	})
+}
 
-	req := pdk.NewHTTPRequest(pdk.MethodGet, url)
-	req.SetHeader("Authorization", "Bearer "+token)
-	req.SetHeader("Content-Type", "application/json")
+func (c *fetchHTTPClient) FetchReplies(token, channelID, threadTS, oldest, latest, cursor string) (map[string]any, error) {
+	return slackGet(token, "conversations.replies", url.Values{
+		"channel":   {channelID},
+		"ts":        {threadTS},
+		"oldest":    {oldest},
+		"latest":    {latest},
+		"inclusive": {"true"},
+		"limit":     {"200"},
+		"cursor":    {cursor},
+	})
+}
 
-	res := req.Send()
+func (c *fetchHTTPClient) FetchReactions(token, userID, teamID, cursor string) (map[string]any, error) {
+	return slackGet(token, "reactions.list", url.Values{
+		"user":    {userID},
+		"full":    {"true"},
+		"limit":   {"100"},
+		"cursor":  {cursor},
+		"team_id": {teamID},
+	})
+}
 
-	if res.Status() != 200 {
-		body := string(res.Body())
-		return nil, fmt.Errorf("Slack API error: HTTP %d, body: %s", res.Status(), body)
-	}
+func (c *fetchHTTPClient) FetchFiles(token, userID, teamID, tsFrom, tsTo string, page int) (map[string]any, error) {
+	return slackGet(token, "files.list", url.Values{
+		"user":    {userID},
+		"ts_from": {tsFrom},
+		"ts_to":   {tsTo},
+		"count":   {"100"},
+		"page":    {strconv.Itoa(page)},
+		"team_id": {teamID},
+	})
+}
 
-	var apiResp map[string]any
-	if err := json.Unmarshal(res.Body(), &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse API response: %w", err)
-	}
+func (c *fetchHTTPClient) FetchPins(token, channelID string) (map[string]any, error) {
+	return slackGet(token, "pins.list", url.Values{
+		"channel": {channelID},
+	})
+}
 
-	return apiResp, nil
+func (c *fetchHTTPClient) FetchTeam(token, teamID string) (map[string]any, error) {
+	return fetchTeam(token, teamID)
 }
 
-// formatDateForQuery formats the target date for Slack search query
-// Input: "2025-12-13T00:00:00Z" or "2025-12-13"
-// Output: "2025-12-13"
-func formatDateForQuery(targetDate string) (string, error) {
-	var t time.Time
-	var err error
+func (c *fetchHTTPClient) FetchChannel(token, channelID string) (map[string]any, error) {
+	return fetchChannel(token, channelID)
+}
 
-	// Try RFC3339 format first
-	t, err = time.Parse(time.RFC3339, targetDate)
-	if err != nil {
-		// Fallback to date-only format
-		t, err = time.Parse("2006-01-02", targetDate)
-		if err != nil {
-			return "", err
-		}
-	}
+func (c *fetchHTTPClient) FetchUser(token, userID string) (map[string]any, error) {
+	return fetchUser(token, userID)
+}
+
+func (c *fetchHTTPClient) FetchUserGroups(token string) (map[string]any, error) {
+	return fetchUserGroups(token)
+}
 
-	// Return in YYYY-MM-DD format
-	return t.Format("2006-01-02"), nil
+func (c *fetchHTTPClient) FetchMembers(token, channelID string) (map[string]any, error) {
+	return fetchMembers(token, channelID)
 }
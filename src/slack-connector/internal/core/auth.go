@@ -0,0 +1,39 @@
+package core
+
+import "fmt"
+
+// Auth method IDs, reported by the host as active_auth_method
+const (
+	// AuthMethodToken uses a user token entered as user_oauth_token
+	AuthMethodToken = "token"
+	// AuthMethodOAuthWeb uses the tokens the host stored as oauth_access_token and
+	// oauth_refresh_token after the OAuth web flow
+	AuthMethodOAuthWeb = "oauth_web"
+)
+
+// TokenFromConfig returns the user token of the active auth method
+func TokenFromConfig(cfg map[string]any) (string, error) {
+	if cfg["active_auth_method"] == AuthMethodOAuthWeb {
+		token, ok := cfg["oauth_access_token"].(string)
+		if !ok || token == "" {
+			return "", fmt.Errorf("not connected via OAuth: please connect via Slack (OAuth) first")
+		}
+		return token, nil
+	}
+
+	token, ok := cfg["user_oauth_token"].(string)
+	if !ok || token == "" {
+		return "", fmt.Errorf("missing user_oauth_token")
+	}
+	return token, nil
+}
+
+// RefreshTokenFromConfig returns the refresh token stored after the OAuth web flow, or "" when
+// the token does not rotate or another auth method is active
+func RefreshTokenFromConfig(cfg map[string]any) string {
+	if cfg["active_auth_method"] != AuthMethodOAuthWeb {
+		return ""
+	}
+	refreshToken, _ := cfg["oauth_refresh_token"].(string)
+	return refreshToken
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenFromConfig(t *testing.T) {
+	tests := []struct {
+		name             string
+		cfg              map[string]any
+		wantToken        string
+		wantRefreshToken string
+		wantErr          bool
+	}{
+		{
+			name:      "user token",
+			cfg:       map[string]any{"user_oauth_token": "xoxp-token"},
+			wantToken: "xoxp-token",
+		},
+		{
+			name: "user token - explicit auth method",
+			cfg: map[string]any{
+				"active_auth_method":  "token",
+				"user_oauth_token":    "xoxp-token",
+				"oauth_access_token":  "xoxe.xoxp-stale",
+				"oauth_refresh_token": "xoxe-1-stale",
+			},
+			wantToken: "xoxp-token",
+		},
+		{
+			name: "oauth web flow",
+			cfg: map[string]any{
+				"active_auth_method":  "oauth_web",
+				"user_oauth_token":    "xoxp-token",
+				"oauth_access_token":  "xoxe.xoxp-access",
+				"oauth_refresh_token": "xoxe-1-refresh",
+			},
+			wantToken:        "xoxe.xoxp-access",
+			wantRefreshToken: "xoxe-1-refresh",
+		},
+		{
+			name: "oauth web flow - without token rotation",
+			cfg: map[string]any{
+				"active_auth_method": "oauth_web",
+				"oauth_access_token": "xoxp-access",
+			},
+			wantToken: "xoxp-access",
+		},
+		{
+			name: "oauth web flow - not connected",
+			cfg: map[string]any{
+				"active_auth_method": "oauth_web",
+				"user_oauth_token":   "xoxp-token",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "missing user_oauth_token",
+			cfg:     map[string]any{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := TokenFromConfig(tt.cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantToken, token)
+			if !tt.wantErr {
+				assert.Equal(t, tt.wantRefreshToken, RefreshTokenFromConfig(tt.cfg))
+			}
+		})
+	}
+}
@@ -22,37 +22,62 @@ type Context struct {
 // ContextGenerator provides factory methods for creating standardized Context objects
 type ContextGenerator struct {
 	connectorID string
+	team        Team
 }
 
-// NewContextGenerator creates a new ContextGenerator
+// NewContextGenerator creates a new ContextGenerator for a single workspace
 func NewContextGenerator() *ContextGenerator {
 	return &ContextGenerator{
 		connectorID: ConnectorID,
 	}
 }
 
-// CreateSourceContext creates a source context for Slack
+// NewTeamContextGenerator creates a ContextGenerator for one of several workspaces. Context IDs
+// are namespaced by the team, and the team is passed on to enrichment.
+func NewTeamContextGenerator(team Team) *ContextGenerator {
+	return &ContextGenerator{
+		connectorID: ConnectorID,
+		team:        team,
+	}
+}
+
+// Team returns the workspace of the generated contexts; it is empty for a single workspace
+func (g *ContextGenerator) Team() Team {
+	return g.team
+}
+
+// CreateSourceContext creates a source context for Slack, or for the workspace of the generator
 func (g *ContextGenerator) CreateSourceContext() *Context {
-	id := MakeSourceContextID()
+	id := MakeSourceContextID(g.team.ID)
+	title := "Slack"
+	description := "Activity source from Slack"
+	url := "https://slack.com"
+	if g.team.ID != "" {
+		title = fmt.Sprintf("Slack: %s", g.team.Label())
+		description = fmt.Sprintf("Activity source from Slack workspace %s", g.team.Label())
+		if teamURL := g.team.URL(); teamURL != "" {
+			url = teamURL
+		}
+	}
 	return &Context{
 		Id:           id,
 		Name:         id,
 		ParentId:     "", // Top level - no parent
 		ConnectorId:  g.connectorID,
 		ResourceType: ResourceTypeSource,
-		Title:        ptrString("Slack"),
-		Description:  ptrString("Activity source from Slack"),
-		Url:          ptrString("https://slack.com"),
+		Title:        ptrString(title),
+		Description:  ptrString(description),
+		Url:          ptrString(url),
 		Metadata: map[string]any{
-			"enrichment_params": map[string]any{},
+			"enrichment_params": g.enrichmentParams(map[string]any{}),
 		},
 	}
 }
 
 // CreateChannelContext creates a channel context
 func (g *ContextGenerator) CreateChannelContext(channelID, channelName string) *Context {
-	id := MakeChannelContextID(channelID)
-	parentID := MakeSourceContextID()
+	id := MakeChannelContextID(g.team.ID, channelID)
+	parentID := MakeSourceContextID(g.team.ID)
 	return &Context{
 		Id:           id,
 		Name:         fmt.Sprintf("channel #%s", channelName),
@@ -61,17 +86,37 @@ func (g *ContextGenerator) CreateChannelContext(channelID, channelName string) *
 		ResourceType: ResourceTypeChannel,
 		Title:        ptrString(fmt.Sprintf("#%s", channelName)),
 		Metadata: map[string]any{
-			"enrichment_params": map[string]any{
+			"enrichment_params": g.enrichmentParams(map[string]any{
+				"channel_id": channelID,
+			}),
+		},
+	}
+}
+
+// CreateDirectMessageContext creates the context of a DM or group DM. It is a channel context
+// titled after the participants (e.g., "DM with Alice" or "Group DM: Alice, Bob").
+func (g *ContextGenerator) CreateDirectMessageContext(channelID, title string) *Context {
+	id := MakeChannelContextID(g.team.ID, channelID)
+	parentID := MakeSourceContextID(g.team.ID)
+	return &Context{
+		Id:           id,
+		Name:         title,
+		ParentId:     parentID,
+		ConnectorId:  g.connectorID,
+		ResourceType: ResourceTypeChannel,
+		Title:        ptrString(title),
+		Metadata: map[string]any{
+			"enrichment_params": g.enrichmentParams(map[string]any{
 				"channel_id": channelID,
-			},
+			}),
 		},
 	}
 }
 
 // CreateThreadContext creates a thread context
 func (g *ContextGenerator) CreateThreadContext(channelID, threadTS string) *Context {
-	id := MakeThreadContextID(channelID, threadTS)
-	parentID := MakeChannelContextID(channelID)
+	id := MakeThreadContextID(g.team.ID, channelID, threadTS)
+	parentID := MakeChannelContextID(g.team.ID, channelID)
 	return &Context{
 		Id:           id,
 		Name:         fmt.Sprintf("Thread %s", threadTS),
@@ -80,14 +125,26 @@ func (g *ContextGenerator) CreateThreadContext(channelID, threadTS string) *Cont
 		ResourceType: ResourceTypeThread,
 		Title:        ptrString(fmt.Sprintf("Thread %s", threadTS)),
 		Metadata: map[string]any{
-			"enrichment_params": map[string]any{
+			"enrichment_params": g.enrichmentParams(map[string]any{
 				"channel_id": channelID,
 				"thread_ts":  threadTS,
-			},
+			}),
 		},
 	}
 }
 
+// enrichmentParams adds the team ID and domain of the generator to the enrichment params, so
+// that enrichment builds URLs on the domain of the workspace
+func (g *ContextGenerator) enrichmentParams(params map[string]any) map[string]any {
+	if g.team.ID != "" {
+		params["team_id"] = g.team.ID
+	}
+	if g.team.Domain != "" {
+		params["team_domain"] = g.team.Domain
+	}
+	return params
+}
+
 // GetStringValue safely extracts string value from map
 func GetStringValue(m map[string]any, key string) string {
 	if val, ok := m[key]; ok {
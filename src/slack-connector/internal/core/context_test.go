@@ -25,6 +25,28 @@ func TestCreateSourceContext(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestCreateSourceContext_Team(t *testing.T) {
+	g := NewTeamContextGenerator(Team{ID: "T0123ABC", Name: "Acme Engineering", Domain: "acme-eng.slack.com"})
+	got := g.CreateSourceContext()
+	want := &Context{
+		Id:           "slack:source:T0123ABC",
+		Name:         "slack:source:T0123ABC",
+		ParentId:     "",
+		ConnectorId:  "slack",
+		ResourceType: "source",
+		Title:        ptrString("Slack: Acme Engineering"),
+		Description:  ptrString("Activity source from Slack workspace Acme Engineering"),
+		Url:          ptrString("https://acme-eng.slack.com"),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"team_id":     "T0123ABC",
+				"team_domain": "acme-eng.slack.com",
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
 func TestCreateChannelContext(t *testing.T) {
 	g := NewContextGenerator()
 	got := g.CreateChannelContext("C1234567890", "general")
@@ -44,6 +66,25 @@ func TestCreateChannelContext(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestCreateDirectMessageContext(t *testing.T) {
+	g := NewContextGenerator()
+	got := g.CreateDirectMessageContext("D1234567890", "DM with Alice")
+	want := &Context{
+		Id:           "slack:channel:D1234567890",
+		Name:         "DM with Alice",
+		ParentId:     "slack:source",
+		ConnectorId:  "slack",
+		ResourceType: "channel",
+		Title:        ptrString("DM with Alice"),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"channel_id": "D1234567890",
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
 func TestCreateThreadContext(t *testing.T) {
 	g := NewContextGenerator()
 	got := g.CreateThreadContext("C1234567890", "1623855600.000200")
@@ -63,3 +104,24 @@ func TestCreateThreadContext(t *testing.T) {
 	}
 	assert.Equal(t, want, got)
 }
+
+func TestCreateThreadContext_Team(t *testing.T) {
+	g := NewTeamContextGenerator(Team{ID: "T0123ABC"})
+	got := g.CreateThreadContext("C1234567890", "1623855600.000200")
+	want := &Context{
+		Id:           "slack:thread:T0123ABC:C1234567890:1623855600.000200",
+		Name:         "Thread 1623855600.000200",
+		ParentId:     "slack:channel:T0123ABC:C1234567890",
+		ConnectorId:  "slack",
+		ResourceType: "thread",
+		Title:        ptrString("Thread 1623855600.000200"),
+		Metadata: map[string]any{
+			"enrichment_params": map[string]any{
+				"channel_id": "C1234567890",
+				"thread_ts":  "1623855600.000200",
+				"team_id":    "T0123ABC",
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
@@ -21,17 +21,49 @@ func MakeActivityID(messageTS string) string {
 	return fmt.Sprintf("%s:%s", ConnectorID, messageTS)
 }
 
-// MakeSourceContextID creates a source context ID with connector prefix
-func MakeSourceContextID() string {
-	return fmt.Sprintf("%s:%s", ConnectorID, ResourceTypeSource)
+// MakeReactionActivityID creates the ID of a reaction the user added to a message
+func MakeReactionActivityID(channelID, messageTS, reaction string) string {
+	return fmt.Sprintf("%s:reaction:%s:%s:%s", ConnectorID, channelID, messageTS, reaction)
 }
 
-// MakeChannelContextID creates a channel context ID with connector prefix
-func MakeChannelContextID(channelID string) string {
-	return fmt.Sprintf("%s:%s:%s", ConnectorID, ResourceTypeChannel, channelID)
+// MakeFileActivityID creates the ID of a file the user shared
+func MakeFileActivityID(fileID string) string {
+	return fmt.Sprintf("%s:file:%s", ConnectorID, fileID)
 }
 
-// MakeThreadContextID creates a thread context ID with connector prefix
-func MakeThreadContextID(channelID, threadTS string) string {
-	return fmt.Sprintf("%s:%s:%s:%s", ConnectorID, ResourceTypeThread, channelID, threadTS)
+// MakePinActivityID creates the ID of a message the user pinned
+func MakePinActivityID(channelID, messageTS string) string {
+	return fmt.Sprintf("%s:pin:%s:%s", ConnectorID, channelID, messageTS)
+}
+
+// MakeSourceContextID creates a source context ID with connector prefix. With a team ID, the
+// source of that workspace is identified.
+// Example: "slack:source", "slack:source:T0123ABC"
+func MakeSourceContextID(teamID string) string {
+	if teamID == "" {
+		return fmt.Sprintf("%s:%s", ConnectorID, ResourceTypeSource)
+	}
+	return fmt.Sprintf("%s:%s:%s", ConnectorID, ResourceTypeSource, teamID)
+}
+
+// MakeChannelContextID creates a channel context ID with connector prefix, namespaced by the team
+// ID when one is given
+// Example: "slack:channel:C0123ABC", "slack:channel:T0123ABC:C0123ABC"
+func MakeChannelContextID(teamID, channelID string) string {
+	return fmt.Sprintf("%s:%s:%s", ConnectorID, ResourceTypeChannel, teamScoped(teamID, channelID))
+}
+
+// MakeThreadContextID creates a thread context ID with connector prefix, namespaced by the team ID
+// when one is given
+func MakeThreadContextID(teamID, channelID, threadTS string) string {
+	return fmt.Sprintf("%s:%s:%s:%s", ConnectorID, ResourceTypeThread, teamScoped(teamID, channelID), threadTS)
+}
+
+// teamScoped prefixes an ID with the team ID. IDs of a single workspace are not prefixed, which
+// keeps the IDs created before multi-workspace support.
+func teamScoped(teamID, id string) string {
+	if teamID == "" {
+		return id
+	}
+	return teamID + ":" + id
 }
@@ -11,17 +11,32 @@ func TestMakeActivityID(t *testing.T) {
 	assert.Equal(t, "slack:1234567890.123456", got)
 }
 
+func TestMakeReactionActivityID(t *testing.T) {
+	got := MakeReactionActivityID("C1234567890", "1234567890.123456", "eyes")
+	assert.Equal(t, "slack:reaction:C1234567890:1234567890.123456:eyes", got)
+}
+
+func TestMakeFileActivityID(t *testing.T) {
+	got := MakeFileActivityID("F1234567890")
+	assert.Equal(t, "slack:file:F1234567890", got)
+}
+
+func TestMakePinActivityID(t *testing.T) {
+	got := MakePinActivityID("C1234567890", "1234567890.123456")
+	assert.Equal(t, "slack:pin:C1234567890:1234567890.123456", got)
+}
+
 func TestMakeSourceContextID(t *testing.T) {
-	got := MakeSourceContextID()
-	assert.Equal(t, "slack:source", got)
+	assert.Equal(t, "slack:source", MakeSourceContextID(""))
+	assert.Equal(t, "slack:source:T0123ABC", MakeSourceContextID("T0123ABC"))
 }
 
 func TestMakeChannelContextID(t *testing.T) {
-	got := MakeChannelContextID("C1234567890")
-	assert.Equal(t, "slack:channel:C1234567890", got)
+	assert.Equal(t, "slack:channel:C1234567890", MakeChannelContextID("", "C1234567890"))
+	assert.Equal(t, "slack:channel:T0123ABC:C1234567890", MakeChannelContextID("T0123ABC", "C1234567890"))
 }
 
 func TestMakeThreadContextID(t *testing.T) {
-	got := MakeThreadContextID("C1234567890", "1234567890.123456")
-	assert.Equal(t, "slack:thread:C1234567890:1234567890.123456", got)
+	assert.Equal(t, "slack:thread:C1234567890:1234567890.123456", MakeThreadContextID("", "C1234567890", "1234567890.123456"))
+	assert.Equal(t, "slack:thread:T0123ABC:C1234567890:1234567890.123456", MakeThreadContextID("T0123ABC", "C1234567890", "1234567890.123456"))
 }
@@ -0,0 +1,23 @@
+package core
+
+// ContextPatternURL finds Slack permalinks and app links embedded in free text. Characters used
+// as delimiters by Slack mrkdwn (<url|label>), Markdown links and quoted attributes terminate a URL.
+const ContextPatternURL = `(?:https://[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.slack\.com|slack://[a-z]+)(?:[/?][^\s<>|()\[\]{}"'` + "`" + `]*)?`
+
+// Path patterns are matched against the path of a workspace URL.
+const (
+	// ContextPatternMessagePath matches a message permalink, e.g. "/archives/C099VUEKVBN/p1765613227980829"
+	ContextPatternMessagePath = `^/archives/(?P<channel>[CDG][A-Z0-9]+)/p(?P<ts>\d{7,})/?$`
+	// ContextPatternChannelPath matches a channel link, e.g. "/archives/C099VUEKVBN"
+	ContextPatternChannelPath = `^/archives/(?P<channel>[CDG][A-Z0-9]+)/?$`
+)
+
+const (
+	// ContextPatternChannelID matches a conversation ID: public channels (C), private channels (G)
+	// and direct messages (D)
+	ContextPatternChannelID = `^[CDG][A-Z0-9]+$`
+	// ContextPatternTS matches a message timestamp, e.g. "1765613134.990399"
+	ContextPatternTS = `^\d+\.\d+$`
+	// ContextPatternTeamID matches a workspace ID, e.g. "T0123ABC"
+	ContextPatternTeamID = `^T[A-Z0-9]+$`
+)
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var reTeamID = regexp.MustCompile(ContextPatternTeamID)
+
+// Team is a Slack workspace. Each workspace of an Enterprise Grid org is a team of its own.
+type Team struct {
+	ID   string
+	Name string
+	// Domain is the host name of the workspace, e.g. "acme-eng.slack.com"
+	Domain string
+}
+
+// NewTeam reads a team object from team.info. The domain is reported without ".slack.com".
+func NewTeam(team map[string]any) Team {
+	domain := GetStringValue(team, "domain")
+	if domain != "" && !strings.Contains(domain, ".") {
+		domain += ".slack.com"
+	}
+	return Team{
+		ID:     GetStringValue(team, "id"),
+		Name:   GetStringValue(team, "name"),
+		Domain: domain,
+	}
+}
+
+// Label returns the name of the team, or its ID if the name is unknown
+func (t Team) Label() string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return t.ID
+}
+
+// URL returns the URL of the workspace, or "" if its domain is unknown
+func (t Team) URL() string {
+	if t.Domain == "" {
+		return ""
+	}
+	return "https://" + t.Domain
+}
+
+// WorkspaceHost normalizes workspace_url, which may be entered with a scheme or a trailing slash,
+// to a lower-cased host name
+func WorkspaceHost(workspaceURL string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(workspaceURL, "https://"), "http://")
+	return strings.ToLower(strings.TrimRight(host, "/"))
+}
+
+// TeamIDsFromConfig returns the workspaces listed in team_ids, which an org-level token of an
+// Enterprise Grid org syncs. It is empty for a single workspace.
+func TeamIDsFromConfig(cfg map[string]any) ([]string, error) {
+	value, ok := cfg["team_ids"]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("team_ids must be an array")
+	}
+
+	teamIDs := []string{}
+	for i, item := range items {
+		teamID, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("team_ids[%d] must be a string", i)
+		}
+		teamID = strings.TrimSpace(teamID)
+		if teamID == "" {
+			continue
+		}
+		if !reTeamID.MatchString(teamID) {
+			return nil, fmt.Errorf("invalid team_ids[%d]: %s", i, teamID)
+		}
+		if !slices.Contains(teamIDs, teamID) {
+			teamIDs = append(teamIDs, teamID)
+		}
+	}
+	if len(teamIDs) == 0 {
+		return nil, nil
+	}
+	return teamIDs, nil
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTeam(t *testing.T) {
+	team := NewTeam(map[string]any{"id": "T0123ABC", "name": "Acme Engineering", "domain": "acme-eng"})
+	assert.Equal(t, Team{ID: "T0123ABC", Name: "Acme Engineering", Domain: "acme-eng.slack.com"}, team)
+	assert.Equal(t, "https://acme-eng.slack.com", team.URL())
+
+	assert.Equal(t, "T0123ABC", Team{ID: "T0123ABC"}.Label())
+	assert.Equal(t, "", Team{ID: "T0123ABC"}.URL())
+}
+
+func TestTeamIDsFromConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     map[string]any
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "single workspace",
+			cfg:  map[string]any{},
+			want: nil,
+		},
+		{
+			name: "workspaces of an org",
+			cfg:  map[string]any{"team_ids": []any{"T0123ABC", " T0456DEF ", "", "T0123ABC"}},
+			want: []string{"T0123ABC", "T0456DEF"},
+		},
+		{
+			name: "blank entries only",
+			cfg:  map[string]any{"team_ids": []any{" "}},
+			want: nil,
+		},
+		{
+			name:    "org ID",
+			cfg:     map[string]any{"team_ids": []any{"E0123ABC"}},
+			wantErr: true,
+		},
+		{
+			name:    "not an array",
+			cfg:     map[string]any{"team_ids": "T0123ABC"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TeamIDsFromConfig(tt.cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,29 +1,54 @@
 package enrich
 
-import "fmt"
+import (
+	"fmt"
+	"slack-connector/internal/core"
+)
+
+// defaultThreadDigestReplies is how many of the latest replies are listed in a thread description
+const defaultThreadDigestReplies = 3
 
 type config struct {
-	contextType      string
-	token            string
-	workspaceURL     string
-	enrichmentParams map[string]any
+	contextType string
+	token       string
+	userID      string
+	// workspaceHost is workspace_url normalized to a host name
+	workspaceHost       string
+	threadDigestReplies int
+	enrichmentParams    map[string]any
 }
 
 func newConfig(contextType string, cfg map[string]any, params map[string]any) (*config, error) {
-	token, ok := cfg["user_oauth_token"].(string)
-	if !ok || token == "" {
-		return nil, fmt.Errorf("missing user_oauth_token")
+	token, err := core.TokenFromConfig(cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	workspaceURL, ok := cfg["workspace_url"].(string)
 	if !ok || workspaceURL == "" {
 		return nil, fmt.Errorf("missing workspace_url")
 	}
+	workspaceHost := core.WorkspaceHost(workspaceURL)
+
+	// The user ID is optional; it leaves the user out of group DM titles and counts the user's
+	// replies to threads
+	userID, _ := cfg["user_id"].(string)
+
+	threadDigestReplies := defaultThreadDigestReplies
+	if value, ok := cfg["thread_digest_replies"]; ok && value != nil {
+		replies, ok := value.(float64)
+		if !ok || replies < 0 || replies != float64(int(replies)) {
+			return nil, fmt.Errorf("invalid thread_digest_replies: %v", value)
+		}
+		threadDigestReplies = int(replies)
+	}
 
 	return &config{
-		contextType:      contextType,
-		token:            token,
-		workspaceURL:     workspaceURL,
-		enrichmentParams: params,
+		contextType:         contextType,
+		token:               token,
+		userID:              userID,
+		workspaceHost:       workspaceHost,
+		threadDigestReplies: threadDigestReplies,
+		enrichmentParams:    params,
 	}, nil
 }
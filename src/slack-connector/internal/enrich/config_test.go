@@ -24,13 +24,46 @@ func TestNewConfig(t *testing.T) {
 			},
 			params: map[string]any{},
 			wantConfig: &config{
-				contextType:      "channel",
-				token:            "valid_token",
-				workspaceURL:     "https://example.slack.com",
-				enrichmentParams: map[string]any{},
+				contextType:         "channel",
+				token:               "valid_token",
+				workspaceHost:       "example.slack.com",
+				threadDigestReplies: 3,
+				enrichmentParams:    map[string]any{},
 			},
 			wantErr: false,
 		},
+		{
+			name:        "valid config - with user_id",
+			contextType: "channel",
+			cfg: map[string]any{
+				"user_oauth_token":      "valid_token",
+				"workspace_url":         "https://example.slack.com",
+				"user_id":               "U12345678",
+				"thread_digest_replies": float64(5),
+			},
+			params: map[string]any{},
+			wantConfig: &config{
+				contextType:         "channel",
+				token:               "valid_token",
+				userID:              "U12345678",
+				workspaceHost:       "example.slack.com",
+				threadDigestReplies: 5,
+				enrichmentParams:    map[string]any{},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "invalid config - negative thread_digest_replies",
+			contextType: "thread",
+			cfg: map[string]any{
+				"user_oauth_token":      "valid_token",
+				"workspace_url":         "https://example.slack.com",
+				"thread_digest_replies": float64(-1),
+			},
+			params:     map[string]any{},
+			wantConfig: nil,
+			wantErr:    true,
+		},
 		{
 			name:        "invalid config - missing token",
 			contextType: "channel",
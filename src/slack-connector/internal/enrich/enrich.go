@@ -3,11 +3,22 @@ package enrich
 import (
 	"fmt"
 	"slack-connector/internal/core"
+	"slack-connector/internal/render"
+	"slack-connector/internal/slackapi"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// threadTitleLength is the maximum length of the parent message in a thread title
+const threadTitleLength = 80
+
+// replyDigestLength is the maximum length of a reply in the digest of a thread
+const replyDigestLength = 120
+
+// maxThreadPages bounds the pages of conversations.replies read for a thread (200 replies each)
+const maxThreadPages = 10
+
 type ContextEnricher struct {
 	httpClient HTTPClient
 	config     *config
@@ -34,11 +45,7 @@ func (e *ContextEnricher) EnrichContext(context *core.Context) (*core.Context, e
 
 	switch e.config.contextType {
 	case core.ResourceTypeSource:
-		context.Title = ptrString("Slack")
-		context.Description = ptrString("Activity source from Slack")
-		context.Url = ptrString("https://" + e.config.workspaceURL)
-
-		return context, nil
+		return e.enrichSource(context), nil
 	case core.ResourceTypeChannel:
 		return e.enrichChannel(context)
 	case core.ResourceTypeThread:
@@ -48,6 +55,47 @@ func (e *ContextEnricher) EnrichContext(context *core.Context) (*core.Context, e
 	}
 }
 
+// enrichSource titles the source context. The source of a workspace of an Enterprise Grid org is
+// titled after the workspace, whose name and domain are looked up.
+func (e *ContextEnricher) enrichSource(context *core.Context) *core.Context {
+	teamID, _ := e.config.enrichmentParams["team_id"].(string)
+	if teamID == "" {
+		context.Title = ptrString("Slack")
+		context.Description = ptrString("Activity source from Slack")
+		context.Url = ptrString("https://" + e.config.workspaceHost)
+		return context
+	}
+
+	e.logger.Info(fmt.Sprintf("Enriching workspace: %s", teamID))
+
+	team := core.Team{ID: teamID}
+	response, err := e.httpClient.FetchTeam(e.config.token, teamID)
+	if err != nil {
+		e.logger.Warn(fmt.Sprintf("Failed to look up workspace %s: %s", teamID, err.Error()))
+	} else if teamObj, ok := response["team"].(map[string]any); ok {
+		team = core.NewTeam(teamObj)
+		team.ID = teamID
+	}
+	if team.Domain == "" {
+		team.Domain = e.workspaceHost()
+	}
+
+	source := core.NewTeamContextGenerator(team).CreateSourceContext()
+	context.Title = source.Title
+	context.Description = source.Description
+	context.Url = source.Url
+	return context
+}
+
+// workspaceHost returns the host name URLs are built on: the domain of the workspace the context
+// belongs to, or workspace_url for a single workspace
+func (e *ContextEnricher) workspaceHost() string {
+	if domain, _ := e.config.enrichmentParams["team_domain"].(string); domain != "" {
+		return domain
+	}
+	return e.config.workspaceHost
+}
+
 func (e *ContextEnricher) enrichChannel(context *core.Context) (*core.Context, error) {
 	channelID, ok := e.config.enrichmentParams["channel_id"].(string)
 	if !ok || channelID == "" {
@@ -75,8 +123,12 @@ func (e *ContextEnricher) applyChannelEnrichment(context *core.Context, apiResp
 	channelID := core.GetStringValue(channelObj, "id")
 
 	title := fmt.Sprintf("#%s", name)
+	if render.ConversationKind(channelObj) != render.KindChannel {
+		// DMs and group DMs are titled after their participants
+		title = render.NewRenderer(e.httpClient, e.config.token, e.logger).Conversation(channelObj, e.config.userID).Label()
+	}
 	description := topicValue
-	url := fmt.Sprintf("https://%s/archives/%s", e.config.workspaceURL, channelID)
+	url := fmt.Sprintf("https://%s/archives/%s", e.workspaceHost(), channelID)
 	createdAt := time.Unix(getIntValue(channelObj, "created"), 0).UTC()
 	updatedAt := time.UnixMilli(getIntValue(channelObj, "updated")).UTC()
 
@@ -96,6 +148,7 @@ func (e *ContextEnricher) applyChannelEnrichment(context *core.Context, apiResp
 	metadataMap["is_channel"] = getBoolValue(channelObj, "is_channel")
 	metadataMap["is_group"] = getBoolValue(channelObj, "is_group")
 	metadataMap["is_im"] = getBoolValue(channelObj, "is_im")
+	metadataMap["is_mpim"] = getBoolValue(channelObj, "is_mpim")
 	metadataMap["topic"] = topicValue
 	metadataMap["purpose"] = getNestedString(channelObj, "purpose", "value")
 	metadataMap["context_team_id"] = core.GetStringValue(channelObj, "context_team_id")
@@ -118,38 +171,44 @@ func (e *ContextEnricher) enrichThread(context *core.Context) (*core.Context, er
 
 	e.logger.Info(fmt.Sprintf("Enriching thread: %s in channel %s", threadTS, channelID))
 
-	response, err := e.httpClient.FetchThread(e.config.token, channelID, threadTS)
+	result, err := slackapi.ListCursorPages("conversations.replies", "messages", maxThreadPages, func(cursor string) (map[string]any, error) {
+		return e.httpClient.FetchThread(e.config.token, channelID, threadTS, cursor)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch thread data: %w", err)
 	}
+	if result.Truncated {
+		e.logger.Warn(fmt.Sprintf("Stopped reading thread %s after %d pages (%d messages)", threadTS, maxThreadPages, len(result.Items)))
+	}
 
-	return e.applyThreadEnrichment(context, response, channelID)
+	return e.applyThreadEnrichment(context, result.Items, channelID)
 }
 
-func (e *ContextEnricher) applyThreadEnrichment(context *core.Context, apiResp map[string]any, channelID string) (*core.Context, error) {
-	messagesInterface, ok := apiResp["messages"]
-	if !ok {
-		return nil, fmt.Errorf("no messages array in API response")
-	}
-
-	messages, ok := messagesInterface.([]any)
-	if !ok || len(messages) == 0 {
+// applyThreadEnrichment enriches a thread context from its messages, the parent message first
+func (e *ContextEnricher) applyThreadEnrichment(context *core.Context, messages []map[string]any, channelID string) (*core.Context, error) {
+	if len(messages) == 0 {
 		return nil, fmt.Errorf("messages array is empty or invalid")
 	}
 
-	// Get first message (parent message)
-	parentMsg, ok := messages[0].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("first message is not a map")
+	parentMsg := messages[0]
+	parentTS := core.GetStringValue(parentMsg, "ts")
+	replies := []map[string]any{}
+	for _, message := range messages[1:] {
+		if core.GetStringValue(message, "ts") != parentTS {
+			replies = append(replies, message)
+		}
 	}
 
-	text := core.GetStringValue(parentMsg, "text")
-	parentTS := core.GetStringValue(parentMsg, "ts")
+	renderer := render.NewRenderer(e.httpClient, e.config.token, e.logger)
+	text := renderer.RenderMessage(parentMsg)
 
-	title := fmt.Sprintf("Thread: %s", text)
-	description := text
-	// Format: https://{workspace_url}/archives/{channel_id}/p{ts with dots removed}
-	url := fmt.Sprintf("https://%s/archives/%s/p%s", e.config.workspaceURL, channelID, formatSlackTS(parentTS))
+	title := fmt.Sprintf("Thread: %s", render.Truncate(text.Plain, threadTitleLength))
+	description := text.Markdown
+	if digest := e.replyDigest(renderer, replies); digest != "" {
+		description += "\n\n" + digest
+	}
+	// Format: https://{workspace host}/archives/{channel_id}/p{ts with dots removed}
+	url := fmt.Sprintf("https://%s/archives/%s/p%s", e.workspaceHost(), channelID, formatSlackTS(parentTS))
 	ts := core.GetStringValue(parentMsg, "thread_ts")
 	if ts == "" {
 		ts = core.GetStringValue(parentMsg, "ts")
@@ -157,10 +216,21 @@ func (e *ContextEnricher) applyThreadEnrichment(context *core.Context, apiResp m
 	createdAt, err := parseSlackTS(ts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse createdAt for thread: %w", err)
-	} else {
-		context.CreatedAt = &createdAt
-		context.UpdatedAt = &createdAt // Slack does not provide updatedAt for threads
 	}
+	context.CreatedAt = &createdAt
+
+	// The thread was last active at its latest reply
+	latestReply := core.GetStringValue(parentMsg, "latest_reply")
+	if latestReply == "" && len(replies) > 0 {
+		latestReply = core.GetStringValue(replies[len(replies)-1], "ts")
+	}
+	updatedAt := createdAt
+	if latestReply != "" {
+		if parsed, err := parseSlackTS(latestReply); err == nil {
+			updatedAt = parsed
+		}
+	}
+	context.UpdatedAt = &updatedAt
 
 	context.Title = &title
 	context.Description = &description
@@ -177,12 +247,73 @@ func (e *ContextEnricher) applyThreadEnrichment(context *core.Context, apiResp m
 	metadataMap["team"] = core.GetStringValue(parentMsg, "team")
 	metadataMap["reply_count"] = getIntValue(parentMsg, "reply_count")
 	metadataMap["reply_users_count"] = getIntValue(parentMsg, "reply_users_count")
+	metadataMap["latest_reply"] = latestReply
+	metadataMap["participants"] = threadParticipants(renderer, parentMsg, replies)
+	if e.config.userID != "" {
+		metadataMap["own_reply_count"] = countReplies(replies, e.config.userID)
+	}
 
 	context.Metadata = metadataMap
 
 	return context, nil
 }
 
+// replyDigest lists the last threadDigestReplies replies of a thread, one line each
+// Example: "**Latest replies**\n- **alice**: Looks good to me"
+func (e *ContextEnricher) replyDigest(renderer *render.Renderer, replies []map[string]any) string {
+	if e.config.threadDigestReplies == 0 || len(replies) == 0 {
+		return ""
+	}
+
+	recent := replies[max(0, len(replies)-e.config.threadDigestReplies):]
+	lines := []string{"**Latest replies**"}
+	for _, reply := range recent {
+		text := renderer.RenderMessage(reply)
+		name := renderer.UserName(core.GetStringValue(reply, "user"))
+		lines = append(lines, fmt.Sprintf("- **%s**: %s", name, render.Truncate(text.Plain, replyDigestLength)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// threadParticipants returns the names of the users who replied to a thread, in the order Slack
+// reports them. The reply authors are used when the parent message does not list them.
+func threadParticipants(renderer *render.Renderer, parentMsg map[string]any, replies []map[string]any) []string {
+	userIDs := []string{}
+	if replyUsers, ok := parentMsg["reply_users"].([]any); ok {
+		for _, user := range replyUsers {
+			if userID, ok := user.(string); ok {
+				userIDs = append(userIDs, userID)
+			}
+		}
+	} else {
+		seen := map[string]bool{}
+		for _, reply := range replies {
+			userID := core.GetStringValue(reply, "user")
+			if userID != "" && !seen[userID] {
+				seen[userID] = true
+				userIDs = append(userIDs, userID)
+			}
+		}
+	}
+
+	participants := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		participants[i] = renderer.UserName(userID)
+	}
+	return participants
+}
+
+// countReplies counts the replies posted by a user
+func countReplies(replies []map[string]any, userID string) int64 {
+	var count int64
+	for _, reply := range replies {
+		if core.GetStringValue(reply, "user") == userID {
+			count++
+		}
+	}
+	return count
+}
+
 // getNestedString safely extracts nested string value
 func getNestedString(m map[string]any, keys ...string) string {
 	current := m
@@ -2,6 +2,7 @@ package enrich
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"slack-connector/internal/core"
 	mock_enrich "slack-connector/mock/enrich"
@@ -57,6 +58,73 @@ func TestEnrichContext(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "enrich source context with a workspace_url including the scheme",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				return mockHTTP
+			},
+			resourceType: "source",
+			cfg: map[string]any{
+				"user_oauth_token": "token",
+				"workspace_url":    "https://Example.slack.com/",
+			},
+			params: map[string]any{},
+			want: &core.Context{
+				Title:       ptrString("Slack"),
+				Description: ptrString("Activity source from Slack"),
+				Url:         ptrString("https://example.slack.com"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "enrich source context of a workspace",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchTeam("token", "T0123ABC").Return(map[string]any{
+					"ok":   true,
+					"team": map[string]any{"id": "T0123ABC", "name": "Acme Engineering", "domain": "acme-eng"},
+				}, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "source",
+			cfg: map[string]any{
+				"user_oauth_token": "token",
+				"workspace_url":    "acme.enterprise.slack.com",
+			},
+			params: map[string]any{
+				"team_id": "T0123ABC",
+			},
+			want: &core.Context{
+				Title:       ptrString("Slack: Acme Engineering"),
+				Description: ptrString("Activity source from Slack workspace Acme Engineering"),
+				Url:         ptrString("https://acme-eng.slack.com"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "enrich source context of a workspace - lookup failed",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchTeam("token", "T0123ABC").Return(nil, errors.New("missing_scope")).Times(1)
+				return mockHTTP
+			},
+			resourceType: "source",
+			cfg: map[string]any{
+				"user_oauth_token": "token",
+				"workspace_url":    "acme.enterprise.slack.com",
+			},
+			params: map[string]any{
+				"team_id":     "T0123ABC",
+				"team_domain": "acme-eng.slack.com",
+			},
+			want: &core.Context{
+				Title:       ptrString("Slack: T0123ABC"),
+				Description: ptrString("Activity source from Slack workspace T0123ABC"),
+				Url:         ptrString("https://acme-eng.slack.com"),
+			},
+			wantErr: false,
+		},
 		{
 			name: "enrich channel context",
 			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
@@ -86,6 +154,7 @@ func TestEnrichContext(t *testing.T) {
 					"is_channel":      true,
 					"is_group":        false,
 					"is_im":           false,
+					"is_mpim":         false,
 					"topic":           "Company-wide announcements and work-based matters",
 					"purpose":         "This channel is for workspace-wide communication and announcements. All members are in this channel.",
 					"context_team_id": "T099VUE950C",
@@ -93,13 +162,67 @@ func TestEnrichContext(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "enrich group DM context",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/enrichment/group_dm.json")
+
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchChannel("token", "G0123GROUPDM").Return(response, nil).Times(1)
+				mockHTTP.EXPECT().FetchMembers("token", "G0123GROUPDM").Return(map[string]any{
+					"ok":      true,
+					"members": []any{"U12345678", "U0123ALICE", "U0123BOB"},
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchUser("token", "U0123ALICE").Return(map[string]any{
+					"ok":   true,
+					"user": map[string]any{"id": "U0123ALICE", "name": "alice", "profile": map[string]any{"display_name": "Alice"}},
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchUser("token", "U0123BOB").Return(map[string]any{
+					"ok":   true,
+					"user": map[string]any{"id": "U0123BOB", "name": "bob", "profile": map[string]any{"display_name": "Bob"}},
+				}, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "channel",
+			cfg: map[string]any{
+				"user_oauth_token": "token",
+				"workspace_url":    "example.slack.com",
+				"user_id":          "U12345678",
+			},
+			params: map[string]any{
+				"channel_id": "G0123GROUPDM",
+			},
+			want: &core.Context{
+				Title:       ptrString("Group DM: Alice, Bob"),
+				Description: ptrString(""),
+				Url:         ptrString("https://example.slack.com/archives/G0123GROUPDM"),
+				CreatedAt:   ptrTime(time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)),
+				UpdatedAt:   ptrTime(time.Date(2025, 12, 13, 8, 0, 0, 0, time.UTC)),
+				Metadata: map[string]any{
+					"name":            "mpdm-alice--bob--sd099rsefgdb_user-1",
+					"is_private":      true,
+					"is_channel":      false,
+					"is_group":        true,
+					"is_im":           false,
+					"is_mpim":         true,
+					"topic":           "",
+					"purpose":         "Group messaging with: @alice @bob @sd099rsefgdb_user",
+					"context_team_id": "T099VUE950C",
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "enrich thread context",
 			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
 				response := loadJSONTestData(t, "../../testdata/enrichment/thread.json")
 
 				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
-				mockHTTP.EXPECT().FetchThread("token", "C099VUEKVBN", "1765613134.990399").Return(response, nil).Times(1)
+				mockHTTP.EXPECT().FetchThread("token", "C099VUEKVBN", "1765613134.990399", "").Return(response, nil).Times(1)
+				mockHTTP.EXPECT().FetchUser("token", "U099SQHSJCW").Return(map[string]any{
+					"ok":   true,
+					"user": map[string]any{"id": "U099SQHSJCW", "name": "sd099rsefgdb_user"},
+				}, nil).Times(1)
 				return mockHTTP
 			},
 			resourceType: "thread",
@@ -113,10 +236,10 @@ func TestEnrichContext(t *testing.T) {
 			},
 			want: &core.Context{
 				Title:       ptrString("Thread: 後からぶら下げる"),
-				Description: ptrString("後からぶら下げる"),
+				Description: ptrString("後からぶら下げる\n\n**Latest replies**\n- **sd099rsefgdb_user**: リプライです"),
 				Url:         ptrString("https://example.slack.com/archives/C099VUEKVBN/p1765613134990399"),
 				CreatedAt:   ptrTime(time.Date(2025, 12, 13, 8, 5, 34, 990399000, time.UTC)),
-				UpdatedAt:   ptrTime(time.Date(2025, 12, 13, 8, 5, 34, 990399000, time.UTC)),
+				UpdatedAt:   ptrTime(time.Date(2025, 12, 13, 8, 7, 7, 980829000, time.UTC)),
 				Metadata: map[string]any{
 					"parent_user":       "U099SQHSJCW",
 					"parent_ts":         "1765613134.990399",
@@ -124,6 +247,51 @@ func TestEnrichContext(t *testing.T) {
 					"team":              "T099VUE950C",
 					"reply_count":       int64(1),
 					"reply_users_count": int64(1),
+					"latest_reply":      "1765613227.980829",
+					"participants":      []string{"sd099rsefgdb_user"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "enrich thread context of a workspace",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/enrichment/thread.json")
+
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchThread("token", "C099VUEKVBN", "1765613134.990399", "").Return(response, nil).Times(1)
+				mockHTTP.EXPECT().FetchUser("token", "U099SQHSJCW").Return(map[string]any{
+					"ok":   true,
+					"user": map[string]any{"id": "U099SQHSJCW", "name": "sd099rsefgdb_user"},
+				}, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "thread",
+			cfg: map[string]any{
+				"user_oauth_token": "token",
+				"workspace_url":    "example.slack.com",
+			},
+			params: map[string]any{
+				"channel_id":  "C099VUEKVBN",
+				"thread_ts":   "1765613134.990399",
+				"team_id":     "T0123ABC",
+				"team_domain": "acme-eng.slack.com",
+			},
+			want: &core.Context{
+				Title:       ptrString("Thread: 後からぶら下げる"),
+				Description: ptrString("後からぶら下げる\n\n**Latest replies**\n- **sd099rsefgdb_user**: リプライです"),
+				Url:         ptrString("https://acme-eng.slack.com/archives/C099VUEKVBN/p1765613134990399"),
+				CreatedAt:   ptrTime(time.Date(2025, 12, 13, 8, 5, 34, 990399000, time.UTC)),
+				UpdatedAt:   ptrTime(time.Date(2025, 12, 13, 8, 7, 7, 980829000, time.UTC)),
+				Metadata: map[string]any{
+					"parent_user":       "U099SQHSJCW",
+					"parent_ts":         "1765613134.990399",
+					"thread_ts":         "1765613134.990399",
+					"team":              "T099VUE950C",
+					"reply_count":       int64(1),
+					"reply_users_count": int64(1),
+					"latest_reply":      "1765613227.980829",
+					"participants":      []string{"sd099rsefgdb_user"},
 				},
 			},
 			wantErr: false,
@@ -134,7 +302,11 @@ func TestEnrichContext(t *testing.T) {
 				response := loadJSONTestData(t, "../../testdata/enrichment/thread_without_reply.json")
 
 				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
-				mockHTTP.EXPECT().FetchThread("token", "C099VUEKVBN", "1765613134.990399").Return(response, nil).Times(1)
+				mockHTTP.EXPECT().FetchThread("token", "C099VUEKVBN", "1765613134.990399", "").Return(response, nil).Times(1)
+				mockHTTP.EXPECT().FetchUser("token", "U099SQHSJCW").Return(map[string]any{
+					"ok":   true,
+					"user": map[string]any{"id": "U099SQHSJCW", "name": "sd099rsefgdb_user"},
+				}, nil).Times(1)
 				return mockHTTP
 			},
 			resourceType: "thread",
@@ -151,7 +323,7 @@ func TestEnrichContext(t *testing.T) {
 				Description: ptrString("test message"),
 				Url:         ptrString("https://example.slack.com/archives/C099VUEKVBN/p1765613134990399"),
 				CreatedAt:   ptrTime(time.Date(2025, 12, 13, 8, 5, 34, 990399000, time.UTC)),
-				UpdatedAt:   ptrTime(time.Date(2025, 12, 13, 8, 5, 34, 990399000, time.UTC)),
+				UpdatedAt:   ptrTime(time.Date(2025, 12, 13, 8, 7, 7, 980829000, time.UTC)),
 				Metadata: map[string]any{
 					"parent_user":       "U099SQHSJCW",
 					"parent_ts":         "1765613134.990399",
@@ -159,6 +331,56 @@ func TestEnrichContext(t *testing.T) {
 					"team":              "T099VUE950C",
 					"reply_count":       int64(1),
 					"reply_users_count": int64(1),
+					"latest_reply":      "1765613227.980829",
+					"participants":      []string{"sd099rsefgdb_user"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "enrich long thread context",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_enrich.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchThread("token", "C099VUEKVBN", "1765600000.000100", "").Return(loadJSONTestData(t, "../../testdata/enrichment/thread_page1.json"), nil).Times(1)
+				mockHTTP.EXPECT().FetchThread("token", "C099VUEKVBN", "1765600000.000100", "bmV4dF90czoxNzY1NjAyMDAw").Return(loadJSONTestData(t, "../../testdata/enrichment/thread_page2.json"), nil).Times(1)
+				mockHTTP.EXPECT().FetchUser("token", "U0123ALICE").Return(map[string]any{
+					"ok":   true,
+					"user": map[string]any{"id": "U0123ALICE", "name": "alice"},
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchUser("token", "U12345678").Return(map[string]any{
+					"ok":   true,
+					"user": map[string]any{"id": "U12345678", "name": "sd099rsefgdb_user"},
+				}, nil).Times(1)
+				return mockHTTP
+			},
+			resourceType: "thread",
+			cfg: map[string]any{
+				"user_oauth_token":      "token",
+				"workspace_url":         "example.slack.com",
+				"user_id":               "U12345678",
+				"thread_digest_replies": float64(2),
+			},
+			params: map[string]any{
+				"channel_id": "C099VUEKVBN",
+				"thread_ts":  "1765600000.000100",
+			},
+			want: &core.Context{
+				Title: ptrString("Thread: Upload queue stalls after deploys"),
+				Description: ptrString("Upload queue stalls after deploys\n\n**Latest replies**\n" +
+					"- **sd099rsefgdb_user**: Found it, the retry loop never resets\n- **sd099rsefgdb_user**: Fix is deployed"),
+				Url:       ptrString("https://example.slack.com/archives/C099VUEKVBN/p1765600000000100"),
+				CreatedAt: ptrTime(time.Date(2025, 12, 13, 4, 26, 40, 100000, time.UTC)),
+				UpdatedAt: ptrTime(time.Date(2025, 12, 13, 5, 16, 40, 400000, time.UTC)),
+				Metadata: map[string]any{
+					"parent_user":       "U0123ALICE",
+					"parent_ts":         "1765600000.000100",
+					"thread_ts":         "1765600000.000100",
+					"team":              "T099VUE950C",
+					"reply_count":       int64(3),
+					"reply_users_count": int64(2),
+					"latest_reply":      "1765603000.000400",
+					"participants":      []string{"alice", "sd099rsefgdb_user"},
+					"own_reply_count":   int64(2),
 				},
 			},
 			wantErr: false,
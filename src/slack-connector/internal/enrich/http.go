@@ -2,5 +2,11 @@ package enrich
 
 type HTTPClient interface {
 	FetchChannel(token, channelID string) (map[string]any, error)
-	FetchThread(token, channelID, threadTS string) (map[string]any, error)
+	// FetchTeam looks up a workspace with team.info
+	FetchTeam(token, teamID string) (map[string]any, error)
+	// FetchThread lists a page of the messages of a thread, the parent message first
+	FetchThread(token, channelID, threadTS, cursor string) (map[string]any, error)
+	FetchUser(token, userID string) (map[string]any, error)
+	FetchUserGroups(token string) (map[string]any, error)
+	FetchMembers(token, channelID string) (map[string]any, error)
 }
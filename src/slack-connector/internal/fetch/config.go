@@ -1,34 +1,143 @@
 package fetch
 
-import "fmt"
+import (
+	"fmt"
+	"slack-connector/internal/core"
+	"time"
+)
+
+// Fetch modes
+const (
+	// FetchModeSearch finds the user's messages with search.messages, falling back to
+	// FetchModeHistory when the token may not search
+	FetchModeSearch = "search"
+	// FetchModeHistory scans the history of every conversation the user is a member of
+	FetchModeHistory = "history"
+)
+
+// defaultThreadLookbackDays is how many days before the target date history mode looks for thread
+// roots that may have received replies on the target date
+const defaultThreadLookbackDays = 7
+
+// defaultReactionPages bounds the pages of reactions.list read per run. The list is ordered by
+// when the reaction was added, newest first, so a backfill of an older date needs more pages.
+const defaultReactionPages = 5
 
 type config struct {
-	token        string
-	userID       string
-	targetDate   string
-	workspaceURL string
+	token      string
+	userID     string
+	targetDate string
+	// workspaceHost is workspace_url normalized to a host name
+	workspaceHost string
+	// teamIDs are the workspaces of an Enterprise Grid org to fetch, or empty for the workspace of
+	// the token
+	teamIDs            []string
+	fetchMode          string
+	threadLookbackDays int
+	channelFilter      *channelFilter
+	includeReactions   bool
+	reactionPages      int
+	includeFileShares  bool
+	includePins        bool
+	startTime, endTime time.Time
 }
 
 func newConfig(cfg map[string]any, targetDate string) (*config, error) {
-	token, ok := cfg["user_oauth_token"].(string)
-	if !ok || token == "" {
-		return nil, fmt.Errorf("missing user_oauth_token")
+	token, err := core.TokenFromConfig(cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	workspaceURL, ok := cfg["workspace_url"].(string)
 	if !ok || workspaceURL == "" {
 		return nil, fmt.Errorf("missing workspace_url")
 	}
+	workspaceHost := core.WorkspaceHost(workspaceURL)
 
 	userID, ok := cfg["user_id"].(string)
 	if !ok || userID == "" {
 		return nil, fmt.Errorf("missing user_id")
 	}
 
+	teamIDs, err := core.TeamIDsFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	fetchMode, _ := cfg["fetch_mode"].(string)
+	switch fetchMode {
+	case "":
+		fetchMode = FetchModeSearch
+	case FetchModeSearch, FetchModeHistory:
+	default:
+		return nil, fmt.Errorf("invalid fetch_mode: %s", fetchMode)
+	}
+
+	threadLookbackDays := defaultThreadLookbackDays
+	if value, ok := cfg["history_thread_lookback_days"]; ok && value != nil {
+		days, ok := value.(float64)
+		if !ok || days < 0 || days != float64(int(days)) {
+			return nil, fmt.Errorf("invalid history_thread_lookback_days: %v", value)
+		}
+		threadLookbackDays = int(days)
+	}
+
+	channelFilter, err := newChannelFilter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	includeReactions, _ := cfg["include_reactions"].(bool)
+	reactionPages := defaultReactionPages
+	if value, ok := cfg["reaction_max_pages"]; ok && value != nil {
+		pages, ok := value.(float64)
+		if !ok || pages < 1 || pages != float64(int(pages)) {
+			return nil, fmt.Errorf("invalid reaction_max_pages: %v", value)
+		}
+		reactionPages = int(pages)
+	}
+	includeFileShares, _ := cfg["include_file_shares"].(bool)
+	includePins, _ := cfg["include_pins"].(bool)
+
+	startTime, endTime, err := parseDateRange(targetDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target date: %w", err)
+	}
+
 	return &config{
-		token:        token,
-		userID:       userID,
-		targetDate:   targetDate,
-		workspaceURL: workspaceURL,
+		token:              token,
+		userID:             userID,
+		targetDate:         targetDate,
+		workspaceHost:      workspaceHost,
+		teamIDs:            teamIDs,
+		fetchMode:          fetchMode,
+		threadLookbackDays: threadLookbackDays,
+		channelFilter:      channelFilter,
+		includeReactions:   includeReactions,
+		reactionPages:      reactionPages,
+		includeFileShares:  includeFileShares,
+		includePins:        includePins,
+		startTime:          startTime,
+		endTime:            endTime,
 	}, nil
 }
+
+func parseDateRange(targetDate string) (time.Time, time.Time, error) {
+	var t time.Time
+	var err error
+
+	// Try RFC3339 format first (2025-12-12T00:00:00Z or 2025-12-12T00:00:00+00:00)
+	t, err = time.Parse(time.RFC3339, targetDate)
+	if err != nil {
+		// Fallback to date-only format (2025-12-12)
+		t, err = time.Parse("2006-01-02", targetDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	startTime := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 999999999, time.UTC)
+
+	return startTime, endTime, nil
+}
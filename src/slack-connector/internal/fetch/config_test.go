@@ -2,6 +2,7 @@ package fetch
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -23,13 +24,223 @@ func TestNewConfig(t *testing.T) {
 			},
 			targetDate: "2025-12-12T12:00:00+09:00",
 			wantConfig: &config{
-				token:        "valid_token",
-				userID:       "U12345678",
-				targetDate:   "2025-12-12T12:00:00+09:00",
-				workspaceURL: "example.slack.com",
+				token:              "valid_token",
+				userID:             "U12345678",
+				targetDate:         "2025-12-12T12:00:00+09:00",
+				workspaceHost:      "example.slack.com",
+				fetchMode:          "search",
+				threadLookbackDays: 7,
+				reactionPages:      5,
+				channelFilter:      &channelFilter{excludedTypes: map[string]bool{}},
+				startTime:          time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
+				endTime:            time.Date(2025, 12, 12, 23, 59, 59, 999999999, time.UTC),
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid config - history fetch mode",
+			cfg: map[string]any{
+				"user_oauth_token":             "valid_token",
+				"workspace_url":                "example.slack.com",
+				"user_id":                      "U12345678",
+				"fetch_mode":                   "history",
+				"history_thread_lookback_days": float64(2),
+				"exclude_direct_messages":      true,
+				"included_channel_patterns":    []any{"team-*"},
+				"excluded_channel_patterns":    []any{"#random", " "},
+				"excluded_conversation_types":  []any{"shared_channel"},
+				"include_reactions":            true,
+				"reaction_max_pages":           float64(20),
+				"include_file_shares":          true,
+				"include_pins":                 true,
+			},
+			targetDate: "2025-12-12",
+			wantConfig: &config{
+				token:              "valid_token",
+				userID:             "U12345678",
+				targetDate:         "2025-12-12",
+				workspaceHost:      "example.slack.com",
+				fetchMode:          "history",
+				threadLookbackDays: 2,
+				reactionPages:      20,
+				channelFilter: &channelFilter{
+					include:       []string{"team-*"},
+					exclude:       []string{"random"},
+					excludedTypes: map[string]bool{"shared_channel": true, "im": true, "mpim": true},
+				},
+				includeReactions:  true,
+				includeFileShares: true,
+				includePins:       true,
+				startTime:         time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
+				endTime:           time.Date(2025, 12, 12, 23, 59, 59, 999999999, time.UTC),
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config - oauth web flow",
+			cfg: map[string]any{
+				"active_auth_method":  "oauth_web",
+				"oauth_access_token":  "oauth_token",
+				"oauth_refresh_token": "refresh_token",
+				"workspace_url":       "example.slack.com",
+				"user_id":             "U12345678",
+			},
+			targetDate: "2025-12-12",
+			wantConfig: &config{
+				token:              "oauth_token",
+				userID:             "U12345678",
+				targetDate:         "2025-12-12",
+				workspaceHost:      "example.slack.com",
+				fetchMode:          "search",
+				threadLookbackDays: 7,
+				reactionPages:      5,
+				channelFilter:      &channelFilter{excludedTypes: map[string]bool{}},
+				startTime:          time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
+				endTime:            time.Date(2025, 12, 12, 23, 59, 59, 999999999, time.UTC),
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid config - oauth web flow not connected",
+			cfg: map[string]any{
+				"active_auth_method": "oauth_web",
+				"user_oauth_token":   "valid_token",
+				"workspace_url":      "example.slack.com",
+				"user_id":            "U12345678",
+			},
+			targetDate: "2025-12-12",
+			wantConfig: nil,
+			wantErr:    true,
+		},
+		{
+			name: "valid config - workspaces of an org",
+			cfg: map[string]any{
+				"user_oauth_token": "valid_token",
+				"workspace_url":    "acme.enterprise.slack.com",
+				"user_id":          "W12345678",
+				"team_ids":         []any{"T0123ABC", "T0456DEF"},
+			},
+			targetDate: "2025-12-12",
+			wantConfig: &config{
+				token:              "valid_token",
+				userID:             "W12345678",
+				targetDate:         "2025-12-12",
+				workspaceHost:      "acme.enterprise.slack.com",
+				teamIDs:            []string{"T0123ABC", "T0456DEF"},
+				fetchMode:          "search",
+				threadLookbackDays: 7,
+				reactionPages:      5,
+				channelFilter:      &channelFilter{excludedTypes: map[string]bool{}},
+				startTime:          time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
+				endTime:            time.Date(2025, 12, 12, 23, 59, 59, 999999999, time.UTC),
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid config - malformed team ID",
+			cfg: map[string]any{
+				"user_oauth_token": "valid_token",
+				"workspace_url":    "acme.enterprise.slack.com",
+				"user_id":          "W12345678",
+				"team_ids":         []any{"acme-eng"},
+			},
+			targetDate: "2025-12-12",
+			wantConfig: nil,
+			wantErr:    true,
+		},
+		{
+			name: "valid config - workspace_url with scheme and trailing slash",
+			cfg: map[string]any{
+				"user_oauth_token": "valid_token",
+				"workspace_url":    "https://Example.slack.com/",
+				"user_id":          "U12345678",
+			},
+			targetDate: "2025-12-12",
+			wantConfig: &config{
+				token:              "valid_token",
+				userID:             "U12345678",
+				targetDate:         "2025-12-12",
+				workspaceHost:      "example.slack.com",
+				fetchMode:          "search",
+				threadLookbackDays: 7,
+				reactionPages:      5,
+				channelFilter:      &channelFilter{excludedTypes: map[string]bool{}},
+				startTime:          time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
+				endTime:            time.Date(2025, 12, 12, 23, 59, 59, 999999999, time.UTC),
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid config - unknown fetch_mode",
+			cfg: map[string]any{
+				"user_oauth_token": "valid_token",
+				"workspace_url":    "example.slack.com",
+				"user_id":          "U12345678",
+				"fetch_mode":       "realtime",
+			},
+			targetDate: "2025-12-12",
+			wantConfig: nil,
+			wantErr:    true,
+		},
+		{
+			name: "invalid config - negative history_thread_lookback_days",
+			cfg: map[string]any{
+				"user_oauth_token":             "valid_token",
+				"workspace_url":                "example.slack.com",
+				"user_id":                      "U12345678",
+				"history_thread_lookback_days": float64(-1),
+			},
+			targetDate: "2025-12-12",
+			wantConfig: nil,
+			wantErr:    true,
+		},
+		{
+			name: "invalid config - zero reaction_max_pages",
+			cfg: map[string]any{
+				"user_oauth_token":   "valid_token",
+				"workspace_url":      "example.slack.com",
+				"user_id":            "U12345678",
+				"reaction_max_pages": float64(0),
+			},
+			targetDate: "2025-12-12",
+			wantConfig: nil,
+			wantErr:    true,
+		},
+		{
+			name: "invalid config - malformed channel pattern",
+			cfg: map[string]any{
+				"user_oauth_token":          "valid_token",
+				"workspace_url":             "example.slack.com",
+				"user_id":                   "U12345678",
+				"excluded_channel_patterns": []any{"alerts-["},
+			},
+			targetDate: "2025-12-12",
+			wantConfig: nil,
+			wantErr:    true,
+		},
+		{
+			name: "invalid config - unknown conversation type",
+			cfg: map[string]any{
+				"user_oauth_token":            "valid_token",
+				"workspace_url":               "example.slack.com",
+				"user_id":                     "U12345678",
+				"excluded_conversation_types": []any{"group"},
+			},
+			targetDate: "2025-12-12",
+			wantConfig: nil,
+			wantErr:    true,
+		},
+		{
+			name: "invalid config - invalid target date",
+			cfg: map[string]any{
+				"user_oauth_token": "valid_token",
+				"workspace_url":    "example.slack.com",
+				"user_id":          "U12345678",
+			},
+			targetDate: "yesterday",
+			wantConfig: nil,
+			wantErr:    true,
+		},
 		{
 			name: "invalid config - missing user_oauth_token",
 			cfg: map[string]any{
@@ -1,10 +1,14 @@
 package fetch
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"slack-connector/internal/core"
+	"slack-connector/internal/render"
+	"slack-connector/internal/slackapi"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -45,17 +49,81 @@ func NewActivityFetcher(httpClient HTTPClient, cfg map[string]any, targetDate st
 func (f *ActivityFetcher) FetchActivities() ([]*Activity, error) {
 	f.logger.Info("Starting to fetch Slack messages")
 
-	allMessages, err := f.fetchAllMessages()
+	renderer := render.NewRenderer(f.httpClient, f.config.token, f.logger)
+	activities := []*Activity{}
+	seen := map[string]bool{}
+	for _, gen := range f.contextGenerators() {
+		workspaceActivities, err := f.fetchWorkspaceActivities(gen, renderer)
+		if err != nil {
+			return nil, err
+		}
+		for _, activity := range workspaceActivities {
+			// Messages in channels shared between workspaces are found in each of them
+			if seen[activity.Id] {
+				continue
+			}
+			seen[activity.Id] = true
+			activities = append(activities, activity)
+		}
+	}
+
+	f.logger.Info(fmt.Sprintf("Transformed %d activities", len(activities)))
+
+	return activities, nil
+}
+
+// contextGenerators returns a context generator for each workspace to fetch: the workspace of the
+// token, or each workspace listed in team_ids
+func (f *ActivityFetcher) contextGenerators() []*core.ContextGenerator {
+	if len(f.config.teamIDs) == 0 {
+		return []*core.ContextGenerator{core.NewContextGenerator()}
+	}
+
+	generators := make([]*core.ContextGenerator, 0, len(f.config.teamIDs))
+	for _, teamID := range f.config.teamIDs {
+		generators = append(generators, core.NewTeamContextGenerator(f.team(teamID)))
+	}
+	return generators
+}
+
+// team looks up the name and domain of a workspace. When the lookup fails, the workspace is named
+// after its ID and its URLs are built on workspace_url.
+func (f *ActivityFetcher) team(teamID string) core.Team {
+	team := core.Team{ID: teamID}
+	response, err := f.httpClient.FetchTeam(f.config.token, teamID)
+	if err != nil {
+		f.logger.Warn(fmt.Sprintf("Failed to look up workspace %s: %s", teamID, err.Error()))
+	} else if teamObj, ok := response["team"].(map[string]any); ok {
+		team = core.NewTeam(teamObj)
+		team.ID = teamID
+	}
+	if team.Domain == "" {
+		team.Domain = f.config.workspaceHost
+	}
+	return team
+}
+
+// fetchWorkspaceActivities fetches the activities in the workspace of the context generator
+func (f *ActivityFetcher) fetchWorkspaceActivities(gen *core.ContextGenerator, renderer *render.Renderer) ([]*Activity, error) {
+	team := gen.Team()
+	if team.ID != "" {
+		f.logger.Info(fmt.Sprintf("Fetching workspace %s", team.Label()))
+	}
+
+	allMessages, err := f.fetchAllMessages(team)
 	if err != nil {
 		return nil, err
 	}
 
 	f.logger.Info(fmt.Sprintf("Fetched %d messages", len(allMessages)))
 
-	gen := core.NewContextGenerator()
 	activities := []*Activity{}
 	for _, message := range allMessages {
-		activity, err := transformMessage(message, gen)
+		// Uploads are reported as file_shared activities instead
+		if f.config.includeFileShares && isFileShare(message) {
+			continue
+		}
+		activity, err := f.transformMessage(message, gen, renderer)
 		if err != nil {
 			f.logger.Warn(fmt.Sprintf("Skipping message: %s", err.Error()))
 			continue
@@ -65,19 +133,43 @@ func (f *ActivityFetcher) FetchActivities() ([]*Activity, error) {
 		}
 	}
 
-	f.logger.Info(fmt.Sprintf("Transformed %d activities", len(activities)))
+	if f.config.includeReactions {
+		activities = append(activities, f.fetchReactionActivities(gen, renderer)...)
+	}
+	if f.config.includeFileShares {
+		activities = append(activities, f.fetchFileActivities(gen, renderer)...)
+	}
+	if f.config.includePins {
+		activities = append(activities, f.fetchPinActivities(gen, renderer)...)
+	}
 
 	return activities, nil
 }
 
-func (f *ActivityFetcher) fetchAllMessages() ([]map[string]any, error) {
+func (f *ActivityFetcher) fetchAllMessages(team core.Team) ([]map[string]any, error) {
+	if f.config.fetchMode == FetchModeHistory {
+		return f.fetchHistoryMessages(team)
+	}
+
+	messages, err := f.fetchSearchMessages(team.ID)
+	if errors.Is(err, slackapi.ErrMissingScope) || errors.Is(err, slackapi.ErrNotAllowedTokenType) {
+		f.logger.Warn(fmt.Sprintf("search.messages is not available to this token, falling back to conversation history: %s", err.Error()))
+		return f.fetchHistoryMessages(team)
+	}
+	return messages, err
+}
+
+// fetchSearchMessages searches the user's messages. teamID selects the workspace to search with an
+// org-level token, and is "" for a workspace token.
+func (f *ActivityFetcher) fetchSearchMessages(teamID string) ([]map[string]any, error) {
 	allMessages := []map[string]any{}
+	query := f.searchQuery()
 
 	// Slack search.messages API pagination (max 100 pages)
 	for page := 1; page <= 100; page++ {
 		f.logger.Debug(fmt.Sprintf("Fetching page %d", page))
 
-		response, err := f.httpClient.FetchMessages(f.config.token, f.config.userID, f.config.targetDate, page)
+		response, err := f.httpClient.FetchMessages(f.config.token, query, teamID, page)
 		if err != nil {
 			return nil, err
 		}
@@ -130,13 +222,43 @@ func (f *ActivityFetcher) fetchAllMessages() ([]map[string]any, error) {
 	return allMessages, nil
 }
 
-func transformMessage(message map[string]any, cgen *core.ContextGenerator) (*Activity, error) {
+// searchQuery returns the search.messages query for the user's messages on the target date,
+// narrowed by the channel filter where it can be expressed as modifiers
+// Example: "from:@U12345678 on:2025-12-13 -in:#random"
+func (f *ActivityFetcher) searchQuery() string {
+	terms := []string{
+		fmt.Sprintf("from:@%s", f.config.userID),
+		fmt.Sprintf("on:%s", f.config.startTime.Format("2006-01-02")),
+	}
+	terms = append(terms, f.config.channelFilter.searchModifiers()...)
+	return strings.Join(terms, " ")
+}
+
+// conversation names a conversation by its ID, looked up once per run
+func (f *ActivityFetcher) conversation(channelID string, renderer *render.Renderer) render.Conversation {
+	return renderer.ConversationByID(channelID, f.config.userID)
+}
+
+// excluded reports whether activities in a conversation are left out by the channel filter
+func (f *ActivityFetcher) excluded(conversation render.Conversation) bool {
+	return !f.config.channelFilter.allows(conversation)
+}
+
+// conversationContext creates the channel context of a conversation. DMs and group DMs are titled
+// after their participants.
+func conversationContext(cgen *core.ContextGenerator, conversation render.Conversation) *core.Context {
+	if conversation.IsDirectMessage() {
+		return cgen.CreateDirectMessageContext(conversation.ID, conversation.Label())
+	}
+	return cgen.CreateChannelContext(conversation.ID, conversation.Name)
+}
+
+func (f *ActivityFetcher) transformMessage(message map[string]any, cgen *core.ContextGenerator, renderer *render.Renderer) (*Activity, error) {
 	ts := core.GetStringValue(message, "ts")
 	if ts == "" {
 		return nil, fmt.Errorf("message missing ts field")
 	}
 
-	text := core.GetStringValue(message, "text")
 	permalink := core.GetStringValue(message, "permalink")
 	username := core.GetStringValue(message, "username")
 	team := core.GetStringValue(message, "team")
@@ -147,12 +269,15 @@ func transformMessage(message map[string]any, cgen *core.ContextGenerator) (*Act
 	}
 
 	channelID := core.GetStringValue(channelObj, "id")
-	channelName := core.GetStringValue(channelObj, "name")
-
-	if channelID == "" || channelName == "" {
+	if channelID == "" || core.GetStringValue(channelObj, "name") == "" {
 		return nil, fmt.Errorf("channel missing id or name")
 	}
 
+	if f.excluded(render.NewConversation(channelObj)) {
+		return nil, nil
+	}
+	conversation := renderer.Conversation(channelObj, f.config.userID)
+
 	// Determine thread_ts (from permalink or fallback to ts)
 	threadTS := parseThreadTS(permalink)
 	if threadTS == "" {
@@ -166,11 +291,12 @@ func transformMessage(message map[string]any, cgen *core.ContextGenerator) (*Act
 	}
 
 	sourceContext := cgen.CreateSourceContext()
-	channelContext := cgen.CreateChannelContext(channelID, channelName)
+	channelContext := conversationContext(cgen, conversation)
 	threadContext := cgen.CreateThreadContext(channelID, threadTS)
 
-	title := fmt.Sprintf("Message in #%s", channelName)
-	description := text
+	text := renderer.RenderMessage(message)
+	title := fmt.Sprintf("Message in %s", conversation.Label())
+	description := text.Markdown
 
 	activity := Activity{
 		Id:           core.MakeActivityID(ts),
@@ -182,10 +308,11 @@ func transformMessage(message map[string]any, cgen *core.ContextGenerator) (*Act
 		Url:          &permalink,
 		Metadata: map[string]any{
 			"channel_id":   channelID,
-			"channel_name": channelName,
+			"channel_name": conversation.Name,
 			"user":         username,
 			"thread_ts":    threadTS,
 			"team":         team,
+			"text":         text.Plain,
 		},
 		Contexts: []*core.Context{
 			sourceContext,
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"slack-connector/internal/core"
+	"slack-connector/internal/slackapi"
 	mock_fetch "slack-connector/mock/fetch"
 	"testing"
 	"time"
@@ -44,7 +45,7 @@ func TestFetchActivities(t *testing.T) {
 				response := loadJSONTestData(t, "../../testdata/events/thread_without_reply.json")
 
 				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
-				mockHTTP.EXPECT().FetchMessages("token", "U12345678", "2025-12-13", 1).Return(map[string]any{
+				mockHTTP.EXPECT().FetchMessages("token", "from:@U12345678 on:2025-12-13", "", 1).Return(map[string]any{
 					"messages": map[string]any{
 						"matches": []any{response},
 					},
@@ -72,6 +73,7 @@ func TestFetchActivities(t *testing.T) {
 						"user":         "sd099rsefgdb_user",
 						"thread_ts":    "1765611321.248519",
 						"team":         "T099VUE950C",
+						"text":         "単体メッセージ",
 					},
 					Contexts: []*core.Context{
 						{
@@ -129,7 +131,7 @@ func TestFetchActivities(t *testing.T) {
 				response := loadJSONTestData(t, "../../testdata/events/reply.json")
 
 				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
-				mockHTTP.EXPECT().FetchMessages("token", "U12345678", "2025-12-13", 1).Return(map[string]any{
+				mockHTTP.EXPECT().FetchMessages("token", "from:@U12345678 on:2025-12-13", "", 1).Return(map[string]any{
 					"messages": map[string]any{
 						"matches": []any{response},
 					},
@@ -157,6 +159,7 @@ func TestFetchActivities(t *testing.T) {
 						"user":         "sd099rsefgdb_user",
 						"thread_ts":    "1765613134.990399",
 						"team":         "T099VUE950C",
+						"text":         "リプライです",
 					},
 					Contexts: []*core.Context{
 						{
@@ -208,6 +211,219 @@ func TestFetchActivities(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "message with mentions",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				response := loadJSONTestData(t, "../../testdata/events/rich_text.json")
+
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchMessages("token", "from:@U12345678 on:2025-12-13", "", 1).Return(map[string]any{
+					"messages": map[string]any{
+						"matches": []any{response},
+					},
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchUser("token", "U0123ABC").Return(map[string]any{
+					"ok":   true,
+					"user": map[string]any{"id": "U0123ABC", "name": "alice"},
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchChannel("token", "C099VUEKVBN").Return(map[string]any{
+					"ok":      true,
+					"channel": map[string]any{"id": "C099VUEKVBN", "name": "general"},
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchUserGroups("token").Return(map[string]any{
+					"ok":         true,
+					"usergroups": []any{map[string]any{"id": "S0456DEF", "handle": "reviewers"}},
+				}, nil).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"user_oauth_token": "token",
+				"workspace_url":    "test-workspace.slack.com",
+				"user_id":          "U12345678",
+			},
+			targetDate: "2025-12-13",
+			want: []*Activity{
+				{
+					ActivityType: "message",
+					Source:       "slack",
+					Id:           "slack:1765614000.123456",
+					Title:        "Message in #general",
+					Description: "@alice see #general and [this](https://example.com/spec) 👍\nPlease review, @reviewers **before Friday**:\n" +
+						"1. run `make test`\n2. update the changelog\n> *Ship it*\n```\ngo test ./...\n```",
+					Url:       ptrString("https://test-workspace.slack.com/archives/C099VUEKVBN/p1765614000123456"),
+					Timestamp: time.Date(2025, 12, 13, 8, 20, 0, 123456001, time.UTC),
+					Metadata: map[string]any{
+						"channel_id":   "C099VUEKVBN",
+						"channel_name": "general",
+						"user":         "sd099rsefgdb_user",
+						"thread_ts":    "1765614000.123456",
+						"team":         "T099VUE950C",
+						"text": "@alice see #general and this 👍\nPlease review, @reviewers before Friday:\n" +
+							"1. run make test\n2. update the changelog\nShip it\ngo test ./...",
+					},
+					Contexts: []*core.Context{
+						core.NewContextGenerator().CreateSourceContext(),
+						core.NewContextGenerator().CreateChannelContext("C099VUEKVBN", "general"),
+						core.NewContextGenerator().CreateThreadContext("C099VUEKVBN", "1765614000.123456"),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "direct messages",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchMessages("token", "from:@U12345678 on:2025-12-13", "", 1).Return(map[string]any{
+					"messages": map[string]any{
+						"matches": []any{
+							loadJSONTestData(t, "../../testdata/events/direct_message.json"),
+							loadJSONTestData(t, "../../testdata/events/group_direct_message.json"),
+						},
+					},
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchUser("token", "U0999ZZZ").Return(map[string]any{
+					"ok":   true,
+					"user": map[string]any{"id": "U0999ZZZ", "name": "carol", "profile": map[string]any{"display_name": "Carol"}},
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchMembers("token", "G0123GROUPDM").Return(map[string]any{
+					"ok":      true,
+					"members": []any{"U12345678", "U0123ALICE", "U0123BOB"},
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchUser("token", "U0123ALICE").Return(map[string]any{
+					"ok":   true,
+					"user": map[string]any{"id": "U0123ALICE", "name": "alice", "profile": map[string]any{"display_name": "Alice"}},
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchUser("token", "U0123BOB").Return(map[string]any{
+					"ok":   true,
+					"user": map[string]any{"id": "U0123BOB", "name": "bob", "profile": map[string]any{"display_name": "Bob"}},
+				}, nil).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"user_oauth_token": "token",
+				"workspace_url":    "test-workspace.slack.com",
+				"user_id":          "U12345678",
+			},
+			targetDate: "2025-12-13",
+			want: []*Activity{
+				{
+					ActivityType: "message",
+					Source:       "slack",
+					Id:           "slack:1765615000.000100",
+					Title:        "Message in DM with Carol",
+					Description:  "Can you take a look at the queue alerts?",
+					Url:          ptrString("https://test-workspace.slack.com/archives/D0123ABCDEF/p1765615000000100"),
+					Timestamp:    mustConvertSlackTS(t, "1765615000.000100"),
+					Metadata: map[string]any{
+						"channel_id":   "D0123ABCDEF",
+						"channel_name": "Carol",
+						"user":         "sd099rsefgdb_user",
+						"thread_ts":    "1765615000.000100",
+						"team":         "T099VUE950C",
+						"text":         "Can you take a look at the queue alerts?",
+					},
+					Contexts: []*core.Context{
+						core.NewContextGenerator().CreateSourceContext(),
+						core.NewContextGenerator().CreateDirectMessageContext("D0123ABCDEF", "DM with Carol"),
+						core.NewContextGenerator().CreateThreadContext("D0123ABCDEF", "1765615000.000100"),
+					},
+				},
+				{
+					ActivityType: "message",
+					Source:       "slack",
+					Id:           "slack:1765615600.000200",
+					Title:        "Message in Group DM: Alice, Bob",
+					Description:  "Lunch at noon?",
+					Url:          ptrString("https://test-workspace.slack.com/archives/G0123GROUPDM/p1765615600000200"),
+					Timestamp:    mustConvertSlackTS(t, "1765615600.000200"),
+					Metadata: map[string]any{
+						"channel_id":   "G0123GROUPDM",
+						"channel_name": "Alice, Bob",
+						"user":         "sd099rsefgdb_user",
+						"thread_ts":    "1765615600.000200",
+						"team":         "T099VUE950C",
+						"text":         "Lunch at noon?",
+					},
+					Contexts: []*core.Context{
+						core.NewContextGenerator().CreateSourceContext(),
+						core.NewContextGenerator().CreateDirectMessageContext("G0123GROUPDM", "Group DM: Alice, Bob"),
+						core.NewContextGenerator().CreateThreadContext("G0123GROUPDM", "1765615600.000200"),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "direct messages excluded",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchMessages("token", "from:@U12345678 on:2025-12-13", "", 1).Return(map[string]any{
+					"messages": map[string]any{
+						"matches": []any{
+							loadJSONTestData(t, "../../testdata/events/direct_message.json"),
+							loadJSONTestData(t, "../../testdata/events/group_direct_message.json"),
+						},
+					},
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchUser(gomock.Any(), gomock.Any()).Return(map[string]any{"ok": true}, nil).AnyTimes()
+				mockHTTP.EXPECT().FetchMembers(gomock.Any(), gomock.Any()).Return(map[string]any{"ok": true}, nil).AnyTimes()
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"user_oauth_token":        "token",
+				"workspace_url":           "test-workspace.slack.com",
+				"user_id":                 "U12345678",
+				"exclude_direct_messages": true,
+			},
+			targetDate: "2025-12-13",
+			want:       []*Activity{},
+			wantErr:    false,
+		},
+		{
+			name: "channel filter",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchMessages("token", "from:@U12345678 on:2025-12-13 -in:#random", "", 1).Return(map[string]any{
+					"messages": map[string]any{
+						"matches": []any{
+							loadJSONTestData(t, "../../testdata/events/thread_without_reply.json"),
+							loadJSONTestData(t, "../../testdata/events/direct_message.json"),
+						},
+					},
+				}, nil).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"user_oauth_token":            "token",
+				"workspace_url":               "test-workspace.slack.com",
+				"user_id":                     "U12345678",
+				"excluded_channel_patterns":   []any{"random", "gen*"},
+				"excluded_conversation_types": []any{"im"},
+			},
+			targetDate: "2025-12-13",
+			want:       []*Activity{},
+			wantErr:    false,
+		},
+		{
+			name: "search error",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchMessages("token", "from:@U12345678 on:2025-12-13", "", 1).Return(nil, &slackapi.Error{
+					Method: "search.messages",
+					Code:   "invalid_auth",
+				}).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"user_oauth_token": "token",
+				"workspace_url":    "test-workspace.slack.com",
+				"user_id":          "U12345678",
+			},
+			targetDate: "2025-12-13",
+			want:       nil,
+			wantErr:    true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -232,6 +448,73 @@ func TestFetchActivities(t *testing.T) {
 	}
 }
 
+func TestFetchActivities_Workspaces(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	standalone := loadJSONTestData(t, "../../testdata/events/thread_without_reply.json")
+	reply := loadJSONTestData(t, "../../testdata/events/reply.json")
+
+	mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+	mockHTTP.EXPECT().FetchTeam("token", "T099VUE950C").Return(map[string]any{
+		"team": map[string]any{"id": "T099VUE950C", "name": "Test Workspace", "domain": "test-workspace"},
+	}, nil).Times(1)
+	mockHTTP.EXPECT().FetchTeam("token", "T0456DEF").Return(nil, &slackapi.Error{Method: "team.info", Code: "missing_scope"}).Times(1)
+	mockHTTP.EXPECT().FetchMessages("token", "from:@U12345678 on:2025-12-13", "T099VUE950C", 1).Return(map[string]any{
+		"messages": map[string]any{"matches": []any{standalone}},
+	}, nil).Times(1)
+	// The shared channel is found in both workspaces
+	mockHTTP.EXPECT().FetchMessages("token", "from:@U12345678 on:2025-12-13", "T0456DEF", 1).Return(map[string]any{
+		"messages": map[string]any{"matches": []any{standalone, reply}},
+	}, nil).Times(1)
+
+	fetcher, err := NewActivityFetcher(mockHTTP, map[string]any{
+		"user_oauth_token": "token",
+		"workspace_url":    "acme.enterprise.slack.com",
+		"user_id":          "U12345678",
+		"team_ids":         []any{"T099VUE950C", "T0456DEF"},
+	}, "2025-12-13", core.NewNoopLogger())
+	if err != nil {
+		t.Fatalf("Failed to create ActivityFetcher: %v", err)
+	}
+
+	got, err := fetcher.FetchActivities()
+	assert.NoError(t, err)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "slack:1765611321.248519", got[0].Id)
+		assert.Equal(t, []string{
+			"slack:source:T099VUE950C",
+			"slack:channel:T099VUE950C:C099VUEKVBN",
+			"slack:thread:T099VUE950C:C099VUEKVBN:1765611321.248519",
+		}, contextIDs(got[0].Contexts))
+		assert.Equal(t, ptrString("Slack: Test Workspace"), got[0].Contexts[0].Title)
+		assert.Equal(t, ptrString("https://test-workspace.slack.com"), got[0].Contexts[0].Url)
+		assert.Equal(t, map[string]any{
+			"channel_id":  "C099VUEKVBN",
+			"team_id":     "T099VUE950C",
+			"team_domain": "test-workspace.slack.com",
+		}, got[0].Contexts[1].Metadata.(map[string]any)["enrichment_params"])
+
+		// The workspace that could not be looked up is named after its ID, on the domain of workspace_url
+		assert.Equal(t, "slack:1765613227.980829", got[1].Id)
+		assert.Equal(t, []string{
+			"slack:source:T0456DEF",
+			"slack:channel:T0456DEF:C099VUEKVBN",
+			"slack:thread:T0456DEF:C099VUEKVBN:1765613134.990399",
+		}, contextIDs(got[1].Contexts))
+		assert.Equal(t, ptrString("Slack: T0456DEF"), got[1].Contexts[0].Title)
+		assert.Equal(t, ptrString("https://acme.enterprise.slack.com"), got[1].Contexts[0].Url)
+	}
+}
+
+func contextIDs(contexts []*core.Context) []string {
+	ids := make([]string, len(contexts))
+	for i, c := range contexts {
+		ids[i] = c.Id
+	}
+	return ids
+}
+
 func ptrString(s string) *string {
 	return &s
 }
@@ -0,0 +1,127 @@
+package fetch
+
+import (
+	"fmt"
+	"slack-connector/internal/core"
+	"slack-connector/internal/render"
+	"strconv"
+	"time"
+)
+
+// maxFilePages bounds the pages of files.list read per run
+const maxFilePages = 10
+
+// fetchFileActivities returns a file_shared activity for every file the user uploaded on the
+// target date, attached to each conversation the file was shared to
+func (f *ActivityFetcher) fetchFileActivities(gen *core.ContextGenerator, renderer *render.Renderer) []*Activity {
+	tsFrom := strconv.FormatInt(f.config.startTime.Unix(), 10)
+	tsTo := strconv.FormatInt(f.config.endTime.Unix(), 10)
+
+	files := []map[string]any{}
+	for page := 1; page <= maxFilePages; page++ {
+		response, err := f.httpClient.FetchFiles(f.config.token, f.config.userID, gen.Team().ID, tsFrom, tsTo, page)
+		if err != nil {
+			f.logger.Warn(fmt.Sprintf("Failed to fetch files: %s", err.Error()))
+			return nil
+		}
+
+		items, _ := response["files"].([]any)
+		for _, item := range items {
+			if file, ok := item.(map[string]any); ok {
+				files = append(files, file)
+			}
+		}
+
+		paging, ok := response["paging"].(map[string]any)
+		if !ok {
+			break
+		}
+		pages, _ := paging["pages"].(float64)
+		if float64(page) >= pages {
+			break
+		}
+	}
+
+	activities := []*Activity{}
+	for _, file := range files {
+		created, ok := file["created"].(float64)
+		if !ok {
+			continue
+		}
+		timestamp := time.Unix(int64(created), 0).UTC()
+		if timestamp.Before(f.config.startTime) || timestamp.After(f.config.endTime) {
+			continue
+		}
+
+		fileID := core.GetStringValue(file, "id")
+		name := core.GetStringValue(file, "name")
+		title := core.GetStringValue(file, "title")
+		if title == "" {
+			title = name
+		}
+		permalink := core.GetStringValue(file, "permalink")
+
+		contexts := []*core.Context{gen.CreateSourceContext()}
+		channelIDs := []string{}
+		filtered := 0
+		for _, key := range []string{"channels", "groups", "ims"} {
+			ids, _ := file[key].([]any)
+			for _, id := range ids {
+				channelID, ok := id.(string)
+				if !ok || channelID == "" {
+					continue
+				}
+				conversation := f.conversation(channelID, renderer)
+				if f.excluded(conversation) {
+					filtered++
+					continue
+				}
+				channelIDs = append(channelIDs, channelID)
+				contexts = append(contexts, conversationContext(gen, conversation))
+			}
+		}
+		if filtered > 0 && len(channelIDs) == 0 {
+			// Shared to excluded conversations only
+			continue
+		}
+
+		size, _ := file["size"].(float64)
+		activities = append(activities, &Activity{
+			Id:           core.MakeFileActivityID(fileID),
+			Timestamp:    timestamp,
+			Source:       core.ConnectorID,
+			ActivityType: "file_shared",
+			Title:        fmt.Sprintf("Shared file %s", title),
+			Description:  fileDescription(file),
+			Url:          &permalink,
+			Metadata: map[string]any{
+				"file_id":     fileID,
+				"name":        name,
+				"filetype":    core.GetStringValue(file, "filetype"),
+				"size":        int64(size),
+				"channel_ids": channelIDs,
+			},
+			Contexts: contexts,
+		})
+	}
+
+	f.logger.Info(fmt.Sprintf("Found %d shared files", len(activities)))
+	return activities
+}
+
+// isFileShare reports whether a message shares files. History marks it with the file_share
+// subtype, while search matches only carry the files.
+func isFileShare(message map[string]any) bool {
+	files, _ := message["files"].([]any)
+	return core.GetStringValue(message, "subtype") == "file_share" || len(files) > 0
+}
+
+// fileDescription describes a file by its name and type
+// Example: "design.pdf (PDF)"
+func fileDescription(file map[string]any) string {
+	name := core.GetStringValue(file, "name")
+	if prettyType := core.GetStringValue(file, "pretty_type"); prettyType != "" {
+		return fmt.Sprintf("%s (%s)", name, prettyType)
+	}
+	return name
+}
@@ -0,0 +1,86 @@
+package fetch
+
+import (
+	"slack-connector/internal/core"
+	mock_fetch "slack-connector/mock/fetch"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFetchActivities_Files(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+	mockHTTP.EXPECT().FetchMessages("token", "from:@U12345678 on:2025-12-13", "", 1).Return(map[string]any{
+		"messages": map[string]any{"matches": []any{
+			// The upload of the file is reported as a file_shared activity only
+			map[string]any{
+				"type":      "message",
+				"subtype":   "file_share",
+				"user":      "U12345678",
+				"ts":        "1765612000.000100",
+				"text":      "",
+				"channel":   map[string]any{"id": "C099VUEKVBN", "name": "general"},
+				"permalink": "https://test-workspace.slack.com/archives/C099VUEKVBN/p1765612000000100",
+				"files":     []any{map[string]any{"id": "F0123ABCDEF"}},
+			},
+		}},
+	}, nil).Times(1)
+	mockHTTP.EXPECT().FetchFiles("token", "U12345678", "", "1765584000", "1765670399", 1).Return(loadJSONTestData(t, "../../testdata/files/files.json"), nil).Times(1)
+	mockHTTP.EXPECT().FetchChannel("token", "C099VUEKVBN").Return(map[string]any{
+		"ok":      true,
+		"channel": map[string]any{"id": "C099VUEKVBN", "name": "general"},
+	}, nil).Times(1)
+	mockHTTP.EXPECT().FetchChannel("token", "D0123ABCDEF").Return(map[string]any{
+		"ok":      true,
+		"channel": map[string]any{"id": "D0123ABCDEF", "is_im": true, "user": "U0999ZZZ"},
+	}, nil).Times(1)
+	mockHTTP.EXPECT().FetchUser("token", "U0999ZZZ").Return(map[string]any{
+		"ok":   true,
+		"user": map[string]any{"id": "U0999ZZZ", "name": "carol", "profile": map[string]any{"display_name": "Carol"}},
+	}, nil).Times(1)
+
+	cfg := map[string]any{
+		"user_oauth_token":    "token",
+		"workspace_url":       "test-workspace.slack.com",
+		"user_id":             "U12345678",
+		"include_file_shares": true,
+	}
+	fetcher, err := NewActivityFetcher(mockHTTP, cfg, "2025-12-13", core.NewNoopLogger())
+	if err != nil {
+		t.Fatalf("Failed to create ActivityFetcher: %v", err)
+	}
+
+	got, err := fetcher.FetchActivities()
+	assert.NoError(t, err)
+
+	gen := core.NewContextGenerator()
+	want := []*Activity{
+		{
+			ActivityType: "file_shared",
+			Source:       "slack",
+			Id:           "slack:file:F0123ABCDEF",
+			Title:        "Shared file Upload queue design",
+			Description:  "design.pdf (PDF)",
+			Url:          ptrString("https://test-workspace.slack.com/files/U12345678/F0123ABCDEF/design.pdf"),
+			Timestamp:    time.Date(2025, 12, 13, 7, 46, 40, 0, time.UTC),
+			Metadata: map[string]any{
+				"file_id":     "F0123ABCDEF",
+				"name":        "design.pdf",
+				"filetype":    "pdf",
+				"size":        int64(48213),
+				"channel_ids": []string{"C099VUEKVBN", "D0123ABCDEF"},
+			},
+			Contexts: []*core.Context{
+				gen.CreateSourceContext(),
+				gen.CreateChannelContext("C099VUEKVBN", "general"),
+				gen.CreateDirectMessageContext("D0123ABCDEF", "DM with Carol"),
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
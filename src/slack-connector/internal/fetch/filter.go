@@ -0,0 +1,161 @@
+package fetch
+
+import (
+	"fmt"
+	"path"
+	"slack-connector/internal/render"
+	"strings"
+)
+
+// Conversation types that can be excluded with excluded_conversation_types
+const (
+	ConversationTypePublicChannel  = "public_channel"
+	ConversationTypePrivateChannel = "private_channel"
+	// ConversationTypeSharedChannel is a channel shared with other organizations (Slack Connect)
+	ConversationTypeSharedChannel = "shared_channel"
+	ConversationTypeIM            = "im"
+	ConversationTypeMPIM          = "mpim"
+)
+
+// channelFilter decides which conversations activities are reported for. Channel name patterns
+// are globs (* and ?) matched against the name without '#'; they apply to channels only, since
+// DMs and group DMs have no name of their own. When include patterns are given, only channels
+// matching one of them are reported. Exclude patterns and excluded types always win.
+type channelFilter struct {
+	include       []string
+	exclude       []string
+	excludedTypes map[string]bool
+}
+
+func newChannelFilter(cfg map[string]any) (*channelFilter, error) {
+	include, err := channelPatterns(cfg, "included_channel_patterns")
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := channelPatterns(cfg, "excluded_channel_patterns")
+	if err != nil {
+		return nil, err
+	}
+
+	excludedTypes := map[string]bool{}
+	if value, ok := cfg["excluded_conversation_types"]; ok && value != nil {
+		items, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("excluded_conversation_types must be an array")
+		}
+		for _, item := range items {
+			conversationType, _ := item.(string)
+			switch conversationType {
+			case ConversationTypePublicChannel, ConversationTypePrivateChannel, ConversationTypeSharedChannel, ConversationTypeIM, ConversationTypeMPIM:
+				excludedTypes[conversationType] = true
+			default:
+				return nil, fmt.Errorf("invalid excluded_conversation_types: %v", item)
+			}
+		}
+	}
+	// exclude_direct_messages is a shorthand for excluding both kinds of DMs
+	if excludeDirectMessages, _ := cfg["exclude_direct_messages"].(bool); excludeDirectMessages {
+		excludedTypes[ConversationTypeIM] = true
+		excludedTypes[ConversationTypeMPIM] = true
+	}
+
+	return &channelFilter{
+		include:       include,
+		exclude:       exclude,
+		excludedTypes: excludedTypes,
+	}, nil
+}
+
+// channelPatterns reads an array of channel name globs, dropping a leading '#'
+func channelPatterns(cfg map[string]any, key string) ([]string, error) {
+	value, ok := cfg[key]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an array", key)
+	}
+
+	patterns := []string{}
+	for i, item := range items {
+		pattern, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s[%d] must be a string", key, i)
+		}
+		pattern = strings.TrimPrefix(strings.TrimSpace(pattern), "#")
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid %s[%d]: %s", key, i, pattern)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// allows reports whether activities in the conversation are reported. Only the ID, kind, name
+// and visibility of the conversation are used, so it may come from render.NewConversation.
+func (f *channelFilter) allows(conversation render.Conversation) bool {
+	if f.excludedTypes[conversationType(conversation)] {
+		return false
+	}
+	if conversation.Shared && f.excludedTypes[ConversationTypeSharedChannel] {
+		return false
+	}
+	if conversation.IsDirectMessage() {
+		return true
+	}
+
+	if matchAny(f.exclude, conversation.Name) {
+		return false
+	}
+	return len(f.include) == 0 || matchAny(f.include, conversation.Name)
+}
+
+// searchModifiers returns the search.messages modifiers that apply the filter in the query.
+// Only exact channel names can be expressed: each excluded name becomes "-in:#name", and a single
+// included name becomes "in:#name". The filter is still applied to the results.
+func (f *channelFilter) searchModifiers() []string {
+	modifiers := []string{}
+	// DMs pass the filter regardless of the include patterns, so the query may only be narrowed
+	// to the channel when they are excluded
+	dmsExcluded := f.excludedTypes[ConversationTypeIM] && f.excludedTypes[ConversationTypeMPIM]
+	if dmsExcluded && len(f.include) == 1 && isExactPattern(f.include[0]) {
+		modifiers = append(modifiers, "in:#"+f.include[0])
+	}
+	for _, pattern := range f.exclude {
+		if isExactPattern(pattern) {
+			modifiers = append(modifiers, "-in:#"+pattern)
+		}
+	}
+	return modifiers
+}
+
+// conversationType returns the excluded_conversation_types value of a conversation
+func conversationType(conversation render.Conversation) string {
+	switch {
+	case conversation.Kind == render.KindIM:
+		return ConversationTypeIM
+	case conversation.Kind == render.KindMPIM:
+		return ConversationTypeMPIM
+	case conversation.Private:
+		return ConversationTypePrivateChannel
+	default:
+		return ConversationTypePublicChannel
+	}
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func isExactPattern(pattern string) bool {
+	return !strings.ContainsAny(pattern, `*?[\`)
+}
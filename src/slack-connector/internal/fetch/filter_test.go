@@ -0,0 +1,113 @@
+package fetch
+
+import (
+	"slack-connector/internal/render"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelFilter_Allows(t *testing.T) {
+	general := render.Conversation{ID: "C1", Kind: render.KindChannel, Name: "general"}
+	teamAPI := render.Conversation{ID: "C2", Kind: render.KindChannel, Name: "team-api"}
+	private := render.Conversation{ID: "C3", Kind: render.KindChannel, Name: "team-secret", Private: true}
+	shared := render.Conversation{ID: "C4", Kind: render.KindChannel, Name: "team-partner", Shared: true}
+	dm := render.Conversation{ID: "D1", Kind: render.KindIM, Name: "Alice", Private: true}
+	groupDM := render.Conversation{ID: "G1", Kind: render.KindMPIM, Name: "Alice, Bob", Private: true}
+
+	tests := []struct {
+		name string
+		cfg  map[string]any
+		want map[string]bool
+	}{
+		{
+			name: "no filter",
+			cfg:  map[string]any{},
+			want: map[string]bool{"C1": true, "C2": true, "C3": true, "C4": true, "D1": true, "G1": true},
+		},
+		{
+			name: "include patterns apply to channels only",
+			cfg:  map[string]any{"included_channel_patterns": []any{"team-*"}},
+			want: map[string]bool{"C1": false, "C2": true, "C3": true, "C4": true, "D1": true, "G1": true},
+		},
+		{
+			name: "exclude patterns win over include patterns",
+			cfg: map[string]any{
+				"included_channel_patterns": []any{"team-*"},
+				"excluded_channel_patterns": []any{"#team-s?cret"},
+			},
+			want: map[string]bool{"C1": false, "C2": true, "C3": false, "C4": true, "D1": true, "G1": true},
+		},
+		{
+			name: "excluded conversation types",
+			cfg:  map[string]any{"excluded_conversation_types": []any{"private_channel", "shared_channel", "mpim"}},
+			want: map[string]bool{"C1": true, "C2": true, "C3": false, "C4": false, "D1": true, "G1": false},
+		},
+		{
+			name: "exclude_direct_messages",
+			cfg:  map[string]any{"exclude_direct_messages": true},
+			want: map[string]bool{"C1": true, "C2": true, "C3": true, "C4": true, "D1": false, "G1": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newChannelFilter(tt.cfg)
+			assert.NoError(t, err)
+
+			got := map[string]bool{}
+			for _, conversation := range []render.Conversation{general, teamAPI, private, shared, dm, groupDM} {
+				got[conversation.ID] = filter.allows(conversation)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChannelFilter_SearchModifiers(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  map[string]any
+		want []string
+	}{
+		{
+			name: "no filter",
+			cfg:  map[string]any{},
+			want: []string{},
+		},
+		{
+			name: "exact exclusions",
+			cfg:  map[string]any{"excluded_channel_patterns": []any{"random", "alerts-*", "#social"}},
+			want: []string{"-in:#random", "-in:#social"},
+		},
+		{
+			name: "single channel is not pushed down while DMs are included",
+			cfg:  map[string]any{"included_channel_patterns": []any{"team-api"}},
+			want: []string{},
+		},
+		{
+			name: "single channel without DMs",
+			cfg: map[string]any{
+				"included_channel_patterns": []any{"team-api"},
+				"exclude_direct_messages":   true,
+			},
+			want: []string{"in:#team-api"},
+		},
+		{
+			name: "several channels are not pushed down",
+			cfg: map[string]any{
+				"included_channel_patterns": []any{"team-api", "team-web"},
+				"exclude_direct_messages":   true,
+			},
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newChannelFilter(tt.cfg)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, filter.searchModifiers())
+		})
+	}
+}
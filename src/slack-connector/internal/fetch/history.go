@@ -0,0 +1,232 @@
+package fetch
+
+import (
+	"errors"
+	"fmt"
+	"slack-connector/internal/core"
+	"slack-connector/internal/render"
+	"slack-connector/internal/slackapi"
+	"strings"
+	"time"
+)
+
+// userMessageSubtypes are the message subtypes written by the user. Other subtypes (joins, topic
+// changes, bot messages) are not activities.
+var userMessageSubtypes = map[string]bool{
+	"":                 true,
+	"thread_broadcast": true,
+	"file_share":       true,
+	"me_message":       true,
+}
+
+// fetchHistoryMessages scans the history of every conversation the user is a member of for the
+// messages the user posted on the target date, including replies to threads started up to
+// threadLookbackDays earlier. The messages are shaped like search.messages matches, with permalinks
+// on the domain of the team.
+func (f *ActivityFetcher) fetchHistoryMessages(team core.Team) ([]map[string]any, error) {
+	conversations, err := f.fetchUserConversations(team.ID)
+	if err != nil {
+		return nil, err
+	}
+	f.logger.Info(fmt.Sprintf("Scanning the history of %d conversations", len(conversations)))
+
+	username := f.username()
+	oldest := formatSlackTime(f.config.startTime)
+	latest := formatSlackTime(f.config.endTime)
+	rootsOldest := formatSlackTime(f.config.startTime.AddDate(0, 0, -f.config.threadLookbackDays))
+
+	allMessages := []map[string]any{}
+	for _, conversation := range conversations {
+		if f.excluded(render.NewConversation(conversation)) {
+			continue
+		}
+
+		channelID := core.GetStringValue(conversation, "id")
+		channel := map[string]any{
+			"id":      channelID,
+			"name":    conversationName(conversation),
+			"is_im":   conversation["is_im"] == true,
+			"is_mpim": conversation["is_mpim"] == true,
+		}
+		seen := map[string]bool{}
+		add := func(message map[string]any) {
+			ts := core.GetStringValue(message, "ts")
+			if seen[ts] || !f.isOwnMessage(message) || !f.postedOnTargetDate(ts) {
+				return
+			}
+			seen[ts] = true
+			allMessages = append(allMessages, f.toSearchMatch(message, channel, username, f.workspaceHost(team)))
+		}
+
+		err := f.scanConversation(channelID, rootsOldest, oldest, latest, add)
+		switch {
+		case err == nil:
+		case isAuthError(err):
+			return nil, err
+		case errors.Is(err, slackapi.ErrRateLimited):
+			// Waiting for the rate limit would stall the fetch, so the messages found so far are kept
+			f.logger.Warn(fmt.Sprintf("Stopped scanning conversation history, keeping %d messages: %s", len(allMessages), err.Error()))
+			return allMessages, nil
+		default:
+			f.logger.Warn(fmt.Sprintf("Failed to scan the history of %s: %s", channelID, err.Error()))
+		}
+	}
+
+	return allMessages, nil
+}
+
+// scanConversation passes the messages of a conversation and of its threads with replies on the
+// target date to add. A thread whose replies cannot be read is skipped, unless the error would
+// fail every other request as well.
+func (f *ActivityFetcher) scanConversation(channelID, rootsOldest, oldest, latest string, add func(map[string]any)) error {
+	history, err := f.listPages("conversations.history", "messages", slackapi.MaxCursorPages, func(cursor string) (map[string]any, error) {
+		return f.httpClient.FetchHistory(f.config.token, channelID, rootsOldest, latest, cursor)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, message := range history {
+		add(message)
+
+		// Only threads with replies on or after the target date need to be read
+		latestReply, err := convertSlackTSToTime(core.GetStringValue(message, "latest_reply"))
+		if err != nil || latestReply.Before(f.config.startTime) {
+			continue
+		}
+		threadTS := core.GetStringValue(message, "ts")
+		replies, err := f.listPages("conversations.replies", "messages", slackapi.MaxCursorPages, func(cursor string) (map[string]any, error) {
+			return f.httpClient.FetchReplies(f.config.token, channelID, threadTS, oldest, latest, cursor)
+		})
+		if isAuthError(err) || errors.Is(err, slackapi.ErrRateLimited) {
+			return err
+		}
+		if err != nil {
+			f.logger.Warn(fmt.Sprintf("Failed to read the replies of %s in %s: %s", threadTS, channelID, err.Error()))
+			continue
+		}
+		for _, reply := range replies {
+			add(reply)
+		}
+	}
+	return nil
+}
+
+// isAuthError reports whether err means the token cannot be used at all
+func isAuthError(err error) bool {
+	return errors.Is(err, slackapi.ErrInvalidAuth) || errors.Is(err, slackapi.ErrTokenRevoked) || errors.Is(err, slackapi.ErrTokenExpired)
+}
+
+// fetchUserConversations lists the conversations the user is a member of in a workspace; teamID
+// is "" for a workspace token
+func (f *ActivityFetcher) fetchUserConversations(teamID string) ([]map[string]any, error) {
+	return f.listPages("users.conversations", "channels", slackapi.MaxCursorPages, func(cursor string) (map[string]any, error) {
+		return f.httpClient.FetchUserConversations(f.config.token, f.config.userID, teamID, cursor)
+	})
+}
+
+// workspaceHost returns the host name permalinks are built on: the domain of the team, or
+// workspace_url for a single workspace
+func (f *ActivityFetcher) workspaceHost(team core.Team) string {
+	if team.Domain != "" {
+		return team.Domain
+	}
+	return f.config.workspaceHost
+}
+
+// listPages reads up to maxPages pages of a cursor-paginated method, warning when the list is cut
+// off
+func (f *ActivityFetcher) listPages(method, key string, maxPages int, page slackapi.PageFunc) ([]map[string]any, error) {
+	result, err := slackapi.ListCursorPages(method, key, maxPages, page)
+	if err != nil {
+		return nil, err
+	}
+	if result.Truncated {
+		f.logger.Warn(fmt.Sprintf("Stopped listing %s after %d pages (%d items)", method, maxPages, len(result.Items)))
+	}
+	return result.Items, nil
+}
+
+// username returns the name of the user, which search.messages reports with each match. The
+// user ID is used when the lookup fails.
+func (f *ActivityFetcher) username() string {
+	response, err := f.httpClient.FetchUser(f.config.token, f.config.userID)
+	if err != nil {
+		f.logger.Warn(fmt.Sprintf("Failed to look up user %s: %s", f.config.userID, err.Error()))
+		return f.config.userID
+	}
+	if name := getNestedString(response, "user", "name"); name != "" {
+		return name
+	}
+	return f.config.userID
+}
+
+func (f *ActivityFetcher) isOwnMessage(message map[string]any) bool {
+	return core.GetStringValue(message, "user") == f.config.userID &&
+		userMessageSubtypes[core.GetStringValue(message, "subtype")]
+}
+
+func (f *ActivityFetcher) postedOnTargetDate(ts string) bool {
+	postedAt, err := convertSlackTSToTime(ts)
+	if err != nil {
+		return false
+	}
+	return !postedAt.Before(f.config.startTime) && !postedAt.After(f.config.endTime)
+}
+
+// toSearchMatch adds the fields search.messages reports with a match to a history message
+func (f *ActivityFetcher) toSearchMatch(message, channel map[string]any, username, host string) map[string]any {
+	match := make(map[string]any, len(message)+3)
+	for key, value := range message {
+		match[key] = value
+	}
+
+	ts := core.GetStringValue(message, "ts")
+	channelID := core.GetStringValue(channel, "id")
+	permalink := fmt.Sprintf("https://%s/archives/%s/p%s", host, channelID, formatPermalinkTS(ts))
+	if threadTS := core.GetStringValue(message, "thread_ts"); threadTS != "" && threadTS != ts {
+		permalink += fmt.Sprintf("?thread_ts=%s&cid=%s", threadTS, channelID)
+	}
+
+	match["channel"] = channel
+	match["permalink"] = permalink
+	match["username"] = username
+	return match
+}
+
+// conversationName returns the name search.messages reports for a conversation. DMs have no name
+// and are reported with the ID of the other user.
+func conversationName(conversation map[string]any) string {
+	if name := core.GetStringValue(conversation, "name"); name != "" {
+		return name
+	}
+	return core.GetStringValue(conversation, "user")
+}
+
+// formatSlackTime converts a time to a Slack timestamp
+// Example: 2025-12-13T07:35:21.248519Z -> "1765611321.248519"
+func formatSlackTime(t time.Time) string {
+	return fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/1000)
+}
+
+// formatPermalinkTS converts a Slack timestamp to its permalink form
+// Example: "1765611321.248519" -> "1765611321248519"
+func formatPermalinkTS(ts string) string {
+	return strings.ReplaceAll(ts, ".", "")
+}
+
+// getNestedString safely extracts nested string value
+func getNestedString(m map[string]any, keys ...string) string {
+	current := m
+	for i, key := range keys {
+		if i == len(keys)-1 {
+			return core.GetStringValue(current, key)
+		}
+		nested, ok := current[key].(map[string]any)
+		if !ok {
+			return ""
+		}
+		current = nested
+	}
+	return ""
+}
@@ -0,0 +1,247 @@
+package fetch
+
+import (
+	"slack-connector/internal/core"
+	"slack-connector/internal/slackapi"
+	mock_fetch "slack-connector/mock/fetch"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFetchActivities_History(t *testing.T) {
+	// 2025-12-13 00:00:00 UTC to 23:59:59 UTC, with threads started from 2025-12-06
+	const (
+		rootsOldest = "1764979200.000000"
+		oldest      = "1765584000.000000"
+		latest      = "1765670399.999999"
+	)
+	expectConversations := func(mockHTTP *mock_fetch.MockHTTPClient) {
+		mockHTTP.EXPECT().FetchUserConversations("token", "U12345678", "", "").Return(loadJSONTestData(t, "../../testdata/history/conversations.json"), nil).Times(1)
+		mockHTTP.EXPECT().FetchUser("token", "U12345678").Return(map[string]any{
+			"ok":   true,
+			"user": map[string]any{"id": "U12345678", "name": "sd099rsefgdb_user"},
+		}, nil).Times(1)
+	}
+	expectHistory := func(mockHTTP *mock_fetch.MockHTTPClient) {
+		expectConversations(mockHTTP)
+		mockHTTP.EXPECT().FetchHistory("token", "C099VUEKVBN", rootsOldest, latest, "").Return(loadJSONTestData(t, "../../testdata/history/history_page1.json"), nil).Times(1)
+		mockHTTP.EXPECT().FetchHistory("token", "C099VUEKVBN", rootsOldest, latest, "bmV4dF90czoxNzY1NjAwMDAw").Return(loadJSONTestData(t, "../../testdata/history/history_page2.json"), nil).Times(1)
+		mockHTTP.EXPECT().FetchReplies("token", "C099VUEKVBN", "1765450000.000100", oldest, latest, "").Return(loadJSONTestData(t, "../../testdata/history/replies.json"), nil).Times(1)
+		mockHTTP.EXPECT().FetchHistory("token", "D0123ABCDEF", rootsOldest, latest, "").Return(map[string]any{"ok": true, "messages": []any{}}, nil).Times(1)
+	}
+
+	gen := core.NewContextGenerator()
+	want := []*Activity{
+		{
+			ActivityType: "message",
+			Source:       "slack",
+			Id:           "slack:1765611321.248519",
+			Title:        "Message in #general",
+			Description:  "Deployed the upload queue",
+			Url:          ptrString("https://test-workspace.slack.com/archives/C099VUEKVBN/p1765611321248519"),
+			Timestamp:    mustConvertSlackTS(t, "1765611321.248519"),
+			Metadata: map[string]any{
+				"channel_id":   "C099VUEKVBN",
+				"channel_name": "general",
+				"user":         "sd099rsefgdb_user",
+				"thread_ts":    "1765611321.248519",
+				"team":         "T099VUE950C",
+				"text":         "Deployed the upload queue",
+			},
+			Contexts: []*core.Context{
+				gen.CreateSourceContext(),
+				gen.CreateChannelContext("C099VUEKVBN", "general"),
+				gen.CreateThreadContext("C099VUEKVBN", "1765611321.248519"),
+			},
+		},
+		{
+			ActivityType: "message",
+			Source:       "slack",
+			Id:           "slack:1765613227.980829",
+			Title:        "Message in #general",
+			Description:  "Looks good to me",
+			Url:          ptrString("https://test-workspace.slack.com/archives/C099VUEKVBN/p1765613227980829?thread_ts=1765450000.000100&cid=C099VUEKVBN"),
+			Timestamp:    mustConvertSlackTS(t, "1765613227.980829"),
+			Metadata: map[string]any{
+				"channel_id":   "C099VUEKVBN",
+				"channel_name": "general",
+				"user":         "sd099rsefgdb_user",
+				"thread_ts":    "1765450000.000100",
+				"team":         "T099VUE950C",
+				"text":         "Looks good to me",
+			},
+			Contexts: []*core.Context{
+				gen.CreateSourceContext(),
+				gen.CreateChannelContext("C099VUEKVBN", "general"),
+				gen.CreateThreadContext("C099VUEKVBN", "1765450000.000100"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		getMockHTTP func(*gomock.Controller) HTTPClient
+		cfg         map[string]any
+		want        []*Activity
+		wantErr     bool
+	}{
+		{
+			name: "history fetch mode",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				expectHistory(mockHTTP)
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"user_oauth_token": "token",
+				"workspace_url":    "test-workspace.slack.com",
+				"user_id":          "U12345678",
+				"fetch_mode":       "history",
+			},
+			want:    want,
+			wantErr: false,
+		},
+		{
+			name: "history fetch mode with a workspace_url including the scheme",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				expectHistory(mockHTTP)
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"user_oauth_token": "token",
+				"workspace_url":    "https://test-workspace.slack.com/",
+				"user_id":          "U12345678",
+				"fetch_mode":       "history",
+			},
+			want:    want,
+			wantErr: false,
+		},
+		{
+			name: "fall back to history when search is not allowed",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchMessages("token", "from:@U12345678 on:2025-12-13", "", 1).Return(nil, &slackapi.Error{
+					Method: "search.messages",
+					Code:   "not_allowed_token_type",
+				}).Times(1)
+				expectHistory(mockHTTP)
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"user_oauth_token": "token",
+				"workspace_url":    "test-workspace.slack.com",
+				"user_id":          "U12345678",
+			},
+			want:    want,
+			wantErr: false,
+		},
+		{
+			name: "skip a conversation whose history cannot be read",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				expectConversations(mockHTTP)
+				mockHTTP.EXPECT().FetchHistory("token", "C099VUEKVBN", rootsOldest, latest, "").Return(loadJSONTestData(t, "../../testdata/history/history_page1.json"), nil).Times(1)
+				mockHTTP.EXPECT().FetchHistory("token", "C099VUEKVBN", rootsOldest, latest, "bmV4dF90czoxNzY1NjAwMDAw").Return(loadJSONTestData(t, "../../testdata/history/history_page2.json"), nil).Times(1)
+				mockHTTP.EXPECT().FetchReplies("token", "C099VUEKVBN", "1765450000.000100", oldest, latest, "").Return(nil, &slackapi.Error{
+					Method: "conversations.replies",
+					Code:   "thread_not_found",
+				}).Times(1)
+				mockHTTP.EXPECT().FetchHistory("token", "D0123ABCDEF", rootsOldest, latest, "").Return(nil, &slackapi.Error{
+					Method: "conversations.history",
+					Code:   "channel_not_found",
+				}).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"user_oauth_token": "token",
+				"workspace_url":    "test-workspace.slack.com",
+				"user_id":          "U12345678",
+				"fetch_mode":       "history",
+			},
+			want:    want[:1],
+			wantErr: false,
+		},
+		{
+			name: "keep the messages found before being rate limited",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				expectConversations(mockHTTP)
+				mockHTTP.EXPECT().FetchHistory("token", "C099VUEKVBN", rootsOldest, latest, "").Return(loadJSONTestData(t, "../../testdata/history/history_page1.json"), nil).Times(1)
+				mockHTTP.EXPECT().FetchHistory("token", "C099VUEKVBN", rootsOldest, latest, "bmV4dF90czoxNzY1NjAwMDAw").Return(loadJSONTestData(t, "../../testdata/history/history_page2.json"), nil).Times(1)
+				mockHTTP.EXPECT().FetchReplies("token", "C099VUEKVBN", "1765450000.000100", oldest, latest, "").Return(nil, &slackapi.Error{
+					Method:     "conversations.replies",
+					Code:       "ratelimited",
+					RetryAfter: 30,
+				}).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"user_oauth_token": "token",
+				"workspace_url":    "test-workspace.slack.com",
+				"user_id":          "U12345678",
+				"fetch_mode":       "history",
+			},
+			want:    want[:1],
+			wantErr: false,
+		},
+		{
+			name: "fail when the token is revoked",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				expectConversations(mockHTTP)
+				mockHTTP.EXPECT().FetchHistory("token", "C099VUEKVBN", rootsOldest, latest, "").Return(nil, &slackapi.Error{
+					Method: "conversations.history",
+					Code:   "token_revoked",
+				}).Times(1)
+				return mockHTTP
+			},
+			cfg: map[string]any{
+				"user_oauth_token": "token",
+				"workspace_url":    "test-workspace.slack.com",
+				"user_id":          "U12345678",
+				"fetch_mode":       "history",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			fetcher, err := NewActivityFetcher(tt.getMockHTTP(ctrl), tt.cfg, "2025-12-13", core.NewNoopLogger())
+			if err != nil {
+				t.Fatalf("Failed to create ActivityFetcher: %v", err)
+			}
+
+			got, err := fetcher.FetchActivities()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatSlackTime(t *testing.T) {
+	assert.Equal(t, "1765611321.248519", formatSlackTime(time.Date(2025, 12, 13, 7, 35, 21, 248519000, time.UTC)))
+	assert.Equal(t, "1765584000.000000", formatSlackTime(time.Date(2025, 12, 13, 0, 0, 0, 0, time.UTC)))
+}
+
+func mustConvertSlackTS(t *testing.T, ts string) time.Time {
+	t.Helper()
+
+	got, err := convertSlackTSToTime(ts)
+	if err != nil {
+		t.Fatalf("Failed to convert timestamp: %v", err)
+	}
+	return got
+}
@@ -1,5 +1,27 @@
 package fetch
 
+// HTTPClient calls the Slack API. A teamID selects a workspace of an Enterprise Grid org for an
+// org-level token, and is "" for a workspace token.
 type HTTPClient interface {
-	FetchMessages(token, userID, targetDate string, page int) (map[string]any, error)
+	// FetchMessages lists a page of search.messages matches for a query
+	FetchMessages(token, query, teamID string, page int) (map[string]any, error)
+	// FetchUserConversations lists a page of the channels, DMs and group DMs the user is a member of
+	FetchUserConversations(token, userID, teamID, cursor string) (map[string]any, error)
+	// FetchHistory lists a page of the top-level messages of a conversation posted between oldest
+	// and latest
+	FetchHistory(token, channelID, oldest, latest, cursor string) (map[string]any, error)
+	// FetchReplies lists a page of the messages of a thread posted between oldest and latest
+	FetchReplies(token, channelID, threadTS, oldest, latest, cursor string) (map[string]any, error)
+	// FetchReactions lists a page of the items the user reacted to, newest first
+	FetchReactions(token, userID, teamID, cursor string) (map[string]any, error)
+	// FetchFiles lists a page of the files the user shared between tsFrom and tsTo
+	FetchFiles(token, userID, teamID, tsFrom, tsTo string, page int) (map[string]any, error)
+	// FetchPins lists the items pinned to a conversation
+	FetchPins(token, channelID string) (map[string]any, error)
+	// FetchTeam looks up a workspace with team.info
+	FetchTeam(token, teamID string) (map[string]any, error)
+	FetchUser(token, userID string) (map[string]any, error)
+	FetchChannel(token, channelID string) (map[string]any, error)
+	FetchUserGroups(token string) (map[string]any, error)
+	FetchMembers(token, channelID string) (map[string]any, error)
 }
@@ -0,0 +1,88 @@
+package fetch
+
+import (
+	"fmt"
+	"slack-connector/internal/core"
+	"slack-connector/internal/render"
+	"time"
+)
+
+// fetchPinActivities returns a pinned activity for every message the user pinned on the target
+// date. Slack has no per-user list of pins, so the pins of every conversation the user is a member
+// of are read.
+func (f *ActivityFetcher) fetchPinActivities(gen *core.ContextGenerator, renderer *render.Renderer) []*Activity {
+	conversations, err := f.fetchUserConversations(gen.Team().ID)
+	if err != nil {
+		f.logger.Warn(fmt.Sprintf("Failed to list conversations for pins: %s", err.Error()))
+		return nil
+	}
+
+	activities := []*Activity{}
+	for _, conversation := range conversations {
+		if f.excluded(render.NewConversation(conversation)) {
+			continue
+		}
+
+		channelID := core.GetStringValue(conversation, "id")
+		response, err := f.httpClient.FetchPins(f.config.token, channelID)
+		if err != nil {
+			f.logger.Warn(fmt.Sprintf("Failed to fetch pins of %s: %s", channelID, err.Error()))
+			continue
+		}
+
+		items, _ := response["items"].([]any)
+		for _, i := range items {
+			item, ok := i.(map[string]any)
+			if !ok || core.GetStringValue(item, "type") != "message" || core.GetStringValue(item, "created_by") != f.config.userID {
+				continue
+			}
+			created, ok := item["created"].(float64)
+			if !ok {
+				continue
+			}
+			timestamp := time.Unix(int64(created), 0).UTC()
+			if timestamp.Before(f.config.startTime) || timestamp.After(f.config.endTime) {
+				continue
+			}
+			message, ok := item["message"].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			ts := core.GetStringValue(message, "ts")
+			threadTS := core.GetStringValue(message, "thread_ts")
+			if threadTS == "" {
+				threadTS = ts
+			}
+			channel := renderer.Conversation(conversation, f.config.userID)
+			permalink := core.GetStringValue(message, "permalink")
+			text := renderer.RenderMessage(message)
+
+			activities = append(activities, &Activity{
+				Id:           core.MakePinActivityID(channelID, ts),
+				Timestamp:    timestamp,
+				Source:       core.ConnectorID,
+				ActivityType: "pinned",
+				Title:        fmt.Sprintf("Pinned a message in %s", channel.Label()),
+				Description:  text.Markdown,
+				Url:          &permalink,
+				Metadata: map[string]any{
+					"channel_id":   channelID,
+					"channel_name": channel.Name,
+					"message_ts":   ts,
+					"message_user": core.GetStringValue(message, "user"),
+					"thread_ts":    threadTS,
+					"text":         text.Plain,
+				},
+				Contexts: []*core.Context{
+					gen.CreateSourceContext(),
+					conversationContext(gen, channel),
+					gen.CreateThreadContext(channelID, threadTS),
+				},
+			})
+		}
+	}
+
+	f.logger.Info(fmt.Sprintf("Found %d pins", len(activities)))
+	return activities
+}
@@ -0,0 +1,66 @@
+package fetch
+
+import (
+	"errors"
+	"slack-connector/internal/core"
+	mock_fetch "slack-connector/mock/fetch"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFetchActivities_Pins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+	mockHTTP.EXPECT().FetchMessages("token", "from:@U12345678 on:2025-12-13", "", 1).Return(map[string]any{
+		"messages": map[string]any{"matches": []any{}},
+	}, nil).Times(1)
+	mockHTTP.EXPECT().FetchUserConversations("token", "U12345678", "", "").Return(loadJSONTestData(t, "../../testdata/history/conversations.json"), nil).Times(1)
+	mockHTTP.EXPECT().FetchPins("token", "C099VUEKVBN").Return(loadJSONTestData(t, "../../testdata/pins/pins.json"), nil).Times(1)
+	mockHTTP.EXPECT().FetchPins("token", "D0123ABCDEF").Return(nil, errors.New("channel_not_found")).Times(1)
+
+	cfg := map[string]any{
+		"user_oauth_token": "token",
+		"workspace_url":    "test-workspace.slack.com",
+		"user_id":          "U12345678",
+		"include_pins":     true,
+	}
+	fetcher, err := NewActivityFetcher(mockHTTP, cfg, "2025-12-13", core.NewNoopLogger())
+	if err != nil {
+		t.Fatalf("Failed to create ActivityFetcher: %v", err)
+	}
+
+	got, err := fetcher.FetchActivities()
+	assert.NoError(t, err)
+
+	gen := core.NewContextGenerator()
+	want := []*Activity{
+		{
+			ActivityType: "pinned",
+			Source:       "slack",
+			Id:           "slack:pin:C099VUEKVBN:1765450000.000100",
+			Title:        "Pinned a message in #general",
+			Description:  "Runbook for the upload queue",
+			Url:          ptrString("https://test-workspace.slack.com/archives/C099VUEKVBN/p1765450000000100"),
+			Timestamp:    time.Date(2025, 12, 13, 7, 55, 0, 0, time.UTC),
+			Metadata: map[string]any{
+				"channel_id":   "C099VUEKVBN",
+				"channel_name": "general",
+				"message_ts":   "1765450000.000100",
+				"message_user": "U0999OTHER",
+				"thread_ts":    "1765450000.000100",
+				"text":         "Runbook for the upload queue",
+			},
+			Contexts: []*core.Context{
+				gen.CreateSourceContext(),
+				gen.CreateChannelContext("C099VUEKVBN", "general"),
+				gen.CreateThreadContext("C099VUEKVBN", "1765450000.000100"),
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
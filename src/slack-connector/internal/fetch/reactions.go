@@ -0,0 +1,99 @@
+package fetch
+
+import (
+	"fmt"
+	"slack-connector/internal/core"
+	"slack-connector/internal/render"
+	"slices"
+)
+
+// fetchReactionActivities returns a reaction_added activity for every reaction the user added to a
+// message posted on the target date. Slack does not report when a reaction was added, so the
+// message time stands in for it (reported as timestamp_source "message") and reactions to older
+// messages are not reported.
+func (f *ActivityFetcher) fetchReactionActivities(gen *core.ContextGenerator, renderer *render.Renderer) []*Activity {
+	items, err := f.listPages("reactions.list", "items", f.config.reactionPages, func(cursor string) (map[string]any, error) {
+		return f.httpClient.FetchReactions(f.config.token, f.config.userID, gen.Team().ID, cursor)
+	})
+	if err != nil {
+		f.logger.Warn(fmt.Sprintf("Failed to fetch reactions: %s", err.Error()))
+		return nil
+	}
+
+	activities := []*Activity{}
+	for _, item := range items {
+		if core.GetStringValue(item, "type") != "message" {
+			continue
+		}
+		message, ok := item["message"].(map[string]any)
+		if !ok {
+			continue
+		}
+		ts := core.GetStringValue(message, "ts")
+		if !f.postedOnTargetDate(ts) {
+			continue
+		}
+
+		channelID := core.GetStringValue(item, "channel")
+		conversation := f.conversation(channelID, renderer)
+		if f.excluded(conversation) {
+			continue
+		}
+		threadTS := core.GetStringValue(message, "thread_ts")
+		if threadTS == "" {
+			threadTS = ts
+		}
+		timestamp, err := convertSlackTSToTime(ts)
+		if err != nil {
+			continue
+		}
+		permalink := core.GetStringValue(message, "permalink")
+		text := renderer.RenderMessage(message)
+
+		reactions, _ := message["reactions"].([]any)
+		for _, r := range reactions {
+			reaction, ok := r.(map[string]any)
+			if !ok || !containsUser(reaction["users"], f.config.userID) {
+				continue
+			}
+			name := core.GetStringValue(reaction, "name")
+			activities = append(activities, &Activity{
+				Id:           core.MakeReactionActivityID(channelID, ts, name),
+				Timestamp:    timestamp,
+				Source:       core.ConnectorID,
+				ActivityType: "reaction_added",
+				Title:        fmt.Sprintf("Reacted :%s: in %s", name, conversation.Label()),
+				Description:  text.Markdown,
+				Url:          &permalink,
+				Metadata: map[string]any{
+					"channel_id":   channelID,
+					"channel_name": conversation.Name,
+					"reaction":     name,
+					"message_ts":   ts,
+					"message_user": core.GetStringValue(message, "user"),
+					"thread_ts":    threadTS,
+					"text":         text.Plain,
+					// Slack does not report when the reaction was added
+					"timestamp_source": "message",
+				},
+				Contexts: []*core.Context{
+					gen.CreateSourceContext(),
+					conversationContext(gen, conversation),
+					gen.CreateThreadContext(channelID, threadTS),
+				},
+			})
+		}
+	}
+
+	f.logger.Info(fmt.Sprintf("Found %d reactions", len(activities)))
+	return activities
+}
+
+// containsUser reports whether a list of user IDs includes userID
+func containsUser(users any, userID string) bool {
+	list, ok := users.([]any)
+	if !ok {
+		return false
+	}
+	return slices.Contains(list, any(userID))
+}
@@ -0,0 +1,96 @@
+package fetch
+
+import (
+	"errors"
+	"slack-connector/internal/core"
+	mock_fetch "slack-connector/mock/fetch"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFetchActivities_Reactions(t *testing.T) {
+	gen := core.NewContextGenerator()
+	tests := []struct {
+		name        string
+		getMockHTTP func(*gomock.Controller) HTTPClient
+		want        []*Activity
+	}{
+		{
+			name: "reactions to messages posted on the target date",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchMessages("token", "from:@U12345678 on:2025-12-13", "", 1).Return(map[string]any{
+					"messages": map[string]any{"matches": []any{}},
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchReactions("token", "U12345678", "", "").Return(loadJSONTestData(t, "../../testdata/reactions/reactions.json"), nil).Times(1)
+				mockHTTP.EXPECT().FetchChannel("token", "C099VUEKVBN").Return(map[string]any{
+					"ok":      true,
+					"channel": map[string]any{"id": "C099VUEKVBN", "name": "general"},
+				}, nil).Times(1)
+				return mockHTTP
+			},
+			want: []*Activity{
+				{
+					ActivityType: "reaction_added",
+					Source:       "slack",
+					Id:           "slack:reaction:C099VUEKVBN:1765611321.248519:eyes",
+					Title:        "Reacted :eyes: in #general",
+					Description:  "Release notes are ready for review",
+					Url:          ptrString("https://test-workspace.slack.com/archives/C099VUEKVBN/p1765611321248519"),
+					Timestamp:    mustConvertSlackTS(t, "1765611321.248519"),
+					Metadata: map[string]any{
+						"channel_id":       "C099VUEKVBN",
+						"channel_name":     "general",
+						"reaction":         "eyes",
+						"message_ts":       "1765611321.248519",
+						"message_user":     "U0999OTHER",
+						"thread_ts":        "1765611321.248519",
+						"text":             "Release notes are ready for review",
+						"timestamp_source": "message",
+					},
+					Contexts: []*core.Context{
+						gen.CreateSourceContext(),
+						gen.CreateChannelContext("C099VUEKVBN", "general"),
+						gen.CreateThreadContext("C099VUEKVBN", "1765611321.248519"),
+					},
+				},
+			},
+		},
+		{
+			name: "reactions.list error is skipped",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_fetch.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchMessages("token", "from:@U12345678 on:2025-12-13", "", 1).Return(map[string]any{
+					"messages": map[string]any{"matches": []any{}},
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchReactions("token", "U12345678", "", "").Return(nil, errors.New("missing_scope")).Times(1)
+				return mockHTTP
+			},
+			want: []*Activity{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			cfg := map[string]any{
+				"user_oauth_token":  "token",
+				"workspace_url":     "test-workspace.slack.com",
+				"user_id":           "U12345678",
+				"include_reactions": true,
+			}
+			fetcher, err := NewActivityFetcher(tt.getMockHTTP(ctrl), cfg, "2025-12-13", core.NewNoopLogger())
+			if err != nil {
+				t.Fatalf("Failed to create ActivityFetcher: %v", err)
+			}
+
+			got, err := fetcher.FetchActivities()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package match
+
+import (
+	"fmt"
+	"slack-connector/internal/core"
+)
+
+type config struct {
+	token               string
+	workspaceHost       string
+	resolveChannelNames bool
+	// teamIDs are the workspaces of an Enterprise Grid org that links are matched for, or empty
+	// for the workspace of workspace_url
+	teamIDs []string
+}
+
+func newConfig(cfg map[string]any) (*config, error) {
+	workspaceURL, ok := cfg["workspace_url"].(string)
+	if !ok || workspaceURL == "" {
+		return nil, fmt.Errorf("missing workspace_url")
+	}
+
+	teamIDs, err := core.TeamIDsFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Channel names and workspaces are only looked up when enabled, so matching the permalinks of a
+	// single workspace needs no token by default; app links are skipped without one
+	resolveChannelNames, _ := cfg["resolve_channel_names"].(bool)
+	token, err := core.TokenFromConfig(cfg)
+	if (resolveChannelNames || len(teamIDs) > 0) && err != nil {
+		return nil, err
+	}
+
+	return &config{
+		token:               token,
+		workspaceHost:       core.WorkspaceHost(workspaceURL),
+		resolveChannelNames: resolveChannelNames,
+		teamIDs:             teamIDs,
+	}, nil
+}
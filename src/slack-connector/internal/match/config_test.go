@@ -0,0 +1,108 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        map[string]any
+		wantConfig *config
+		wantErr    bool
+	}{
+		{
+			name: "valid config",
+			cfg: map[string]any{
+				"workspace_url": "example.slack.com",
+			},
+			wantConfig: &config{
+				workspaceHost: "example.slack.com",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config - workspace URL with scheme",
+			cfg: map[string]any{
+				"user_oauth_token":      "valid_token",
+				"workspace_url":         "https://Example.slack.com/",
+				"resolve_channel_names": true,
+			},
+			wantConfig: &config{
+				token:               "valid_token",
+				workspaceHost:       "example.slack.com",
+				resolveChannelNames: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config - oauth web flow",
+			cfg: map[string]any{
+				"active_auth_method":    "oauth_web",
+				"oauth_access_token":    "oauth_token",
+				"workspace_url":         "example.slack.com",
+				"resolve_channel_names": true,
+			},
+			wantConfig: &config{
+				token:               "oauth_token",
+				workspaceHost:       "example.slack.com",
+				resolveChannelNames: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config - workspaces of an org",
+			cfg: map[string]any{
+				"user_oauth_token": "valid_token",
+				"workspace_url":    "acme.enterprise.slack.com",
+				"team_ids":         []any{"T0123ABC"},
+			},
+			wantConfig: &config{
+				token:         "valid_token",
+				workspaceHost: "acme.enterprise.slack.com",
+				teamIDs:       []string{"T0123ABC"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid config - workspaces of an org without user_oauth_token",
+			cfg: map[string]any{
+				"workspace_url": "acme.enterprise.slack.com",
+				"team_ids":      []any{"T0123ABC"},
+			},
+			wantConfig: nil,
+			wantErr:    true,
+		},
+		{
+			name: "invalid config - missing workspace_url",
+			cfg: map[string]any{
+				"user_oauth_token": "valid_token",
+			},
+			wantConfig: nil,
+			wantErr:    true,
+		},
+		{
+			name: "invalid config - resolving channel names without user_oauth_token",
+			cfg: map[string]any{
+				"workspace_url":         "example.slack.com",
+				"resolve_channel_names": true,
+			},
+			wantConfig: nil,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newConfig(tt.cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantConfig, got)
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package match
+
+type HTTPClient interface {
+	FetchChannel(token, channelID string) (map[string]any, error)
+	// FetchTeam looks up a workspace with team.info
+	FetchTeam(token, teamID string) (map[string]any, error)
+	// FetchAuth looks up the workspace of the token with auth.test
+	FetchAuth(token string) (map[string]any, error)
+}
@@ -0,0 +1,275 @@
+package match
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slack-connector/internal/core"
+	"slices"
+	"strings"
+)
+
+var (
+	reURL         = regexp.MustCompile(core.ContextPatternURL)
+	reMessagePath = regexp.MustCompile(core.ContextPatternMessagePath)
+	reChannelPath = regexp.MustCompile(core.ContextPatternChannelPath)
+	reChannelID   = regexp.MustCompile(core.ContextPatternChannelID)
+	reTS          = regexp.MustCompile(core.ContextPatternTS)
+)
+
+// Matcher builds the context hierarchies of Slack URLs found in text
+type Matcher struct {
+	httpClient HTTPClient
+	config     *config
+	logger     core.Logger
+	gen        *core.ContextGenerator
+
+	// channels and teams cache the channels and workspaces looked up during a single match request
+	channels map[string]map[string]any
+	teams    map[string]core.Team
+	// workspaceTeamID caches the team ID of workspace_url once workspaceChecked is set; it is ""
+	// when the token could not confirm it
+	workspaceTeamID  string
+	workspaceChecked bool
+}
+
+// NewMatcher creates a new Matcher instance. httpClient is only used when resolve_channel_names
+// is enabled, team_ids lists the workspaces of an org, or an app link is matched.
+func NewMatcher(httpClient HTTPClient, cfg map[string]any, logger core.Logger) (*Matcher, error) {
+	config, err := newConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Matcher{
+		httpClient: httpClient,
+		config:     config,
+		logger:     logger,
+		gen:        core.NewContextGenerator(),
+		channels:   map[string]map[string]any{},
+		teams:      map[string]core.Team{},
+	}, nil
+}
+
+// MatchURL returns the context hierarchies for every Slack URL of the configured workspace found
+// in text, or an empty slice if nothing matches. Contexts shared between URLs (e.g., the source)
+// appear only once.
+//
+// When team_ids lists the workspaces of an org, URLs of each of them are matched: permalinks by
+// the domain of the workspace, and app links by their team ID. Permalinks on the domain of
+// workspace_url (e.g., the org domain) belong to the workspace of their channel. Otherwise app
+// links are only matched when their team is the workspace of the token, which is looked up once
+// with auth.test; without a token they are skipped.
+//
+// Without resolve_channel_names or team_ids no API calls are made for permalinks and channels are
+// named after their IDs until they are enriched.
+func (m *Matcher) MatchURL(text string) []*core.Context {
+	contexts := []*core.Context{}
+	seen := map[string]bool{}
+
+	// Slack escapes "&" in message text
+	text = strings.ReplaceAll(text, "&amp;", "&")
+	for _, candidate := range reURL.FindAllString(text, -1) {
+		for _, c := range m.matchSingleURL(candidate) {
+			if seen[c.Id] {
+				continue
+			}
+			seen[c.Id] = true
+			contexts = append(contexts, c)
+		}
+	}
+
+	return contexts
+}
+
+// matchSingleURL returns the context hierarchy for a single URL extracted from text.
+func (m *Matcher) matchSingleURL(rawURL string) []*core.Context {
+	u, err := url.Parse(strings.TrimRight(rawURL, ".,;:!?"))
+	if err != nil {
+		return nil
+	}
+
+	switch u.Scheme {
+	case "https":
+		var channelID, threadTS string
+		if captures := reMessagePath.FindStringSubmatch(u.Path); captures != nil {
+			channelID = captures[1]
+			// A reply links to its thread with thread_ts; any other message is the root of its own thread
+			threadTS = u.Query().Get("thread_ts")
+			if !reTS.MatchString(threadTS) {
+				threadTS = permalinkTS(captures[2])
+			}
+		} else if captures := reChannelPath.FindStringSubmatch(u.Path); captures != nil {
+			channelID = captures[1]
+		} else {
+			return nil
+		}
+		gen := m.hostGenerator(strings.ToLower(u.Host), channelID)
+		if gen == nil {
+			return nil
+		}
+		return m.channelContexts(gen, channelID, threadTS, true)
+	case "slack":
+		// slack://channel?team={team_id}&id={channel_id} opens a channel in the desktop app
+		channelID := u.Query().Get("id")
+		if u.Host != "channel" || !reChannelID.MatchString(channelID) {
+			return nil
+		}
+		teamID := u.Query().Get("team")
+		if len(m.config.teamIDs) == 0 {
+			if teamID == "" || teamID != m.workspaceTeam() {
+				return nil
+			}
+			return m.channelContexts(m.gen, channelID, "", false)
+		}
+		team, ok := m.team(teamID)
+		if !ok {
+			return nil
+		}
+		return m.channelContexts(core.NewTeamContextGenerator(team), channelID, "", false)
+	}
+
+	return nil
+}
+
+// hostGenerator returns the context generator of the workspace a permalink host belongs to, or
+// nil if it is not a configured workspace
+func (m *Matcher) hostGenerator(host, channelID string) *core.ContextGenerator {
+	if len(m.config.teamIDs) == 0 {
+		if host != m.config.workspaceHost {
+			return nil
+		}
+		return m.gen
+	}
+
+	if host == m.config.workspaceHost {
+		// The org domain names no workspace, but the channel knows which one it belongs to
+		channel, err := m.channel(channelID)
+		if err != nil {
+			m.logger.Warn(fmt.Sprintf("Failed to look up channel %s: %s", channelID, err.Error()))
+			return nil
+		}
+		if team, ok := m.team(core.GetStringValue(channel, "context_team_id")); ok {
+			return core.NewTeamContextGenerator(team)
+		}
+		return nil
+	}
+	for _, teamID := range m.config.teamIDs {
+		if team, ok := m.team(teamID); ok && team.Domain == host {
+			return core.NewTeamContextGenerator(team)
+		}
+	}
+	return nil
+}
+
+// workspaceTeam returns the team ID of the workspace of workspace_url, or "" if it cannot be
+// confirmed: without a token, when auth.test fails, or when the token belongs to another
+// workspace
+func (m *Matcher) workspaceTeam() string {
+	if m.workspaceChecked {
+		return m.workspaceTeamID
+	}
+	m.workspaceChecked = true
+	if m.config.token == "" {
+		return ""
+	}
+
+	response, err := m.httpClient.FetchAuth(m.config.token)
+	if err != nil {
+		m.logger.Warn(fmt.Sprintf("Failed to look up the workspace of the token: %s", err.Error()))
+		return ""
+	}
+	if host := core.WorkspaceHost(core.GetStringValue(response, "url")); host != m.config.workspaceHost {
+		m.logger.Warn(fmt.Sprintf("The token belongs to %s rather than %s, skipping app links", host, m.config.workspaceHost))
+		return ""
+	}
+	m.workspaceTeamID = core.GetStringValue(response, "team_id")
+
+	return m.workspaceTeamID
+}
+
+// team looks up a workspace listed in team_ids. A workspace that cannot be looked up is named
+// after its ID on the domain of workspace_url.
+func (m *Matcher) team(teamID string) (core.Team, bool) {
+	if !slices.Contains(m.config.teamIDs, teamID) {
+		return core.Team{}, false
+	}
+	if team, ok := m.teams[teamID]; ok {
+		return team, true
+	}
+
+	team := core.Team{ID: teamID}
+	response, err := m.httpClient.FetchTeam(m.config.token, teamID)
+	if err != nil {
+		m.logger.Warn(fmt.Sprintf("Failed to look up workspace %s: %s", teamID, err.Error()))
+	} else if teamObj, ok := response["team"].(map[string]any); ok {
+		team = core.NewTeam(teamObj)
+		team.ID = teamID
+	}
+	if team.Domain == "" {
+		team.Domain = m.config.workspaceHost
+	}
+	m.teams[teamID] = team
+
+	return team, true
+}
+
+// channelContexts builds the source > channel (> thread) hierarchy. App links carry a team ID
+// rather than the workspace domain; when channel names are resolved, an app link to a channel the
+// token cannot see is assumed to belong to another workspace and yields no contexts.
+func (m *Matcher) channelContexts(gen *core.ContextGenerator, channelID, threadTS string, inWorkspace bool) []*core.Context {
+	name := channelID
+	if m.config.resolveChannelNames {
+		resolved, err := m.channelName(channelID)
+		if err != nil {
+			if !inWorkspace {
+				m.logger.Info(fmt.Sprintf("Skipping app link to channel %s: %s", channelID, err.Error()))
+				return nil
+			}
+			m.logger.Warn(fmt.Sprintf("Failed to resolve name of channel %s: %s", channelID, err.Error()))
+		} else if resolved != "" {
+			name = resolved
+		}
+	}
+
+	contexts := []*core.Context{
+		gen.CreateSourceContext(),
+		gen.CreateChannelContext(channelID, name),
+	}
+	if threadTS != "" {
+		contexts = append(contexts, gen.CreateThreadContext(channelID, threadTS))
+	}
+	return contexts
+}
+
+// channelName looks up the name of a channel. Direct messages have no name, in which case "" is
+// returned.
+func (m *Matcher) channelName(channelID string) (string, error) {
+	channel, err := m.channel(channelID)
+	if err != nil {
+		return "", err
+	}
+	return core.GetStringValue(channel, "name"), nil
+}
+
+// channel looks up a channel with conversations.info
+func (m *Matcher) channel(channelID string) (map[string]any, error) {
+	if channel, ok := m.channels[channelID]; ok {
+		return channel, nil
+	}
+
+	response, err := m.httpClient.FetchChannel(m.config.token, channelID)
+	if err != nil {
+		return nil, err
+	}
+	channel, _ := response["channel"].(map[string]any)
+	m.channels[channelID] = channel
+
+	return channel, nil
+}
+
+// permalinkTS converts the timestamp of a permalink back to a message timestamp
+// Example: "1765613227980829" -> "1765613227.980829"
+func permalinkTS(ts string) string {
+	return ts[:len(ts)-6] + "." + ts[len(ts)-6:]
+}
@@ -0,0 +1,389 @@
+package match
+
+import (
+	"errors"
+	"slack-connector/internal/core"
+	mock_match "slack-connector/mock/match"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func ptrString(s string) *string { return &s }
+
+var (
+	sourceContext = &core.Context{
+		Id:           "slack:source",
+		Name:         "slack:source",
+		ParentId:     "",
+		ConnectorId:  "slack",
+		ResourceType: "source",
+		Title:        ptrString("Slack"),
+		Description:  ptrString("Activity source from Slack"),
+		Url:          ptrString("https://slack.com"),
+		Metadata:     map[string]any{"enrichment_params": map[string]any{}},
+	}
+	channelContext = &core.Context{
+		Id:           "slack:channel:C099VUEKVBN",
+		Name:         "channel #C099VUEKVBN",
+		ParentId:     "slack:source",
+		ConnectorId:  "slack",
+		ResourceType: "channel",
+		Title:        ptrString("#C099VUEKVBN"),
+		Metadata:     map[string]any{"enrichment_params": map[string]any{"channel_id": "C099VUEKVBN"}},
+	}
+)
+
+// authResponse is the auth.test response of a token of the example.slack.com workspace
+var authResponse = map[string]any{
+	"ok":      true,
+	"url":     "https://example.slack.com/",
+	"team":    "Example",
+	"team_id": "T01234567",
+}
+
+func threadContext(threadTS string) *core.Context {
+	return &core.Context{
+		Id:           "slack:thread:C099VUEKVBN:" + threadTS,
+		Name:         "Thread " + threadTS,
+		ParentId:     "slack:channel:C099VUEKVBN",
+		ConnectorId:  "slack",
+		ResourceType: "thread",
+		Title:        ptrString("Thread " + threadTS),
+		Metadata:     map[string]any{"enrichment_params": map[string]any{"channel_id": "C099VUEKVBN", "thread_ts": threadTS}},
+	}
+}
+
+func TestMatchURL(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []*core.Context
+	}{
+		{
+			name: "message permalink",
+			text: "https://example.slack.com/archives/C099VUEKVBN/p1765613227980829",
+			want: []*core.Context{sourceContext, channelContext, threadContext("1765613227.980829")},
+		},
+		{
+			name: "reply permalink",
+			text: "https://example.slack.com/archives/C099VUEKVBN/p1765613227980829?thread_ts=1765613134.990399&cid=C099VUEKVBN",
+			want: []*core.Context{sourceContext, channelContext, threadContext("1765613134.990399")},
+		},
+		{
+			name: "reply permalink in mrkdwn",
+			text: "see <https://example.slack.com/archives/C099VUEKVBN/p1765613227980829?thread_ts=1765613134.990399&amp;cid=C099VUEKVBN|this thread>",
+			want: []*core.Context{sourceContext, channelContext, threadContext("1765613134.990399")},
+		},
+		{
+			name: "channel link",
+			text: "Discussed in https://example.slack.com/archives/C099VUEKVBN.",
+			want: []*core.Context{sourceContext, channelContext},
+		},
+		{
+			name: "app link without a token to confirm the workspace",
+			text: "slack://channel?team=T01234567&id=C099VUEKVBN",
+			want: []*core.Context{},
+		},
+		{
+			name: "workspace URL with different case",
+			text: "https://Example.slack.com/archives/C099VUEKVBN/",
+			want: []*core.Context{sourceContext, channelContext},
+		},
+		{
+			name: "multiple links share contexts",
+			text: "https://example.slack.com/archives/C099VUEKVBN and https://example.slack.com/archives/C099VUEKVBN/p1765613227980829",
+			want: []*core.Context{sourceContext, channelContext, threadContext("1765613227.980829")},
+		},
+		{
+			name: "other workspace",
+			text: "https://other.slack.com/archives/C099VUEKVBN/p1765613227980829",
+			want: []*core.Context{},
+		},
+		{
+			name: "unsupported path",
+			text: "https://example.slack.com/team/U12345678",
+			want: []*core.Context{},
+		},
+		{
+			name: "unsupported app link",
+			text: "slack://user?team=T01234567&id=U12345678",
+			want: []*core.Context{},
+		},
+		{
+			name: "no URL",
+			text: "nothing to see here",
+			want: []*core.Context{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := NewMatcher(nil, map[string]any{"workspace_url": "example.slack.com"}, core.NewNoopLogger())
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.want, matcher.MatchURL(tt.text))
+		})
+	}
+}
+
+func TestMatchURL_AppLinks(t *testing.T) {
+	cfg := map[string]any{
+		"workspace_url":    "example.slack.com",
+		"user_oauth_token": "token",
+	}
+
+	tests := []struct {
+		name        string
+		getMockHTTP func(*gomock.Controller) HTTPClient
+		text        string
+		want        []*core.Context
+	}{
+		{
+			name: "workspace is looked up once",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_match.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchAuth("token").Return(authResponse, nil).Times(1)
+				return mockHTTP
+			},
+			text: "slack://channel?team=T01234567&id=C099VUEKVBN slack://channel?team=T01234567&id=C0123ABCDEF",
+			want: []*core.Context{
+				sourceContext,
+				channelContext,
+				core.NewContextGenerator().CreateChannelContext("C0123ABCDEF", "C0123ABCDEF"),
+			},
+		},
+		{
+			name: "app link to another workspace",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_match.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchAuth("token").Return(authResponse, nil).Times(1)
+				return mockHTTP
+			},
+			text: "slack://channel?team=T76543210&id=C099VUEKVBN",
+			want: []*core.Context{},
+		},
+		{
+			name: "app link without a team",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				return mock_match.NewMockHTTPClient(ctrl)
+			},
+			text: "slack://channel?id=C099VUEKVBN",
+			want: []*core.Context{},
+		},
+		{
+			name: "workspace lookup failure",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_match.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchAuth("token").Return(nil, errors.New("Slack API error: invalid_auth")).Times(1)
+				return mockHTTP
+			},
+			text: "slack://channel?team=T01234567&id=C099VUEKVBN slack://channel?team=T01234567&id=C0123ABCDEF",
+			want: []*core.Context{},
+		},
+		{
+			name: "token of another workspace",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_match.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchAuth("token").Return(map[string]any{
+					"ok":      true,
+					"url":     "https://other.slack.com/",
+					"team_id": "T01234567",
+				}, nil).Times(1)
+				return mockHTTP
+			},
+			text: "slack://channel?team=T01234567&id=C099VUEKVBN",
+			want: []*core.Context{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			matcher, err := NewMatcher(tt.getMockHTTP(ctrl), cfg, core.NewNoopLogger())
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.want, matcher.MatchURL(tt.text))
+		})
+	}
+}
+
+func TestMatchURL_ResolveChannelNames(t *testing.T) {
+	cfg := map[string]any{
+		"workspace_url":         "example.slack.com",
+		"user_oauth_token":      "token",
+		"resolve_channel_names": true,
+	}
+	namedChannelContext := &core.Context{
+		Id:           "slack:channel:C099VUEKVBN",
+		Name:         "channel #general",
+		ParentId:     "slack:source",
+		ConnectorId:  "slack",
+		ResourceType: "channel",
+		Title:        ptrString("#general"),
+		Metadata:     map[string]any{"enrichment_params": map[string]any{"channel_id": "C099VUEKVBN"}},
+	}
+
+	tests := []struct {
+		name        string
+		getMockHTTP func(*gomock.Controller) HTTPClient
+		text        string
+		want        []*core.Context
+	}{
+		{
+			name: "channel name is looked up once",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_match.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchAuth("token").Return(authResponse, nil).Times(1)
+				mockHTTP.EXPECT().FetchChannel("token", "C099VUEKVBN").Return(map[string]any{
+					"ok":      true,
+					"channel": map[string]any{"id": "C099VUEKVBN", "name": "general"},
+				}, nil).Times(1)
+				return mockHTTP
+			},
+			text: "https://example.slack.com/archives/C099VUEKVBN/p1765613227980829 slack://channel?team=T01234567&id=C099VUEKVBN",
+			want: []*core.Context{sourceContext, namedChannelContext, threadContext("1765613227.980829")},
+		},
+		{
+			name: "lookup failure falls back to the channel ID",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_match.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchChannel("token", "C099VUEKVBN").Return(nil, errors.New("Slack API error: channel_not_found")).Times(1)
+				return mockHTTP
+			},
+			text: "https://example.slack.com/archives/C099VUEKVBN",
+			want: []*core.Context{sourceContext, channelContext},
+		},
+		{
+			name: "app link to a channel the token cannot see",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_match.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchAuth("token").Return(authResponse, nil).Times(1)
+				mockHTTP.EXPECT().FetchChannel("token", "C099VUEKVBN").Return(nil, errors.New("Slack API error: channel_not_found")).Times(1)
+				return mockHTTP
+			},
+			text: "slack://channel?team=T01234567&id=C099VUEKVBN",
+			want: []*core.Context{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			matcher, err := NewMatcher(tt.getMockHTTP(ctrl), cfg, core.NewNoopLogger())
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.want, matcher.MatchURL(tt.text))
+		})
+	}
+}
+
+func TestMatchURL_Workspaces(t *testing.T) {
+	cfg := map[string]any{
+		"workspace_url":    "acme.enterprise.slack.com",
+		"user_oauth_token": "token",
+		"team_ids":         []any{"T0123ABC", "T0456DEF"},
+	}
+	engineering := core.NewTeamContextGenerator(core.Team{ID: "T0123ABC", Name: "Acme Engineering", Domain: "acme-eng.slack.com"})
+	sales := core.NewTeamContextGenerator(core.Team{ID: "T0456DEF", Domain: "acme.enterprise.slack.com"})
+
+	tests := []struct {
+		name        string
+		getMockHTTP func(*gomock.Controller) HTTPClient
+		text        string
+		want        []*core.Context
+	}{
+		{
+			name: "permalink on the domain of a workspace",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_match.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchTeam("token", "T0123ABC").Return(map[string]any{
+					"ok":   true,
+					"team": map[string]any{"id": "T0123ABC", "name": "Acme Engineering", "domain": "acme-eng"},
+				}, nil).Times(1)
+				return mockHTTP
+			},
+			text: "https://acme-eng.slack.com/archives/C099VUEKVBN/p1765613227980829",
+			want: []*core.Context{
+				engineering.CreateSourceContext(),
+				engineering.CreateChannelContext("C099VUEKVBN", "C099VUEKVBN"),
+				engineering.CreateThreadContext("C099VUEKVBN", "1765613227.980829"),
+			},
+		},
+		{
+			name: "permalink on the org domain",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_match.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchChannel("token", "C099VUEKVBN").Return(map[string]any{
+					"ok":      true,
+					"channel": map[string]any{"id": "C099VUEKVBN", "name": "deals", "context_team_id": "T0456DEF"},
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchTeam("token", "T0456DEF").Return(nil, errors.New("Slack API error: missing_scope")).Times(1)
+				return mockHTTP
+			},
+			text: "https://acme.enterprise.slack.com/archives/C099VUEKVBN",
+			want: []*core.Context{
+				sales.CreateSourceContext(),
+				sales.CreateChannelContext("C099VUEKVBN", "C099VUEKVBN"),
+			},
+		},
+		{
+			name: "app link to a workspace",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_match.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchTeam("token", "T0123ABC").Return(map[string]any{
+					"ok":   true,
+					"team": map[string]any{"id": "T0123ABC", "name": "Acme Engineering", "domain": "acme-eng"},
+				}, nil).Times(1)
+				return mockHTTP
+			},
+			text: "slack://channel?team=T0123ABC&id=C099VUEKVBN",
+			want: []*core.Context{
+				engineering.CreateSourceContext(),
+				engineering.CreateChannelContext("C099VUEKVBN", "C099VUEKVBN"),
+			},
+		},
+		{
+			name: "app link to an unlisted workspace",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				return mock_match.NewMockHTTPClient(ctrl)
+			},
+			text: "slack://channel?team=T76543210&id=C099VUEKVBN",
+			want: []*core.Context{},
+		},
+		{
+			name: "permalink on the domain of another workspace",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_match.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchTeam("token", "T0123ABC").Return(map[string]any{
+					"ok":   true,
+					"team": map[string]any{"id": "T0123ABC", "name": "Acme Engineering", "domain": "acme-eng"},
+				}, nil).Times(1)
+				mockHTTP.EXPECT().FetchTeam("token", "T0456DEF").Return(map[string]any{
+					"ok":   true,
+					"team": map[string]any{"id": "T0456DEF", "name": "Acme Sales", "domain": "acme-sales"},
+				}, nil).Times(1)
+				return mockHTTP
+			},
+			text: "https://other.slack.com/archives/C099VUEKVBN",
+			want: []*core.Context{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			matcher, err := NewMatcher(tt.getMockHTTP(ctrl), cfg, core.NewNoopLogger())
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.want, matcher.MatchURL(tt.text))
+		})
+	}
+}
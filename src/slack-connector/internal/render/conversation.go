@@ -0,0 +1,161 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"slack-connector/internal/core"
+	"strings"
+)
+
+// Conversation kinds
+const (
+	// KindChannel is a public or private channel
+	KindChannel = "channel"
+	// KindIM is a direct message with one other user
+	KindIM = "im"
+	// KindMPIM is a group direct message
+	KindMPIM = "mpim"
+)
+
+// userIDPattern matches a user ID, which search.messages reports as the name of a DM
+var userIDPattern = regexp.MustCompile(`^[UW][A-Z0-9]+$`)
+
+// mpimNamePattern matches the generated name of a group DM
+// Example: "mpdm-alice--bob--carol-1"
+var mpimNamePattern = regexp.MustCompile(`^mpdm-(.+)-\d+$`)
+
+// Conversation is a channel, DM or group DM with a human-readable label
+type Conversation struct {
+	ID   string
+	Kind string
+	// Name is the channel name, or the participant names of a DM or group DM joined by ", "
+	Name string
+	// Participants are the names of the other users of a DM or group DM
+	Participants []string
+	// Private is true for private channels, DMs and group DMs
+	Private bool
+	// Shared is true for channels shared with other organizations (Slack Connect)
+	Shared bool
+}
+
+// Label returns the title of the conversation
+// Example: "#general", "DM with Alice", "Group DM: Alice, Bob"
+func (c Conversation) Label() string {
+	switch c.Kind {
+	case KindIM:
+		return "DM with " + c.Name
+	case KindMPIM:
+		return "Group DM: " + c.Name
+	default:
+		return "#" + c.Name
+	}
+}
+
+// IsDirectMessage reports whether the conversation is a DM or group DM
+func (c Conversation) IsDirectMessage() bool {
+	return c.Kind == KindIM || c.Kind == KindMPIM
+}
+
+// ConversationKind returns the kind of a conversation object from conversations.info,
+// users.conversations or a search.messages match
+func ConversationKind(channel map[string]any) string {
+	switch {
+	case channel["is_im"] == true:
+		return KindIM
+	case channel["is_mpim"] == true:
+		return KindMPIM
+	case mpimNamePattern.MatchString(core.GetStringValue(channel, "name")):
+		return KindMPIM
+	default:
+		return KindChannel
+	}
+}
+
+// NewConversation reads the ID, kind, name and visibility of a conversation object without
+// resolving participant names
+func NewConversation(channel map[string]any) Conversation {
+	kind := ConversationKind(channel)
+	return Conversation{
+		ID:      core.GetStringValue(channel, "id"),
+		Kind:    kind,
+		Name:    core.GetStringValue(channel, "name"),
+		Private: kind != KindChannel || channel["is_private"] == true || channel["is_group"] == true,
+		Shared:  channel["is_shared"] == true || channel["is_ext_shared"] == true || channel["is_pending_ext_shared"] == true,
+	}
+}
+
+// Conversation names a conversation object. The participants of DMs and group DMs other than
+// selfID are resolved to user names; conversations are cached by ID for the lifetime of the
+// renderer.
+func (r *Renderer) Conversation(channel map[string]any, selfID string) Conversation {
+	id := core.GetStringValue(channel, "id")
+	if conversation, ok := r.conversations[id]; ok {
+		return conversation
+	}
+
+	conversation := NewConversation(channel)
+	switch conversation.Kind {
+	case KindIM:
+		// conversations.info reports the other user, search.messages reports it as the name
+		userID := core.GetStringValue(channel, "user")
+		if userID == "" && userIDPattern.MatchString(conversation.Name) {
+			userID = conversation.Name
+		}
+		if userID != "" {
+			conversation.Participants = []string{r.UserName(userID)}
+		}
+	case KindMPIM:
+		conversation.Participants = r.groupParticipants(id, conversation.Name, selfID)
+	}
+	if len(conversation.Participants) > 0 {
+		conversation.Name = strings.Join(conversation.Participants, ", ")
+	}
+	if conversation.Name == "" {
+		conversation.Name = id
+	}
+	r.conversations[id] = conversation
+
+	return conversation
+}
+
+// ConversationByID looks up and names a conversation by its ID. A failed lookup is named after
+// the ID.
+func (r *Renderer) ConversationByID(channelID, selfID string) Conversation {
+	if conversation, ok := r.conversations[channelID]; ok {
+		return conversation
+	}
+
+	channel := map[string]any{"id": channelID}
+	response, err := r.httpClient.FetchChannel(r.token, channelID)
+	if err != nil {
+		r.logger.Warn(fmt.Sprintf("Failed to look up channel %s: %s", channelID, err.Error()))
+	} else if found, ok := response["channel"].(map[string]any); ok {
+		channel = found
+	}
+
+	return r.Conversation(channel, selfID)
+}
+
+// groupParticipants returns the names of the members of a group DM other than selfID. When the
+// members cannot be listed, the handles in the generated name are used instead.
+func (r *Renderer) groupParticipants(channelID, name, selfID string) []string {
+	response, err := r.httpClient.FetchMembers(r.token, channelID)
+	if err != nil {
+		r.logger.Warn(fmt.Sprintf("Failed to list members of %s: %s", channelID, err.Error()))
+		if matches := mpimNamePattern.FindStringSubmatch(name); matches != nil {
+			return strings.Split(matches[1], "--")
+		}
+		return nil
+	}
+
+	participants := []string{}
+	members, _ := response["members"].([]any)
+	for _, member := range members {
+		userID, ok := member.(string)
+		if !ok || userID == selfID {
+			continue
+		}
+		participants = append(participants, r.UserName(userID))
+	}
+	return participants
+}
@@ -0,0 +1,82 @@
+package render
+
+import (
+	"errors"
+	"slack-connector/internal/core"
+	mock_render "slack-connector/mock/render"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRenderer_Conversation(t *testing.T) {
+	tests := []struct {
+		name        string
+		getMockHTTP func(*gomock.Controller) HTTPClient
+		channel     map[string]any
+		wantLabel   string
+		wantKind    string
+	}{
+		{
+			name: "channel",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				return mock_render.NewMockHTTPClient(ctrl)
+			},
+			channel:   map[string]any{"id": "C099VUEKVBN", "name": "general", "is_channel": true},
+			wantLabel: "#general",
+			wantKind:  KindChannel,
+		},
+		{
+			name: "DM reported by search.messages",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_render.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchUser("token", "U0999ZZZ").Return(map[string]any{
+					"ok":   true,
+					"user": map[string]any{"id": "U0999ZZZ", "name": "carol", "real_name": "Carol Jones"},
+				}, nil).Times(1)
+				return mockHTTP
+			},
+			channel:   map[string]any{"id": "D0123ABCDEF", "name": "U0999ZZZ", "is_im": true},
+			wantLabel: "DM with Carol Jones",
+			wantKind:  KindIM,
+		},
+		{
+			name: "DM reported by conversations.info",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_render.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchUser("token", "U0999ZZZ").Return(nil, errors.New("user_not_found")).Times(1)
+				return mockHTTP
+			},
+			channel:   map[string]any{"id": "D0123ABCDEF", "is_im": true, "user": "U0999ZZZ"},
+			wantLabel: "DM with U0999ZZZ",
+			wantKind:  KindIM,
+		},
+		{
+			name: "group DM without member list falls back to the generated name",
+			getMockHTTP: func(ctrl *gomock.Controller) HTTPClient {
+				mockHTTP := mock_render.NewMockHTTPClient(ctrl)
+				mockHTTP.EXPECT().FetchMembers("token", "G0123GROUPDM").Return(nil, errors.New("missing_scope")).Times(1)
+				return mockHTTP
+			},
+			channel:   map[string]any{"id": "G0123GROUPDM", "name": "mpdm-alice--bob-1"},
+			wantLabel: "Group DM: alice, bob",
+			wantKind:  KindMPIM,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			r := NewRenderer(tt.getMockHTTP(ctrl), "token", core.NewNoopLogger())
+			got := r.Conversation(tt.channel, "U12345678")
+			assert.Equal(t, tt.wantLabel, got.Label())
+			assert.Equal(t, tt.wantKind, got.Kind)
+
+			// Conversations are cached by ID
+			assert.Equal(t, got, r.Conversation(tt.channel, "U12345678"))
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package render
+
+// emojiShortcodes maps the shortcodes of commonly used standard emoji to the emoji. Rich text
+// blocks carry the code points of every standard emoji, so this is only needed for mrkdwn text.
+var emojiShortcodes = map[string]string{
+	"+1":                       "👍",
+	"thumbsup":                 "👍",
+	"-1":                       "👎",
+	"thumbsdown":               "👎",
+	"ok_hand":                  "👌",
+	"clap":                     "👏",
+	"pray":                     "🙏",
+	"raised_hands":             "🙌",
+	"muscle":                   "💪",
+	"wave":                     "👋",
+	"point_up":                 "☝️",
+	"point_right":              "👉",
+	"eyes":                     "👀",
+	"smile":                    "😄",
+	"smiley":                   "😃",
+	"grinning":                 "😀",
+	"laughing":                 "😆",
+	"joy":                      "😂",
+	"sweat_smile":              "😅",
+	"slightly_smiling_face":    "🙂",
+	"wink":                     "😉",
+	"blush":                    "😊",
+	"innocent":                 "😇",
+	"heart_eyes":               "😍",
+	"thinking_face":            "🤔",
+	"neutral_face":             "😐",
+	"expressionless":           "😑",
+	"confused":                 "😕",
+	"disappointed":             "😞",
+	"worried":                  "😟",
+	"cry":                      "😢",
+	"sob":                      "😭",
+	"scream":                   "😱",
+	"sweat":                    "😓",
+	"rage":                     "😡",
+	"sunglasses":               "😎",
+	"upside_down_face":         "🙃",
+	"face_with_rolling_eyes":   "🙄",
+	"exploding_head":           "🤯",
+	"partying_face":            "🥳",
+	"sleeping":                 "😴",
+	"heart":                    "❤️",
+	"broken_heart":             "💔",
+	"fire":                     "🔥",
+	"sparkles":                 "✨",
+	"star":                     "⭐",
+	"tada":                     "🎉",
+	"rocket":                   "🚀",
+	"100":                      "💯",
+	"white_check_mark":         "✅",
+	"heavy_check_mark":         "✔️",
+	"ballot_box_with_check":    "☑️",
+	"x":                        "❌",
+	"warning":                  "⚠️",
+	"no_entry":                 "⛔",
+	"question":                 "❓",
+	"exclamation":              "❗",
+	"bulb":                     "💡",
+	"memo":                     "📝",
+	"pencil":                   "📝",
+	"pushpin":                  "📌",
+	"link":                     "🔗",
+	"lock":                     "🔒",
+	"key":                      "🔑",
+	"bug":                      "🐛",
+	"wrench":                   "🔧",
+	"hammer":                   "🔨",
+	"gear":                     "⚙️",
+	"package":                  "📦",
+	"chart_with_upwards_trend": "📈",
+	"calendar":                 "📆",
+	"hourglass":                "⌛",
+	"alarm_clock":              "⏰",
+	"coffee":                   "☕",
+	"beer":                     "🍺",
+	"pizza":                    "🍕",
+	"sunny":                    "☀️",
+	"zap":                      "⚡",
+	"boom":                     "💥",
+	"speech_balloon":           "💬",
+	"loudspeaker":              "📢",
+	"mega":                     "📣",
+	"bell":                     "🔔",
+	"rotating_light":           "🚨",
+	"construction":             "🚧",
+	"arrow_right":              "➡️",
+	"arrow_left":               "⬅️",
+	"arrow_up":                 "⬆️",
+	"arrow_down":               "⬇️",
+	"large_green_circle":       "🟢",
+	"large_yellow_circle":      "🟡",
+	"red_circle":               "🔴",
+	"white_circle":             "⚪",
+	"ok":                       "🆗",
+	"new":                      "🆕",
+	"sos":                      "🆘",
+}
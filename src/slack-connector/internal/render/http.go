@@ -0,0 +1,9 @@
+package render
+
+type HTTPClient interface {
+	FetchUser(token, userID string) (map[string]any, error)
+	FetchChannel(token, channelID string) (map[string]any, error)
+	FetchUserGroups(token string) (map[string]any, error)
+	// FetchMembers lists the members of a conversation
+	FetchMembers(token, channelID string) (map[string]any, error)
+}
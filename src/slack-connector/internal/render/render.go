@@ -0,0 +1,373 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"slack-connector/internal/core"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	reEntity    = regexp.MustCompile(`<([^<>\s][^<>]*)>`)
+	reShortcode = regexp.MustCompile(`:([a-z0-9_+'-]+):`)
+	reSpace     = regexp.MustCompile(`\s+`)
+)
+
+// Text is a message rendered for reading
+type Text struct {
+	// Plain is the text with mentions and links resolved and formatting removed
+	Plain string
+	// Markdown is the text with mentions resolved and formatting and links converted to Markdown
+	Markdown string
+}
+
+// Renderer renders Slack message text. User, channel and user group names are looked up once
+// and cached for the lifetime of the renderer; a failed lookup falls back to the ID.
+type Renderer struct {
+	httpClient HTTPClient
+	token      string
+	logger     core.Logger
+
+	users         map[string]string
+	channels      map[string]string
+	userGroups    map[string]string
+	conversations map[string]Conversation
+}
+
+// NewRenderer creates a new Renderer instance
+func NewRenderer(httpClient HTTPClient, token string, logger core.Logger) *Renderer {
+	return &Renderer{
+		httpClient:    httpClient,
+		token:         token,
+		logger:        logger,
+		users:         map[string]string{},
+		channels:      map[string]string{},
+		conversations: map[string]Conversation{},
+	}
+}
+
+// RenderMessage renders a message from its rich_text blocks, falling back to the mrkdwn text
+// for messages without them (e.g., messages posted by apps)
+func (r *Renderer) RenderMessage(message map[string]any) Text {
+	blocks, _ := message["blocks"].([]any)
+	if text, ok := r.RenderBlocks(blocks); ok {
+		return text
+	}
+	return r.RenderMrkdwn(core.GetStringValue(message, "text"))
+}
+
+// RenderMrkdwn renders mrkdwn text. Mentions, links and emoji shortcodes are resolved; the
+// *bold*, _italic_ and ~strike~ markers are kept as they are.
+func (r *Renderer) RenderMrkdwn(text string) Text {
+	var plain, markdown strings.Builder
+	last := 0
+	for _, loc := range reEntity.FindAllStringSubmatchIndex(text, -1) {
+		plain.WriteString(r.renderShortcodes(unescape(text[last:loc[0]])))
+		markdown.WriteString(r.renderShortcodes(unescape(text[last:loc[0]])))
+		p, m := r.renderEntity(text[loc[2]:loc[3]])
+		plain.WriteString(p)
+		markdown.WriteString(m)
+		last = loc[1]
+	}
+	plain.WriteString(r.renderShortcodes(unescape(text[last:])))
+	markdown.WriteString(r.renderShortcodes(unescape(text[last:])))
+
+	return Text{Plain: strings.TrimSpace(plain.String()), Markdown: strings.TrimSpace(markdown.String())}
+}
+
+// renderEntity renders the inside of an angle-bracketed mrkdwn entity, e.g. "@U0123ABC",
+// "#C099VUEKVBN|general", "!subteam^S0123ABC|@team" or "https://example.com|label"
+func (r *Renderer) renderEntity(entity string) (plain, markdown string) {
+	target, label, _ := strings.Cut(entity, "|")
+	label = unescape(label)
+
+	var rendered string
+	switch {
+	case strings.HasPrefix(target, "@"):
+		if label == "" {
+			label = r.UserName(target[1:])
+		}
+		rendered = "@" + label
+	case strings.HasPrefix(target, "#"):
+		if label == "" {
+			label = r.channelName(target[1:])
+		}
+		rendered = "#" + label
+	case strings.HasPrefix(target, "!subteam^"):
+		if label == "" {
+			label = "@" + r.userGroupHandle(strings.TrimPrefix(target, "!subteam^"))
+		}
+		rendered = label
+	case strings.HasPrefix(target, "!date^"):
+		rendered = label
+	case strings.HasPrefix(target, "!"):
+		rendered = "@" + strings.TrimPrefix(target, "!")
+	default:
+		url := unescape(target)
+		return linkText(url, label)
+	}
+
+	return rendered, rendered
+}
+
+// RenderBlocks renders the rich_text blocks of a message. ok is false when there are none.
+func (r *Renderer) RenderBlocks(blocks []any) (text Text, ok bool) {
+	var plain, markdown []string
+	for _, b := range blocks {
+		block, _ := b.(map[string]any)
+		if core.GetStringValue(block, "type") != "rich_text" {
+			continue
+		}
+		ok = true
+		elements, _ := block["elements"].([]any)
+		for _, e := range elements {
+			element, _ := e.(map[string]any)
+			p, m := r.renderBlockElement(element)
+			plain = append(plain, strings.TrimRight(p, "\n"))
+			markdown = append(markdown, strings.TrimRight(m, "\n"))
+		}
+	}
+
+	return Text{
+		Plain:    strings.TrimSpace(strings.Join(plain, "\n")),
+		Markdown: strings.TrimSpace(strings.Join(markdown, "\n")),
+	}, ok
+}
+
+func (r *Renderer) renderBlockElement(element map[string]any) (plain, markdown string) {
+	elements, _ := element["elements"].([]any)
+	switch core.GetStringValue(element, "type") {
+	case "rich_text_section":
+		return r.renderInlineElements(elements)
+	case "rich_text_preformatted":
+		p, _ := r.renderInlineElements(elements)
+		return p, "```\n" + p + "\n```"
+	case "rich_text_quote":
+		p, m := r.renderInlineElements(elements)
+		return p, "> " + strings.ReplaceAll(m, "\n", "\n> ")
+	case "rich_text_list":
+		indent := strings.Repeat("  ", int(numberValue(element, "indent")))
+		ordered := core.GetStringValue(element, "style") == "ordered"
+		offset := int(numberValue(element, "offset"))
+		var plainItems, markdownItems []string
+		for i, item := range elements {
+			itemMap, _ := item.(map[string]any)
+			p, m := r.renderBlockElement(itemMap)
+			marker := "- "
+			if ordered {
+				marker = strconv.Itoa(offset+i+1) + ". "
+			}
+			plainItems = append(plainItems, indent+marker+p)
+			markdownItems = append(markdownItems, indent+marker+m)
+		}
+		return strings.Join(plainItems, "\n"), strings.Join(markdownItems, "\n")
+	default:
+		return "", ""
+	}
+}
+
+func (r *Renderer) renderInlineElements(elements []any) (plain, markdown string) {
+	var p, m strings.Builder
+	for _, e := range elements {
+		element, _ := e.(map[string]any)
+		switch core.GetStringValue(element, "type") {
+		case "text":
+			text := core.GetStringValue(element, "text")
+			p.WriteString(text)
+			m.WriteString(styled(text, element["style"]))
+		case "link":
+			lp, lm := linkText(core.GetStringValue(element, "url"), core.GetStringValue(element, "text"))
+			p.WriteString(lp)
+			m.WriteString(lm)
+		case "user":
+			name := "@" + r.UserName(core.GetStringValue(element, "user_id"))
+			p.WriteString(name)
+			m.WriteString(name)
+		case "channel":
+			name := "#" + r.channelName(core.GetStringValue(element, "channel_id"))
+			p.WriteString(name)
+			m.WriteString(name)
+		case "usergroup":
+			name := "@" + r.userGroupHandle(core.GetStringValue(element, "usergroup_id"))
+			p.WriteString(name)
+			m.WriteString(name)
+		case "broadcast":
+			name := "@" + core.GetStringValue(element, "range")
+			p.WriteString(name)
+			m.WriteString(name)
+		case "emoji":
+			emoji := emojiText(core.GetStringValue(element, "name"), core.GetStringValue(element, "unicode"))
+			p.WriteString(emoji)
+			m.WriteString(emoji)
+		case "date":
+			p.WriteString(core.GetStringValue(element, "fallback"))
+			m.WriteString(core.GetStringValue(element, "fallback"))
+		case "color":
+			p.WriteString(core.GetStringValue(element, "value"))
+			m.WriteString(core.GetStringValue(element, "value"))
+		}
+	}
+	return p.String(), m.String()
+}
+
+// styled wraps text in the Markdown markers of its rich text style
+func styled(text string, style any) string {
+	styleMap, _ := style.(map[string]any)
+	if len(styleMap) == 0 || strings.TrimSpace(text) == "" {
+		return text
+	}
+	if code, _ := styleMap["code"].(bool); code {
+		return "`" + text + "`"
+	}
+	for _, s := range []struct{ key, marker string }{
+		{"strike", "~~"},
+		{"italic", "*"},
+		{"bold", "**"},
+	} {
+		if on, _ := styleMap[s.key].(bool); on {
+			text = s.marker + text + s.marker
+		}
+	}
+	return text
+}
+
+// linkText renders a link as its label, or the URL when it has none
+func linkText(url, label string) (plain, markdown string) {
+	url = strings.TrimPrefix(url, "mailto:")
+	if label == "" || label == url {
+		return url, url
+	}
+	return label, fmt.Sprintf("[%s](%s)", label, url)
+}
+
+// renderShortcodes replaces known emoji shortcodes with the emoji. Custom emoji keep their
+// shortcode.
+func (r *Renderer) renderShortcodes(text string) string {
+	return reShortcode.ReplaceAllStringFunc(text, func(shortcode string) string {
+		return emojiText(strings.Trim(shortcode, ":"), "")
+	})
+}
+
+// emojiText returns the emoji of a rich text emoji element, given as hyphen-separated code
+// points, or of a shortcode
+func emojiText(name, codePoints string) string {
+	if codePoints != "" {
+		var b strings.Builder
+		for _, cp := range strings.Split(codePoints, "-") {
+			r, err := strconv.ParseUint(cp, 16, 32)
+			if err != nil {
+				b.Reset()
+				break
+			}
+			b.WriteRune(rune(r))
+		}
+		if b.Len() > 0 {
+			return b.String()
+		}
+	}
+	// Skin tone modifiers are appended as "::skin-tone-2"
+	base, _, _ := strings.Cut(name, "::")
+	if emoji, ok := emojiShortcodes[base]; ok {
+		return emoji
+	}
+	return ":" + name + ":"
+}
+
+// UserName returns the display name of a user
+func (r *Renderer) UserName(userID string) string {
+	if name, ok := r.users[userID]; ok {
+		return name
+	}
+
+	name := userID
+	response, err := r.httpClient.FetchUser(r.token, userID)
+	if err != nil {
+		r.logger.Warn(fmt.Sprintf("Failed to look up user %s: %s", userID, err.Error()))
+	} else {
+		user, _ := response["user"].(map[string]any)
+		profile, _ := user["profile"].(map[string]any)
+		for _, candidate := range []string{
+			core.GetStringValue(profile, "display_name"),
+			core.GetStringValue(profile, "real_name"),
+			core.GetStringValue(user, "real_name"),
+			core.GetStringValue(user, "name"),
+		} {
+			if candidate != "" {
+				name = candidate
+				break
+			}
+		}
+	}
+	r.users[userID] = name
+
+	return name
+}
+
+func (r *Renderer) channelName(channelID string) string {
+	if name, ok := r.channels[channelID]; ok {
+		return name
+	}
+
+	name := channelID
+	response, err := r.httpClient.FetchChannel(r.token, channelID)
+	if err != nil {
+		r.logger.Warn(fmt.Sprintf("Failed to look up channel %s: %s", channelID, err.Error()))
+	} else if channelObj, ok := response["channel"].(map[string]any); ok && core.GetStringValue(channelObj, "name") != "" {
+		name = core.GetStringValue(channelObj, "name")
+	}
+	r.channels[channelID] = name
+
+	return name
+}
+
+// userGroupHandle returns the handle of a user group. All user groups are listed with the first
+// lookup.
+func (r *Renderer) userGroupHandle(userGroupID string) string {
+	if r.userGroups == nil {
+		r.userGroups = map[string]string{}
+		response, err := r.httpClient.FetchUserGroups(r.token)
+		if err != nil {
+			r.logger.Warn(fmt.Sprintf("Failed to list user groups: %s", err.Error()))
+		}
+		groups, _ := response["usergroups"].([]any)
+		for _, g := range groups {
+			group, _ := g.(map[string]any)
+			if id, handle := core.GetStringValue(group, "id"), core.GetStringValue(group, "handle"); id != "" && handle != "" {
+				r.userGroups[id] = handle
+			}
+		}
+	}
+
+	if handle, ok := r.userGroups[userGroupID]; ok {
+		return handle
+	}
+	return userGroupID
+}
+
+// Truncate collapses whitespace and shortens text to at most maxLength characters, cutting at a
+// word boundary when there is one in the second half and appending an ellipsis
+func Truncate(text string, maxLength int) string {
+	text = strings.TrimSpace(reSpace.ReplaceAllString(text, " "))
+	if utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+
+	runes := []rune(text)
+	cut := string(runes[:maxLength-1])
+	if i := strings.LastIndex(cut, " "); i > 0 && utf8.RuneCountInString(cut[:i]) >= maxLength/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " .,;:") + "…"
+}
+
+// unescape reverses the escaping of "&", "<" and ">" in message text
+func unescape(text string) string {
+	return strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&").Replace(text)
+}
+
+func numberValue(m map[string]any, key string) float64 {
+	value, _ := m[key].(float64)
+	return value
+}
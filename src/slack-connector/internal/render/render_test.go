@@ -0,0 +1,204 @@
+package render
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"slack-connector/internal/core"
+	mock_render "slack-connector/mock/render"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func loadJSONTestData(t *testing.T, path string) map[string]any {
+	t.Helper()
+
+	b, err := os.ReadFile(path) // nolint:gosec
+	if err != nil {
+		t.Fatalf("Failed to read test data file: %v", err)
+	}
+
+	var data map[string]any
+	err = json.Unmarshal(b, &data)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal test data: %v", err)
+	}
+
+	return data
+}
+
+func expectLookups(mockHTTP *mock_render.MockHTTPClient) {
+	mockHTTP.EXPECT().FetchUser("token", "U0123ABC").Return(map[string]any{
+		"ok": true,
+		"user": map[string]any{
+			"id":        "U0123ABC",
+			"name":      "alice",
+			"real_name": "Alice Smith",
+			"profile":   map[string]any{"display_name": "alice.s", "real_name": "Alice Smith"},
+		},
+	}, nil).Times(1)
+	mockHTTP.EXPECT().FetchChannel("token", "C099VUEKVBN").Return(map[string]any{
+		"ok":      true,
+		"channel": map[string]any{"id": "C099VUEKVBN", "name": "general"},
+	}, nil).AnyTimes()
+	mockHTTP.EXPECT().FetchUserGroups("token").Return(map[string]any{
+		"ok": true,
+		"usergroups": []any{
+			map[string]any{"id": "S0456DEF", "handle": "reviewers", "name": "Reviewers"},
+		},
+	}, nil).AnyTimes()
+}
+
+func TestRenderMessage(t *testing.T) {
+	message := loadJSONTestData(t, "../../testdata/events/rich_text.json")
+
+	tests := []struct {
+		name    string
+		message map[string]any
+		want    Text
+	}{
+		{
+			name:    "rich text blocks",
+			message: message,
+			want: Text{
+				Plain: "@alice.s see #general and this 👍\nPlease review, @reviewers before Friday:\n" +
+					"1. run make test\n2. update the changelog\nShip it\ngo test ./...",
+				Markdown: "@alice.s see #general and [this](https://example.com/spec) 👍\nPlease review, @reviewers **before Friday**:\n" +
+					"1. run `make test`\n2. update the changelog\n> *Ship it*\n```\ngo test ./...\n```",
+			},
+		},
+		{
+			name:    "mrkdwn text without blocks",
+			message: map[string]any{"text": message["text"]},
+			want: Text{
+				Plain: "@alice.s see #general and this 👍\nPlease review, @reviewers *before Friday*:\n" +
+					"1. run `make test`\n2. update the changelog\n> _Ship it_\n```go test ./...```",
+				Markdown: "@alice.s see #general and [this](https://example.com/spec) 👍\nPlease review, @reviewers *before Friday*:\n" +
+					"1. run `make test`\n2. update the changelog\n> _Ship it_\n```go test ./...```",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockHTTP := mock_render.NewMockHTTPClient(ctrl)
+			expectLookups(mockHTTP)
+
+			renderer := NewRenderer(mockHTTP, "token", core.NewNoopLogger())
+			assert.Equal(t, tt.want, renderer.RenderMessage(tt.message))
+		})
+	}
+}
+
+func TestRenderMrkdwn(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want Text
+	}{
+		{
+			name: "URL without label",
+			text: "<https://example.com/a?b=1&amp;c=2>",
+			want: Text{Plain: "https://example.com/a?b=1&c=2", Markdown: "https://example.com/a?b=1&c=2"},
+		},
+		{
+			name: "mail link",
+			text: "<mailto:alice@example.com|alice@example.com>",
+			want: Text{Plain: "alice@example.com", Markdown: "alice@example.com"},
+		},
+		{
+			name: "user mention with label",
+			text: "<@U0999ZZZ|bob> hi",
+			want: Text{Plain: "@bob hi", Markdown: "@bob hi"},
+		},
+		{
+			name: "broadcast and date",
+			text: "<!here> meeting <!date^1765614000^{date_short}|Dec 13>",
+			want: Text{Plain: "@here meeting Dec 13", Markdown: "@here meeting Dec 13"},
+		},
+		{
+			name: "emoji shortcodes",
+			text: ":tada: released :partyparrot: at 10:30:45",
+			want: Text{Plain: "🎉 released :partyparrot: at 10:30:45", Markdown: "🎉 released :partyparrot: at 10:30:45"},
+		},
+		{
+			name: "escaped comparison",
+			text: "a &lt; b &amp;&amp; b &gt; c",
+			want: Text{Plain: "a < b && b > c", Markdown: "a < b && b > c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			renderer := NewRenderer(mock_render.NewMockHTTPClient(ctrl), "token", core.NewNoopLogger())
+			assert.Equal(t, tt.want, renderer.RenderMrkdwn(tt.text))
+		})
+	}
+}
+
+func TestRenderer_Lookups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockHTTP := mock_render.NewMockHTTPClient(ctrl)
+	mockHTTP.EXPECT().FetchUser("token", "U0123ABC").Return(map[string]any{
+		"ok":   true,
+		"user": map[string]any{"id": "U0123ABC", "name": "alice", "profile": map[string]any{"display_name": ""}},
+	}, nil).Times(1)
+	mockHTTP.EXPECT().FetchChannel("token", "C0PRIVATE").Return(nil, errors.New("Slack API error: channel_not_found")).Times(1)
+	mockHTTP.EXPECT().FetchUserGroups("token").Return(nil, errors.New("Slack API error: missing_scope")).Times(1)
+
+	renderer := NewRenderer(mockHTTP, "token", core.NewNoopLogger())
+	for i := 0; i < 2; i++ {
+		got := renderer.RenderMrkdwn("<@U0123ABC> <#C0PRIVATE> <!subteam^S0456DEF>")
+		assert.Equal(t, Text{Plain: "@alice #C0PRIVATE @S0456DEF", Markdown: "@alice #C0PRIVATE @S0456DEF"}, got)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		maxLength int
+		want      string
+	}{
+		{
+			name:      "short text",
+			text:      "Deploy finished",
+			maxLength: 20,
+			want:      "Deploy finished",
+		},
+		{
+			name:      "collapses whitespace",
+			text:      "Deploy\n\n  finished ",
+			maxLength: 20,
+			want:      "Deploy finished",
+		},
+		{
+			name:      "cuts at a word boundary",
+			text:      "The deployment of the new upload queue finished without errors",
+			maxLength: 30,
+			want:      "The deployment of the new…",
+		},
+		{
+			name:      "cuts text without spaces",
+			text:      "後からぶら下げるメッセージです",
+			maxLength: 8,
+			want:      "後からぶら下げ…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Truncate(tt.text, tt.maxLength))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package slackapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UserScopes are the user token scopes requested by the OAuth web flow. They cover every API the
+// connector calls: history and replies of all conversation types, search, users and user groups,
+// plus team info, reactions, files and pins, which only team_ids, include_reactions,
+// include_file_shares and include_pins use, so those options work without reconnecting.
+var UserScopes = []string{
+	"channels:history",
+	"channels:read",
+	"groups:history",
+	"groups:read",
+	"im:history",
+	"im:read",
+	"mpim:history",
+	"mpim:read",
+	"search:read",
+	"users:read",
+	"usergroups:read",
+	"team:read",
+	"reactions:read",
+	"files:read",
+	"pins:read",
+}
+
+// Tokens are the user tokens issued by oauth.v2.access
+type Tokens struct {
+	AccessToken string
+	// RefreshToken and ExpiresIn are only issued when token rotation is enabled for the app;
+	// otherwise they are "" and 0
+	RefreshToken string
+	ExpiresIn    int64
+}
+
+// UserTokens reads the user tokens from a decoded oauth.v2.access response. Exchanging an
+// authorization code reports them under "authed_user"; refreshing a user token reports them at
+// the top level with token_type "user".
+func UserTokens(body map[string]any) (*Tokens, error) {
+	source, _ := body["authed_user"].(map[string]any)
+	if token, _ := source["access_token"].(string); token == "" {
+		source = body
+		if tokenType, _ := body["token_type"].(string); tokenType != "user" {
+			return nil, fmt.Errorf("oauth.v2.access returned no user token")
+		}
+	}
+
+	accessToken, _ := source["access_token"].(string)
+	if strings.TrimSpace(accessToken) == "" {
+		return nil, fmt.Errorf("oauth.v2.access returned an empty access_token")
+	}
+	refreshToken, _ := source["refresh_token"].(string)
+	expiresIn, _ := source["expires_in"].(float64)
+
+	return &Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(expiresIn),
+	}, nil
+}
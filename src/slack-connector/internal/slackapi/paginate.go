@@ -0,0 +1,50 @@
+package slackapi
+
+import "fmt"
+
+// MaxCursorPages bounds the pages read from a single cursor-paginated list
+const MaxCursorPages = 100
+
+// PageFunc fetches the page of a list that starts at cursor, "" being the first page
+type PageFunc func(cursor string) (map[string]any, error)
+
+// ListResult is the items of a cursor-paginated list
+type ListResult struct {
+	Items []map[string]any
+	// Truncated is true when the list was cut off at the page limit
+	Truncated bool
+}
+
+// ListCursor reads the items under key from every page of a cursor-paginated method, following
+// response_metadata.next_cursor, up to MaxCursorPages pages
+func ListCursor(method, key string, page PageFunc) (*ListResult, error) {
+	return ListCursorPages(method, key, MaxCursorPages, page)
+}
+
+// ListCursorPages is ListCursor with a page limit of maxPages
+func ListCursorPages(method, key string, maxPages int, page PageFunc) (*ListResult, error) {
+	result := &ListResult{Items: []map[string]any{}}
+	cursor := ""
+	for i := 0; i < maxPages; i++ {
+		response, err := page(cursor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", method, err)
+		}
+
+		list, _ := response[key].([]any)
+		for _, item := range list {
+			if itemMap, ok := item.(map[string]any); ok {
+				result.Items = append(result.Items, itemMap)
+			}
+		}
+
+		metadata, _ := response["response_metadata"].(map[string]any)
+		cursor, _ = metadata["next_cursor"].(string)
+		if cursor == "" {
+			return result, nil
+		}
+	}
+
+	result.Truncated = true
+	return result, nil
+}
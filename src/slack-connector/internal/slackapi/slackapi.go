@@ -0,0 +1,138 @@
+// Package slackapi validates Slack Web API responses. Every method answers with HTTP 200 and an
+// "ok" field; failures are reported through an "error" code, which Decode converts to an *Error.
+package slackapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Errors that an *Error matches with errors.Is, grouping the Slack error codes that are handled
+// the same way
+var (
+	// ErrInvalidAuth is returned for invalid_auth, not_authed and account_inactive
+	ErrInvalidAuth = errors.New("authentication failed")
+	// ErrTokenRevoked is returned for token_revoked
+	ErrTokenRevoked = errors.New("token has been revoked")
+	// ErrTokenExpired is returned for token_expired, when a rotating token must be refreshed
+	ErrTokenExpired = errors.New("token has expired")
+	// ErrMissingScope is returned for missing_scope; Error.Needed names the missing scope
+	ErrMissingScope = errors.New("token lacks a required scope")
+	// ErrNotAllowedTokenType is returned for not_allowed_token_type
+	ErrNotAllowedTokenType = errors.New("method is not available to this token type")
+	// ErrRateLimited is returned for ratelimited and HTTP 429; Error.RetryAfter is the wait in seconds
+	ErrRateLimited = errors.New("rate limited")
+	// ErrChannelNotFound is returned for channel_not_found
+	ErrChannelNotFound = errors.New("channel not found")
+)
+
+var errorCodes = map[string]error{
+	"invalid_auth":           ErrInvalidAuth,
+	"not_authed":             ErrInvalidAuth,
+	"account_inactive":       ErrInvalidAuth,
+	"token_revoked":          ErrTokenRevoked,
+	"token_expired":          ErrTokenExpired,
+	"missing_scope":          ErrMissingScope,
+	"not_allowed_token_type": ErrNotAllowedTokenType,
+	"ratelimited":            ErrRateLimited,
+	"channel_not_found":      ErrChannelNotFound,
+}
+
+// Error is a failure reported by a Slack API method
+type Error struct {
+	Method string
+	Code   string
+	// Needed and Provided are the required and granted scopes of a missing_scope error
+	Needed, Provided string
+	// RetryAfter is the number of seconds to wait after a ratelimited error, or 0 if unknown
+	RetryAfter int
+}
+
+func (e *Error) Error() string {
+	message := fmt.Sprintf("Slack API error: %s: %s", e.Method, e.Code)
+	switch {
+	case e.Code == "missing_scope" && e.Needed != "":
+		message += fmt.Sprintf(" (needed: %s, provided: %s)", e.Needed, e.Provided)
+	case e.Code == "ratelimited" && e.RetryAfter > 0:
+		message += fmt.Sprintf(" (retry after %ds)", e.RetryAfter)
+	}
+	return message
+}
+
+// Is reports whether the error code belongs to target, one of the Err* errors of this package
+func (e *Error) Is(target error) bool {
+	return errorCodes[e.Code] == target
+}
+
+// Response is a decoded Slack API response
+type Response struct {
+	Body map[string]any
+	// Warnings are the non-fatal problems reported with the response, e.g. "superfluous_charset"
+	Warnings []string
+}
+
+// Decode validates the HTTP status and "ok" field of a Slack API response and decodes its body.
+// headers are only used for the Retry-After header of a rate-limited response.
+func Decode(method string, status int, headers map[string]string, body []byte) (*Response, error) {
+	if status == 429 {
+		return nil, &Error{Method: method, Code: "ratelimited", RetryAfter: retryAfter(headers)}
+	}
+	if status != 200 {
+		return nil, fmt.Errorf("Slack API error (status %d): %s", status, string(body))
+	}
+
+	var apiResp map[string]any
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse API response: %w", err)
+	}
+
+	if ok, _ := apiResp["ok"].(bool); !ok {
+		code, _ := apiResp["error"].(string)
+		if code == "" {
+			code = "unknown_error"
+		}
+		needed, _ := apiResp["needed"].(string)
+		provided, _ := apiResp["provided"].(string)
+		return nil, &Error{Method: method, Code: code, Needed: needed, Provided: provided, RetryAfter: retryAfter(headers)}
+	}
+
+	return &Response{Body: apiResp, Warnings: warnings(apiResp)}, nil
+}
+
+// warnings collects the "warning" field and response_metadata.warnings of a response
+func warnings(apiResp map[string]any) []string {
+	var result []string
+	if warning, ok := apiResp["warning"].(string); ok && warning != "" {
+		result = strings.Split(warning, ",")
+	}
+	metadata, _ := apiResp["response_metadata"].(map[string]any)
+	list, _ := metadata["warnings"].([]any)
+	for _, w := range list {
+		if warning, ok := w.(string); ok && !contains(result, warning) {
+			result = append(result, warning)
+		}
+	}
+	return result
+}
+
+func retryAfter(headers map[string]string) int {
+	for key, value := range headers {
+		if strings.EqualFold(key, "Retry-After") {
+			seconds, _ := strconv.Atoi(strings.TrimSpace(value))
+			return seconds
+		}
+	}
+	return 0
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package slackapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+		body    string
+		want    *Response
+		wantErr error
+	}{
+		{
+			name:   "ok response",
+			status: 200,
+			body:   `{"ok": true, "channel": {"id": "C099VUEKVBN"}}`,
+			want: &Response{
+				Body: map[string]any{"ok": true, "channel": map[string]any{"id": "C099VUEKVBN"}},
+			},
+		},
+		{
+			name:   "ok response with warnings",
+			status: 200,
+			body:   `{"ok": true, "warning": "superfluous_charset", "response_metadata": {"warnings": ["superfluous_charset", "method_deprecated"]}}`,
+			want: &Response{
+				Body: map[string]any{
+					"ok":                true,
+					"warning":           "superfluous_charset",
+					"response_metadata": map[string]any{"warnings": []any{"superfluous_charset", "method_deprecated"}},
+				},
+				Warnings: []string{"superfluous_charset", "method_deprecated"},
+			},
+		},
+		{
+			name:    "invalid auth",
+			status:  200,
+			body:    `{"ok": false, "error": "invalid_auth"}`,
+			wantErr: &Error{Method: "conversations.info", Code: "invalid_auth"},
+		},
+		{
+			name:    "missing scope",
+			status:  200,
+			body:    `{"ok": false, "error": "missing_scope", "needed": "search:read", "provided": "channels:history,users:read"}`,
+			wantErr: &Error{Method: "conversations.info", Code: "missing_scope", Needed: "search:read", Provided: "channels:history,users:read"},
+		},
+		{
+			name:    "rate limited",
+			status:  429,
+			headers: map[string]string{"retry-after": "30"},
+			body:    `{"ok": false, "error": "ratelimited"}`,
+			wantErr: &Error{Method: "conversations.info", Code: "ratelimited", RetryAfter: 30},
+		},
+		{
+			name:    "error without code",
+			status:  200,
+			body:    `{"ok": false}`,
+			wantErr: &Error{Method: "conversations.info", Code: "unknown_error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode("conversations.info", tt.status, tt.headers, []byte(tt.body))
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecode_HTTPError(t *testing.T) {
+	got, err := Decode("conversations.info", 500, nil, []byte("Internal Server Error"))
+	assert.EqualError(t, err, "Slack API error (status 500): Internal Server Error")
+	assert.Nil(t, got)
+
+	got, err = Decode("conversations.info", 200, nil, []byte("<html>"))
+	assert.Error(t, err)
+	assert.Nil(t, got)
+}
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     *Error
+		target  error
+		message string
+	}{
+		{
+			name:    "token revoked",
+			err:     &Error{Method: "users.info", Code: "token_revoked"},
+			target:  ErrTokenRevoked,
+			message: "Slack API error: users.info: token_revoked",
+		},
+		{
+			name:    "token expired",
+			err:     &Error{Method: "users.info", Code: "token_expired"},
+			target:  ErrTokenExpired,
+			message: "Slack API error: users.info: token_expired",
+		},
+		{
+			name:    "missing scope",
+			err:     &Error{Method: "search.messages", Code: "missing_scope", Needed: "search:read", Provided: "users:read"},
+			target:  ErrMissingScope,
+			message: "Slack API error: search.messages: missing_scope (needed: search:read, provided: users:read)",
+		},
+		{
+			name:    "rate limited",
+			err:     &Error{Method: "conversations.history", Code: "ratelimited", RetryAfter: 30},
+			target:  ErrRateLimited,
+			message: "Slack API error: conversations.history: ratelimited (retry after 30s)",
+		},
+		{
+			name:    "channel not found",
+			err:     &Error{Method: "conversations.info", Code: "channel_not_found"},
+			target:  ErrChannelNotFound,
+			message: "Slack API error: conversations.info: channel_not_found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := errors.Join(errors.New("failed to fetch"), tt.err)
+			assert.ErrorIs(t, wrapped, tt.target)
+			assert.NotErrorIs(t, wrapped, ErrInvalidAuth)
+			assert.EqualError(t, tt.err, tt.message)
+		})
+	}
+}
+
+func TestListCursor(t *testing.T) {
+	pages := map[string]map[string]any{
+		"": {
+			"channels":          []any{map[string]any{"id": "C1"}, map[string]any{"id": "C2"}},
+			"response_metadata": map[string]any{"next_cursor": "page2"},
+		},
+		"page2": {
+			"channels":          []any{map[string]any{"id": "C3"}},
+			"response_metadata": map[string]any{"next_cursor": ""},
+		},
+	}
+
+	got, err := ListCursor("users.conversations", "channels", func(cursor string) (map[string]any, error) {
+		return pages[cursor], nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &ListResult{
+		Items: []map[string]any{{"id": "C1"}, {"id": "C2"}, {"id": "C3"}},
+	}, got)
+}
+
+func TestListCursor_Truncated(t *testing.T) {
+	calls := 0
+	got, err := ListCursor("conversations.history", "messages", func(cursor string) (map[string]any, error) {
+		calls++
+		return map[string]any{
+			"messages":          []any{map[string]any{"ts": "1765611321.248519"}},
+			"response_metadata": map[string]any{"next_cursor": "more"},
+		}, nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, got.Truncated)
+	assert.Len(t, got.Items, MaxCursorPages)
+	assert.Equal(t, MaxCursorPages, calls)
+}
+
+func TestListCursor_Error(t *testing.T) {
+	_, err := ListCursor("conversations.history", "messages", func(cursor string) (map[string]any, error) {
+		return nil, &Error{Method: "conversations.history", Code: "ratelimited"}
+	})
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.EqualError(t, err, "failed to list conversations.history: Slack API error: conversations.history: ratelimited")
+}
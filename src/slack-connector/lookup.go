@@ -0,0 +1,27 @@
+package main
+
+import "net/url"
+
+// The lookups below are shared by the fetch and enrichment clients, which resolve the names of
+// channels, users and user groups mentioned in messages, the participants of DMs, and the
+// workspaces of an Enterprise Grid org.
+
+func fetchChannel(token, channelID string) (map[string]any, error) {
+	return slackGet(token, "conversations.info", url.Values{"channel": {channelID}})
+}
+
+func fetchUser(token, userID string) (map[string]any, error) {
+	return slackGet(token, "users.info", url.Values{"user": {userID}})
+}
+
+func fetchUserGroups(token string) (map[string]any, error) {
+	return slackGet(token, "usergroups.list", nil)
+}
+
+func fetchMembers(token, channelID string) (map[string]any, error) {
+	return slackGet(token, "conversations.members", url.Values{"channel": {channelID}, "limit": {"100"}})
+}
+
+func fetchTeam(token, teamID string) (map[string]any, error) {
+	return slackGet(token, "team.info", url.Values{"team": {teamID}})
+}
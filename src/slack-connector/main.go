@@ -3,9 +3,10 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"slack-connector/internal/core"
+	"slack-connector/internal/slackapi"
 
 	"github.com/extism/go-pdk"
 )
@@ -23,6 +24,13 @@ func GetConfigSchema() (ConfigSchema, error) {
 				{Key: "user_oauth_token", Name: "User OAuth Token", Secret: &secretTrue},
 			},
 		},
+		{
+			Id:          "oauth_web",
+			Type:        AuthMethodTypeOauthWeb,
+			Label:       "Slack OAuth",
+			Description: strPtr("Authenticate via Slack OAuth. Click Connect to open Slack in your browser and grant read access to your conversations. Rotating tokens are refreshed automatically."),
+			Fields:      []AuthField{},
+		},
 	}
 	return ConfigSchema{
 		Type: "object",
@@ -35,9 +43,100 @@ func GetConfigSchema() (ConfigSchema, error) {
 			"workspace_url": map[string]any{
 				"type":        "string",
 				"title":       "Workspace URL",
-				"description": "Your Slack workspace domain (e.g., your-workspace.slack.com)",
+				"description": "Your Slack workspace domain (e.g., your-workspace.slack.com). For an Enterprise Grid org, the org domain (e.g., your-org.enterprise.slack.com).",
 				"placeholder": "your-workspace.slack.com",
 			},
+			"team_ids": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "string",
+				},
+				"title":       "Workspace IDs (Enterprise Grid)",
+				"description": "With an org-level token of an Enterprise Grid org, the IDs of the workspaces to sync (e.g., T0123ABC). Each workspace gets its own source, and its channels and threads are kept apart from those of other workspaces. Workspace names and domains are looked up with the team:read scope; otherwise the workspace URL is used. Leave empty for a single workspace.",
+			},
+			"fetch_mode": map[string]any{
+				"type":        "string",
+				"title":       "Fetch Mode",
+				"description": "How messages are found. \"search\" uses search.messages (requires the search:read scope) and switches to \"history\" when the token may not search. \"history\" scans the history of every conversation you are a member of, which needs no search scope and sees messages immediately, but makes more API calls.",
+				"enum":        []string{"search", "history"},
+				"default":     "search",
+			},
+			"history_thread_lookback_days": map[string]any{
+				"type":        "integer",
+				"title":       "Thread Lookback Days (History Mode)",
+				"description": "In history mode, how many days before the target date to look for threads you may have replied to",
+				"default":     7,
+				"minimum":     0,
+			},
+			"included_channel_patterns": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "string",
+				},
+				"title":       "Channel Patterns",
+				"description": "Channel names to include (e.g., 'team-*', 'general'). Leave empty for all channels. Use * and ? for wildcards. DMs and group DMs are not matched by name; exclude them by type instead.",
+			},
+			"excluded_channel_patterns": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "string",
+				},
+				"title":       "Excluded Channel Patterns",
+				"description": "Channel names to exclude (e.g., 'random', 'alerts-*'). Exclusions override the channel patterns. Exact names are also left out of the search query.",
+			},
+			"excluded_conversation_types": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "string",
+					"enum": []string{"public_channel", "private_channel", "shared_channel", "im", "mpim"},
+				},
+				"title":       "Excluded Conversation Types",
+				"description": "Kinds of conversations to leave out: public and private channels, channels shared with other organizations (Slack Connect), DMs (im) and group DMs (mpim)",
+			},
+			"exclude_direct_messages": map[string]any{
+				"type":        "boolean",
+				"title":       "Exclude Direct Messages",
+				"description": "Leave out activities in DMs and group DMs. Same as excluding the im and mpim conversation types.",
+				"default":     false,
+			},
+			"include_reactions": map[string]any{
+				"type":        "boolean",
+				"title":       "Include Reactions",
+				"description": "Also report the reactions you added to messages posted on the target date (requires the reactions:read scope). Slack does not report when a reaction was added, so reactions are dated by the message and reactions to messages posted on other days are not reported.",
+				"default":     false,
+			},
+			"reaction_max_pages": map[string]any{
+				"type":        "integer",
+				"title":       "Reaction Pages",
+				"description": "How many pages of your most recent reactions to read. Raise it to find reactions when importing older dates.",
+				"default":     5,
+				"minimum":     1,
+			},
+			"include_file_shares": map[string]any{
+				"type":        "boolean",
+				"title":       "Include File Shares",
+				"description": "Also report the files you shared (requires the files:read scope)",
+				"default":     false,
+			},
+			"include_pins": map[string]any{
+				"type":        "boolean",
+				"title":       "Include Pins",
+				"description": "Also report the messages you pinned (requires the pins:read scope). Reads the pins of every conversation you are a member of.",
+				"default":     false,
+			},
+			"resolve_channel_names": map[string]any{
+				"type":        "boolean",
+				"title":       "Resolve Channel Names in Links",
+				"description": "Look up the names of channels linked from other sources through the Slack API. Otherwise linked channels are shown by ID until they are enriched.",
+				"default":     false,
+			},
+			"thread_digest_replies": map[string]any{
+				"type":        "integer",
+				"title":       "Thread Digest Replies",
+				"description": "How many of the latest replies to list in the description of a thread. Set to 0 to show the parent message only.",
+				"default":     3,
+				"minimum":     0,
+			},
 		},
 		Required: &[]string{
 			"user_id",
@@ -49,26 +148,6 @@ func GetConfigSchema() (ConfigSchema, error) {
 
 func strPtr(s string) *string { return &s }
 
-// BuildOAuthUrl is not supported by this connector.
-func BuildOAuthUrl(_ OAuthUrlRequest) (OAuthUrlResponse, error) {
-	return OAuthUrlResponse{}, fmt.Errorf("OAuth web flow is not supported by this connector")
-}
-
-// ExchangeOAuthCode is not supported by this connector.
-func ExchangeOAuthCode(_ OAuthCodeExchangeRequest) (OAuthTokenResponse, error) {
-	return OAuthTokenResponse{}, fmt.Errorf("OAuth web flow is not supported by this connector")
-}
-
-// StartDeviceFlow is not supported by this connector.
-func StartDeviceFlow() (DeviceFlowResponse, error) {
-	return DeviceFlowResponse{}, fmt.Errorf("OAuth device flow is not supported by this connector")
-}
-
-// PollDeviceToken is not supported by this connector.
-func PollDeviceToken(_ DeviceTokenRequest) (OAuthTokenResponse, error) {
-	return OAuthTokenResponse{}, fmt.Errorf("OAuth device flow is not supported by this connector")
-}
-
 // TestConnection tests the Slack API connection using the provided configuration
 func TestConnection(input TestConnectionRequest) error {
 	pdk.Log(pdk.LogInfo, "TestConnection: Starting Slack API connection test")
@@ -84,53 +163,39 @@ func TestConnection(input TestConnectionRequest) error {
 		return fmt.Errorf("invalid configuration format")
 	}
 
-	botToken, ok := config["user_oauth_token"].(string)
-	if !ok || botToken == "" {
-		return fmt.Errorf("user_oauth_token is required")
+	token, err := core.TokenFromConfig(config)
+	if err != nil {
+		return err
 	}
+	useAuthConfig(config)
 
-	url := fmt.Sprintf("%s/auth.test", core.SlackAPIBaseURL)
-
-	req := pdk.NewHTTPRequest(pdk.MethodPost, url)
-	req.SetHeader("Authorization", fmt.Sprintf("Bearer %s", botToken))
-	req.SetHeader("Content-Type", "application/x-www-form-urlencoded")
-
-	pdk.Log(pdk.LogInfo, fmt.Sprintf("Testing connection to: %s", url))
+	pdk.Log(pdk.LogInfo, fmt.Sprintf("Testing connection to: %s/auth.test", core.SlackAPIBaseURL))
 
-	res := req.Send()
-	statusCode := res.Status()
-
-	pdk.Log(pdk.LogInfo, fmt.Sprintf("Response status: %d", statusCode))
-
-	var authResponse struct {
-		Ok    bool   `json:"ok"`
-		Error string `json:"error"`
-		Team  string `json:"team"`
-	}
-
-	if err := json.Unmarshal(res.Body(), &authResponse); err != nil {
-		errorMsg := fmt.Sprintf("Failed to parse Slack API response: %v", err)
-		pdk.Log(pdk.LogError, errorMsg)
-		return fmt.Errorf("%s", errorMsg)
-	}
-
-	if authResponse.Ok {
+	_, err = slackGet(token, "auth.test", nil)
+	if err == nil {
 		pdk.Log(pdk.LogInfo, "Connection test successful")
 		return nil
 	}
 
 	var errorMsg string
-	switch authResponse.Error {
-	case "invalid_auth":
-		errorMsg = "Authentication failed: Invalid or expired Bot Token"
-	case "account_inactive":
-		errorMsg = "Authentication failed: Account is inactive"
-	case "token_revoked":
-		errorMsg = "Authentication failed: Token has been revoked"
-	case "no_permission":
-		errorMsg = "Authentication failed: Token lacks required permissions"
-	default:
-		errorMsg = fmt.Sprintf("Connection failed: %s", authResponse.Error)
+	var apiErr *slackapi.Error
+	if !errors.As(err, &apiErr) {
+		errorMsg = fmt.Sprintf("Connection failed: %v", err)
+	} else {
+		switch apiErr.Code {
+		case "invalid_auth":
+			errorMsg = "Authentication failed: Invalid or expired Bot Token"
+		case "account_inactive":
+			errorMsg = "Authentication failed: Account is inactive"
+		case "token_revoked":
+			errorMsg = "Authentication failed: Token has been revoked"
+		case "token_expired":
+			errorMsg = "Authentication failed: Token has expired, please reconnect via Slack (OAuth)"
+		case "no_permission":
+			errorMsg = "Authentication failed: Token lacks required permissions"
+		default:
+			errorMsg = fmt.Sprintf("Connection failed: %s", apiErr.Code)
+		}
 	}
 
 	pdk.Log(pdk.LogError, errorMsg)
@@ -145,9 +210,8 @@ func validateConfig(config any) error {
 		return fmt.Errorf("invalid configuration format")
 	}
 
-	botToken, ok := configMap["user_oauth_token"].(string)
-	if !ok || botToken == "" {
-		return fmt.Errorf("user_oauth_token is required")
+	if _, err := core.TokenFromConfig(configMap); err != nil {
+		return err
 	}
 
 	userID, ok := configMap["user_id"].(string)
@@ -160,15 +224,9 @@ func validateConfig(config any) error {
 		return fmt.Errorf("workspace_url is required")
 	}
 
-	return nil
-}
-
-// getStringValue safely extracts string value from map
-func getStringValue(m map[string]any, key string) string {
-	if val, ok := m[key]; ok {
-		if str, ok := val.(string); ok {
-			return str
-		}
+	if _, err := core.TeamIDsFromConfig(configMap); err != nil {
+		return err
 	}
-	return ""
+
+	return nil
 }
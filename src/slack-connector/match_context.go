@@ -1,14 +1,29 @@
 package main
 
-// MatchContext matches the provided URLs for the Slack connector.
-// Currently not supported: all URLs return empty context lists.
+import (
+	"fmt"
+	"slack-connector/internal/match"
+)
+
+// MatchContext matches the provided URLs against Slack permalinks and app links of the configured
+// workspace and returns context nodes. The context hierarchy is constructed from the URL alone
+// unless resolve_channel_names is enabled, in which case channel names are looked up.
 func MatchContext(input MatchContextRequest) (MatchContextResponse, error) {
+	config, _ := input.Config.(map[string]any)
+	useAuthConfig(config)
+	matcher, err := match.NewMatcher(&enrichHTTPClient{}, config, logger)
+	if err != nil {
+		return MatchContextResponse{}, fmt.Errorf("failed to create context matcher: %w", err)
+	}
+
 	results := make([]MatchContextResult, 0, len(input.Urls))
 	for _, url := range input.Urls {
+		coreContexts := matcher.MatchURL(url)
 		results = append(results, MatchContextResult{
 			Url:      url,
-			Contexts: []Context{},
+			Contexts: convertContexts(coreContexts),
 		})
 	}
+
 	return MatchContextResponse{Results: results}, nil
 }
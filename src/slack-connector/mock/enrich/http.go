@@ -54,17 +54,77 @@ func (mr *MockHTTPClientMockRecorder) FetchChannel(token, channelID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchChannel", reflect.TypeOf((*MockHTTPClient)(nil).FetchChannel), token, channelID)
 }
 
+// FetchMembers mocks base method.
+func (m *MockHTTPClient) FetchMembers(token, channelID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMembers", token, channelID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMembers indicates an expected call of FetchMembers.
+func (mr *MockHTTPClientMockRecorder) FetchMembers(token, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMembers", reflect.TypeOf((*MockHTTPClient)(nil).FetchMembers), token, channelID)
+}
+
+// FetchTeam mocks base method.
+func (m *MockHTTPClient) FetchTeam(token, teamID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTeam", token, teamID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTeam indicates an expected call of FetchTeam.
+func (mr *MockHTTPClientMockRecorder) FetchTeam(token, teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTeam", reflect.TypeOf((*MockHTTPClient)(nil).FetchTeam), token, teamID)
+}
+
 // FetchThread mocks base method.
-func (m *MockHTTPClient) FetchThread(token, channelID, threadTS string) (map[string]any, error) {
+func (m *MockHTTPClient) FetchThread(token, channelID, threadTS, cursor string) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchThread", token, channelID, threadTS)
+	ret := m.ctrl.Call(m, "FetchThread", token, channelID, threadTS, cursor)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchThread indicates an expected call of FetchThread.
-func (mr *MockHTTPClientMockRecorder) FetchThread(token, channelID, threadTS any) *gomock.Call {
+func (mr *MockHTTPClientMockRecorder) FetchThread(token, channelID, threadTS, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchThread", reflect.TypeOf((*MockHTTPClient)(nil).FetchThread), token, channelID, threadTS, cursor)
+}
+
+// FetchUser mocks base method.
+func (m *MockHTTPClient) FetchUser(token, userID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchUser", token, userID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchUser indicates an expected call of FetchUser.
+func (mr *MockHTTPClientMockRecorder) FetchUser(token, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUser", reflect.TypeOf((*MockHTTPClient)(nil).FetchUser), token, userID)
+}
+
+// FetchUserGroups mocks base method.
+func (m *MockHTTPClient) FetchUserGroups(token string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchUserGroups", token)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchUserGroups indicates an expected call of FetchUserGroups.
+func (mr *MockHTTPClientMockRecorder) FetchUserGroups(token any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchThread", reflect.TypeOf((*MockHTTPClient)(nil).FetchThread), token, channelID, threadTS)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUserGroups", reflect.TypeOf((*MockHTTPClient)(nil).FetchUserGroups), token)
 }
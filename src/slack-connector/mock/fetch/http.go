@@ -39,17 +39,182 @@ func (m *MockHTTPClient) EXPECT() *MockHTTPClientMockRecorder {
 	return m.recorder
 }
 
+// FetchChannel mocks base method.
+func (m *MockHTTPClient) FetchChannel(token, channelID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchChannel", token, channelID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchChannel indicates an expected call of FetchChannel.
+func (mr *MockHTTPClientMockRecorder) FetchChannel(token, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchChannel", reflect.TypeOf((*MockHTTPClient)(nil).FetchChannel), token, channelID)
+}
+
+// FetchFiles mocks base method.
+func (m *MockHTTPClient) FetchFiles(token, userID, teamID, tsFrom, tsTo string, page int) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchFiles", token, userID, teamID, tsFrom, tsTo, page)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchFiles indicates an expected call of FetchFiles.
+func (mr *MockHTTPClientMockRecorder) FetchFiles(token, userID, teamID, tsFrom, tsTo, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchFiles", reflect.TypeOf((*MockHTTPClient)(nil).FetchFiles), token, userID, teamID, tsFrom, tsTo, page)
+}
+
+// FetchHistory mocks base method.
+func (m *MockHTTPClient) FetchHistory(token, channelID, oldest, latest, cursor string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchHistory", token, channelID, oldest, latest, cursor)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchHistory indicates an expected call of FetchHistory.
+func (mr *MockHTTPClientMockRecorder) FetchHistory(token, channelID, oldest, latest, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchHistory", reflect.TypeOf((*MockHTTPClient)(nil).FetchHistory), token, channelID, oldest, latest, cursor)
+}
+
+// FetchMembers mocks base method.
+func (m *MockHTTPClient) FetchMembers(token, channelID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMembers", token, channelID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMembers indicates an expected call of FetchMembers.
+func (mr *MockHTTPClientMockRecorder) FetchMembers(token, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMembers", reflect.TypeOf((*MockHTTPClient)(nil).FetchMembers), token, channelID)
+}
+
 // FetchMessages mocks base method.
-func (m *MockHTTPClient) FetchMessages(token, userID, targetDate string, page int) (map[string]any, error) {
+func (m *MockHTTPClient) FetchMessages(token, query, teamID string, page int) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchMessages", token, userID, targetDate, page)
+	ret := m.ctrl.Call(m, "FetchMessages", token, query, teamID, page)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchMessages indicates an expected call of FetchMessages.
-func (mr *MockHTTPClientMockRecorder) FetchMessages(token, userID, targetDate, page any) *gomock.Call {
+func (mr *MockHTTPClientMockRecorder) FetchMessages(token, query, teamID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMessages", reflect.TypeOf((*MockHTTPClient)(nil).FetchMessages), token, query, teamID, page)
+}
+
+// FetchPins mocks base method.
+func (m *MockHTTPClient) FetchPins(token, channelID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchPins", token, channelID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchPins indicates an expected call of FetchPins.
+func (mr *MockHTTPClientMockRecorder) FetchPins(token, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPins", reflect.TypeOf((*MockHTTPClient)(nil).FetchPins), token, channelID)
+}
+
+// FetchReactions mocks base method.
+func (m *MockHTTPClient) FetchReactions(token, userID, teamID, cursor string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchReactions", token, userID, teamID, cursor)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchReactions indicates an expected call of FetchReactions.
+func (mr *MockHTTPClientMockRecorder) FetchReactions(token, userID, teamID, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchReactions", reflect.TypeOf((*MockHTTPClient)(nil).FetchReactions), token, userID, teamID, cursor)
+}
+
+// FetchReplies mocks base method.
+func (m *MockHTTPClient) FetchReplies(token, channelID, threadTS, oldest, latest, cursor string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchReplies", token, channelID, threadTS, oldest, latest, cursor)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchReplies indicates an expected call of FetchReplies.
+func (mr *MockHTTPClientMockRecorder) FetchReplies(token, channelID, threadTS, oldest, latest, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchReplies", reflect.TypeOf((*MockHTTPClient)(nil).FetchReplies), token, channelID, threadTS, oldest, latest, cursor)
+}
+
+// FetchTeam mocks base method.
+func (m *MockHTTPClient) FetchTeam(token, teamID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTeam", token, teamID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTeam indicates an expected call of FetchTeam.
+func (mr *MockHTTPClientMockRecorder) FetchTeam(token, teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTeam", reflect.TypeOf((*MockHTTPClient)(nil).FetchTeam), token, teamID)
+}
+
+// FetchUser mocks base method.
+func (m *MockHTTPClient) FetchUser(token, userID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchUser", token, userID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchUser indicates an expected call of FetchUser.
+func (mr *MockHTTPClientMockRecorder) FetchUser(token, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUser", reflect.TypeOf((*MockHTTPClient)(nil).FetchUser), token, userID)
+}
+
+// FetchUserConversations mocks base method.
+func (m *MockHTTPClient) FetchUserConversations(token, userID, teamID, cursor string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchUserConversations", token, userID, teamID, cursor)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchUserConversations indicates an expected call of FetchUserConversations.
+func (mr *MockHTTPClientMockRecorder) FetchUserConversations(token, userID, teamID, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUserConversations", reflect.TypeOf((*MockHTTPClient)(nil).FetchUserConversations), token, userID, teamID, cursor)
+}
+
+// FetchUserGroups mocks base method.
+func (m *MockHTTPClient) FetchUserGroups(token string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchUserGroups", token)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchUserGroups indicates an expected call of FetchUserGroups.
+func (mr *MockHTTPClientMockRecorder) FetchUserGroups(token any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMessages", reflect.TypeOf((*MockHTTPClient)(nil).FetchMessages), token, userID, targetDate, page)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUserGroups", reflect.TypeOf((*MockHTTPClient)(nil).FetchUserGroups), token)
 }
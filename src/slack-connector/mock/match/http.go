@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/match/http.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/match/http.go -destination mock/match/http.go
+//
+
+// Package mock_match is a generated GoMock package.
+package mock_match
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHTTPClient is a mock of HTTPClient interface.
+type MockHTTPClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockHTTPClientMockRecorder
+	isgomock struct{}
+}
+
+// MockHTTPClientMockRecorder is the mock recorder for MockHTTPClient.
+type MockHTTPClientMockRecorder struct {
+	mock *MockHTTPClient
+}
+
+// NewMockHTTPClient creates a new mock instance.
+func NewMockHTTPClient(ctrl *gomock.Controller) *MockHTTPClient {
+	mock := &MockHTTPClient{ctrl: ctrl}
+	mock.recorder = &MockHTTPClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHTTPClient) EXPECT() *MockHTTPClientMockRecorder {
+	return m.recorder
+}
+
+// FetchAuth mocks base method.
+func (m *MockHTTPClient) FetchAuth(token string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchAuth", token)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAuth indicates an expected call of FetchAuth.
+func (mr *MockHTTPClientMockRecorder) FetchAuth(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAuth", reflect.TypeOf((*MockHTTPClient)(nil).FetchAuth), token)
+}
+
+// FetchChannel mocks base method.
+func (m *MockHTTPClient) FetchChannel(token, channelID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchChannel", token, channelID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchChannel indicates an expected call of FetchChannel.
+func (mr *MockHTTPClientMockRecorder) FetchChannel(token, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchChannel", reflect.TypeOf((*MockHTTPClient)(nil).FetchChannel), token, channelID)
+}
+
+// FetchTeam mocks base method.
+func (m *MockHTTPClient) FetchTeam(token, teamID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTeam", token, teamID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTeam indicates an expected call of FetchTeam.
+func (mr *MockHTTPClientMockRecorder) FetchTeam(token, teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTeam", reflect.TypeOf((*MockHTTPClient)(nil).FetchTeam), token, teamID)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/render/http.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/render/http.go -destination mock/render/http.go
+//
+
+// Package mock_render is a generated GoMock package.
+package mock_render
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHTTPClient is a mock of HTTPClient interface.
+type MockHTTPClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockHTTPClientMockRecorder
+	isgomock struct{}
+}
+
+// MockHTTPClientMockRecorder is the mock recorder for MockHTTPClient.
+type MockHTTPClientMockRecorder struct {
+	mock *MockHTTPClient
+}
+
+// NewMockHTTPClient creates a new mock instance.
+func NewMockHTTPClient(ctrl *gomock.Controller) *MockHTTPClient {
+	mock := &MockHTTPClient{ctrl: ctrl}
+	mock.recorder = &MockHTTPClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHTTPClient) EXPECT() *MockHTTPClientMockRecorder {
+	return m.recorder
+}
+
+// FetchChannel mocks base method.
+func (m *MockHTTPClient) FetchChannel(token, channelID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchChannel", token, channelID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchChannel indicates an expected call of FetchChannel.
+func (mr *MockHTTPClientMockRecorder) FetchChannel(token, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchChannel", reflect.TypeOf((*MockHTTPClient)(nil).FetchChannel), token, channelID)
+}
+
+// FetchMembers mocks base method.
+func (m *MockHTTPClient) FetchMembers(token, channelID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMembers", token, channelID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMembers indicates an expected call of FetchMembers.
+func (mr *MockHTTPClientMockRecorder) FetchMembers(token, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMembers", reflect.TypeOf((*MockHTTPClient)(nil).FetchMembers), token, channelID)
+}
+
+// FetchUser mocks base method.
+func (m *MockHTTPClient) FetchUser(token, userID string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchUser", token, userID)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchUser indicates an expected call of FetchUser.
+func (mr *MockHTTPClientMockRecorder) FetchUser(token, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUser", reflect.TypeOf((*MockHTTPClient)(nil).FetchUser), token, userID)
+}
+
+// FetchUserGroups mocks base method.
+func (m *MockHTTPClient) FetchUserGroups(token string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchUserGroups", token)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchUserGroups indicates an expected call of FetchUserGroups.
+func (mr *MockHTTPClientMockRecorder) FetchUserGroups(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUserGroups", reflect.TypeOf((*MockHTTPClient)(nil).FetchUserGroups), token)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slack-connector/internal/core"
+	"slack-connector/internal/slackapi"
+	"strings"
+
+	"github.com/extism/go-pdk"
+)
+
+// slackAuthorizeURL is the authorization page of the Slack OAuth v2 flow
+const slackAuthorizeURL = "https://slack.com/oauth/v2/authorize"
+
+// SlackClientID is injected at build time via:
+//
+//	-ldflags "-X main.SlackClientID=<value>"
+//
+// Set SLACK_CLIENT_ID env var before building (see xtp.toml).
+var SlackClientID string
+
+// SlackClientSecret is injected at build time via:
+//
+//	-ldflags "-X main.SlackClientSecret=<value>"
+//
+// Set SLACK_CLIENT_SECRET env var before building (see xtp.toml).
+var SlackClientSecret string
+
+// State stored between BuildOAuthUrl and ExchangeOAuthCode calls.
+// Package-level vars persist across WASM calls within the same plugin instance.
+var oauthState string
+
+// oauthSession tracks token rotation within the plugin instance. refreshToken is set from the
+// configuration at each entry point; rotated maps an expired access token to the one that replaced
+// it, so that clients still holding the expired token use the new one.
+var oauthSession = struct {
+	refreshToken string
+	rotated      map[string]string
+}{
+	rotated: map[string]string{},
+}
+
+// useAuthConfig prepares token rotation for a call with the given configuration
+func useAuthConfig(cfg map[string]any) {
+	oauthSession.refreshToken = core.RefreshTokenFromConfig(cfg)
+}
+
+// BuildOAuthUrl builds the Slack OAuth v2 authorization URL requesting the user scopes of the
+// connector.
+func BuildOAuthUrl(input OAuthUrlRequest) (OAuthUrlResponse, error) {
+	if SlackClientID == "" {
+		return OAuthUrlResponse{}, fmt.Errorf("Slack OAuth is not configured in this build; use a User OAuth Token instead")
+	}
+
+	// Generate state: 16 random bytes → hex
+	stateBytes := make([]byte, 16)
+	if _, err := rand.Read(stateBytes); err != nil {
+		return OAuthUrlResponse{}, fmt.Errorf("failed to generate state: %w", err)
+	}
+	oauthState = hex.EncodeToString(stateBytes)
+
+	params := url.Values{}
+	params.Set("client_id", SlackClientID)
+	params.Set("user_scope", strings.Join(slackapi.UserScopes, ","))
+	params.Set("redirect_uri", input.RedirectUri)
+	params.Set("state", oauthState)
+
+	return OAuthUrlResponse{
+		State: oauthState,
+		Url:   slackAuthorizeURL + "?" + params.Encode(),
+	}, nil
+}
+
+// ExchangeOAuthCode exchanges the authorization code for a user token. When token rotation is
+// enabled for the Slack app, the refresh token and lifetime of the token are returned as well.
+func ExchangeOAuthCode(input OAuthCodeExchangeRequest) (OAuthTokenResponse, error) {
+	if oauthState != "" && input.State != oauthState {
+		return OAuthTokenResponse{}, fmt.Errorf("OAuth state mismatch")
+	}
+
+	body := url.Values{}
+	body.Set("code", input.Code)
+	body.Set("client_id", SlackClientID)
+	body.Set("client_secret", SlackClientSecret)
+	body.Set("redirect_uri", input.RedirectUri)
+
+	pdk.Log(pdk.LogInfo, "ExchangeOAuthCode: exchanging authorization code")
+	response, err := slackPost("oauth.v2.access", body)
+	if err != nil {
+		return OAuthTokenResponse{}, fmt.Errorf("token exchange failed: %w", err)
+	}
+	tokens, err := slackapi.UserTokens(response)
+	if err != nil {
+		return OAuthTokenResponse{}, err
+	}
+	pdk.Log(pdk.LogInfo, "ExchangeOAuthCode: token exchange successful")
+
+	result := OAuthTokenResponse{AccessToken: tokens.AccessToken}
+	if tokens.RefreshToken != "" {
+		result.RefreshToken = &tokens.RefreshToken
+	}
+	if tokens.ExpiresIn > 0 {
+		result.ExpiresIn = &tokens.ExpiresIn
+	}
+	return result, nil
+}
+
+// StartDeviceFlow is not supported by this connector.
+func StartDeviceFlow() (DeviceFlowResponse, error) {
+	return DeviceFlowResponse{}, fmt.Errorf("OAuth device flow is not supported by this connector; use oauth_web instead")
+}
+
+// PollDeviceToken is not supported by this connector.
+func PollDeviceToken(_ DeviceTokenRequest) (OAuthTokenResponse, error) {
+	return OAuthTokenResponse{}, fmt.Errorf("OAuth device flow is not supported by this connector; use oauth_web instead")
+}
+
+// refreshOAuthToken exchanges the refresh token for a new access token replacing expiredToken,
+// and persists the rotated tokens to the host keychain via the store_oauth_tokens host function.
+func refreshOAuthToken(expiredToken string) (string, error) {
+	body := url.Values{}
+	body.Set("grant_type", "refresh_token")
+	body.Set("refresh_token", oauthSession.refreshToken)
+	body.Set("client_id", SlackClientID)
+	body.Set("client_secret", SlackClientSecret)
+
+	response, err := slackPost("oauth.v2.access", body)
+	if err != nil {
+		return "", err
+	}
+	tokens, err := slackapi.UserTokens(response)
+	if err != nil {
+		return "", err
+	}
+
+	// Update in-memory tokens.
+	oauthSession.rotated[expiredToken] = tokens.AccessToken
+	if tokens.RefreshToken != "" {
+		oauthSession.refreshToken = tokens.RefreshToken
+	}
+
+	// Persist to host keychain.
+	payload := map[string]string{"access_token": tokens.AccessToken}
+	if oauthSession.refreshToken != "" {
+		payload["refresh_token"] = oauthSession.refreshToken
+	}
+	jsonBytes, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal token payload: %w", err)
+	}
+	storeOAuthTokens(string(jsonBytes))
+
+	return tokens.AccessToken, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slack-connector/internal/core"
+	"slack-connector/internal/slackapi"
+
+	"github.com/extism/go-pdk"
+)
+
+// slackGet calls a Slack Web API method. Empty parameters (e.g., the cursor of a first page) are
+// omitted. Responses with "ok": false are returned as *slackapi.Error, and warnings reported with
+// a response are logged. When a rotating OAuth token has expired, it is refreshed and the call is
+// retried once.
+func slackGet(token, method string, params url.Values) (map[string]any, error) {
+	for key, values := range params {
+		if len(values) == 1 && values[0] == "" {
+			params.Del(key)
+		}
+	}
+	endpoint := fmt.Sprintf("%s/%s", core.SlackAPIBaseURL, method)
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	if rotated, ok := oauthSession.rotated[token]; ok {
+		token = rotated
+	}
+
+	response, err := sendGet(token, method, endpoint)
+	if errors.Is(err, slackapi.ErrTokenExpired) && oauthSession.refreshToken != "" {
+		logger.Info("Refreshing token...")
+		token, err = refreshOAuthToken(token)
+		if err != nil {
+			return nil, fmt.Errorf("OAuth token expired and refresh failed: %w – please reconnect via Slack (OAuth)", err)
+		}
+		logger.Info("Refresh token completed")
+		// Retry with the new access token.
+		response, err = sendGet(token, method, endpoint)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, warning := range response.Warnings {
+		logger.Warn(fmt.Sprintf("Slack API warning from %s: %s", method, warning))
+	}
+
+	return response.Body, nil
+}
+
+// sendGet performs a single GET request with the given token
+func sendGet(token, method, endpoint string) (*slackapi.Response, error) {
+	logger.Debug(fmt.Sprintf("Calling %s", endpoint))
+
+	req := pdk.NewHTTPRequest(pdk.MethodGet, endpoint)
+	req.SetHeader("Authorization", "Bearer "+token)
+
+	res := req.Send()
+	return slackapi.Decode(method, int(res.Status()), res.Headers(), res.Body())
+}
+
+// slackPost calls a Slack Web API method that takes form data instead of a token, such as
+// oauth.v2.access
+func slackPost(method string, form url.Values) (map[string]any, error) {
+	endpoint := fmt.Sprintf("%s/%s", core.SlackAPIBaseURL, method)
+
+	req := pdk.NewHTTPRequest(pdk.MethodPost, endpoint)
+	req.SetHeader("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBody([]byte(form.Encode()))
+
+	res := req.Send()
+	response, err := slackapi.Decode(method, int(res.Status()), res.Headers(), res.Body())
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}